@@ -0,0 +1,69 @@
+package cmd
+
+// Copyright © 2019 Christian Weichel
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// jobApproveCmd represents the approve command
+var jobApproveCmd = &cobra.Command{
+	Use:   "approve [name]",
+	Short: "Approves a job which is awaiting approval",
+	Long: `Approves a job which is awaiting approval, which starts the job.
+Use --reject to reject the job instead, which fails it without running.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn := dial()
+		defer conn.Close()
+		client := v1.NewWerftServiceClient(conn)
+
+		name, localJobContext, err := getLocalJobName(client, args)
+		if err != nil {
+			return err
+		}
+		ctx, cancel, err := getRequestContext(localJobContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		comment, _ := cmd.Flags().GetString("comment")
+		if reject, _ := cmd.Flags().GetBool("reject"); reject {
+			_, err = client.RejectJob(ctx, &v1.RejectJobRequest{Name: name, Comment: comment})
+		} else {
+			_, err = client.ApproveJob(ctx, &v1.ApproveJobRequest{Name: name, Comment: comment})
+		}
+		if err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	jobCmd.AddCommand(jobApproveCmd)
+
+	jobApproveCmd.Flags().Bool("reject", false, "rejects the job instead of approving it")
+	jobApproveCmd.Flags().String("comment", "", "explains the decision, e.g. a link to the change request")
+}
@@ -28,6 +28,9 @@ import (
 var jobGetTpl = `Name:	{{ .Name }}
 Phase:	{{ .Phase }}
 Success:	{{ .Conditions.Success }}
+{{- if .Pinned }}
+Pinned:	true
+{{- end }}
 Metadata:
   Owner:	{{ .Metadata.Owner }}
   Trigger:	{{ .Metadata.Trigger }}
@@ -39,6 +42,16 @@ Repository:
   Repo:	{{ .Metadata.Repository.Repo }}
   Ref:	{{ .Metadata.Repository.Ref }}
   Revision:	{{ .Metadata.Repository.Revision }}
+{{- if .Approval }}
+Approval:
+{{- if .Conditions.AwaitingApproval }}
+  Awaiting approval
+{{- end }}
+{{- range .Approval.Decisions }}
+  {{ if .Approved }}Approved{{ else }}Rejected{{ end }} by {{ .User }}:	{{ .Time | toRFC3339 }}
+	{{ .Comment -}}
+{{ end -}}
+{{- end }}
 {{- if .Results }}
 Results:
 {{- range .Results }}
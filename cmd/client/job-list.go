@@ -34,7 +34,7 @@ import (
 var jobListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "Lists and searches for jobs",
-	Long: `Lists and searches for jobs using search expressions in the form of "<key><op><value>":
+	Long: `Lists and searches for jobs using filter expressions. Simple expressions take the form of "<key><op><value>":
 Available keys are:
   name        name of the job
   trigger     one of push, manual, unkown
@@ -45,30 +45,43 @@ Available keys are:
   repo.host   host of the source repository (e.g. github.com)
   repo.ref    source reference, i.e. branch name
   success     one of true, false
-  created     time the job started as RFC3339 date
+  created     time the job started as RFC3339 date, or relative to now (e.g. -24h, now+1h)
+  finished    time the job finished as RFC3339 date, or relative to now (e.g. -7d)
+  pinned      one of true, false
+  annotation.<key>  value of an annotation
+Any other field of a job can be used by its path, e.g. results.type, conditions.did_execute or spec_name.
 
 Available operators are:
   ==          checks for equality
   ~=          value must be contained in
-  |=		  starts with
+  |=          starts with
   =|          ends with
+  =~          matches regular expression
+  =*          matches glob pattern (* matches any sequence of characters, ? a single one)
+  <, <=       less than (or equal)
+  >, >=       greater than (or equal)
 
-Operators can be negated by prefixing them with !.
+Operators can be negated by prefixing them with !. Values containing spaces or operators must be quoted.
+Expressions can be combined using and, or, not and parentheses. "<key> in (a, b)" checks if the key is
+one of the listed values. Multiple arguments are combined using and.
 
 For example:
-  phase==running             finds all running jobs
-  owner!==webui              finds all jobs NOT owned by webui
-  repo.repo|=werft           finds all jobs on repositories whose names begin with werft
-  phase==done success==true  finds all successfully finished jobs
+  phase==running                              finds all running jobs
+  owner!==webui                               finds all jobs NOT owned by webui
+  repo.repo|=werft                            finds all jobs on repositories whose names begin with werft
+  phase==done success==true                   finds all successfully finished jobs
+  "created > -24h and not success==true"      finds all jobs from the last 24 hours that did not succeed
+  "owner in (foo, bar) or repo.ref|=refs/tags/"  finds all jobs owned by foo or bar, or running on a tag
 		`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		filterterms, err := filterexpr.Parse(args)
+		exprs := make([]string, len(args))
+		for i, arg := range args {
+			exprs[i] = "(" + arg + ")"
+		}
+		filter, err := filterexpr.ParseExpression(strings.Join(exprs, " and "))
 		if err != nil {
 			return err
 		}
-		filter := []*v1.FilterExpression{
-			{Terms: filterterms},
-		}
 
 		useLocalContext, _ := cmd.Flags().GetBool("local")
 		var localJobContext *v1.JobMetadata
@@ -0,0 +1,106 @@
+package cmd
+
+// Copyright © 2019 Christian Weichel
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io/ioutil"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var jobValidateTpl = `{{ if .Valid }}{{ .Name }} is valid{{ else }}{{ .Name }} is invalid{{ end }}
+{{- range .Diagnostics }}
+{{ .Severity }}	{{ .Stage }}:	{{ .Message }}
+{{- end }}
+{{ .PodSpec }}`
+
+// jobValidateCmd represents the validate command
+var jobValidateCmd = &cobra.Command{
+	Use:   "validate [<owner>/<repo>(:ref | @revision)]",
+	Short: "Validates a job without starting it",
+	Long: `Validates a job like "werft run github" would start it, and prints the rendered pod spec and all problems found.
+Without --job-file or --remote-job-path this validates the job the werft config of the repository would start.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		triggerName, _ := cmd.Flags().GetString("trigger")
+		md, err := getGitHubJobMetadata("", args, triggerName)
+		if err != nil {
+			return err
+		}
+		annotations, _ := cmd.Flags().GetStringToString("annotations")
+		for k, v := range annotations {
+			md.Annotations = append(md.Annotations, &v1.Annotation{
+				Key:   k,
+				Value: v,
+			})
+		}
+
+		req := &v1.StartGitHubJobRequest{Metadata: md}
+		req.JobPath, _ = cmd.Flags().GetString("remote-job-path")
+		if fn, _ := cmd.Flags().GetString("job-file"); fn != "" {
+			req.JobYaml, err = ioutil.ReadFile(fn)
+			if err != nil {
+				return err
+			}
+		}
+
+		conn := dial()
+		defer conn.Close()
+		client := v1.NewWerftServiceClient(conn)
+
+		ctx, cancel, err := getRequestContext(md)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		return validateJob(ctx, client, getGitHubValidateJobRequest(req))
+	},
+}
+
+// validateJob validates a job and prints the problems found and the rendered pod spec
+func validateJob(ctx context.Context, client v1.WerftServiceClient, req *v1.ValidateJobRequest) error {
+	resp, err := client.ValidateJob(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	err = prettyPrint(resp, jobValidateTpl)
+	if err != nil {
+		return err
+	}
+	if !resp.Valid {
+		return xerrors.Errorf("job is invalid")
+	}
+	return nil
+}
+
+func init() {
+	jobCmd.AddCommand(jobValidateCmd)
+
+	jobValidateCmd.Flags().StringP("job-file", "j", "", "location of the job file (defaults to the job the werft config of the repository starts)")
+	jobValidateCmd.Flags().String("remote-job-path", "", "validate the job at that path in the repo")
+	jobValidateCmd.Flags().StringToStringP("annotations", "a", map[string]string{}, "adds an annotation to the job")
+	jobValidateCmd.Flags().String("trigger", "manual", "job trigger. One of push, manual")
+}
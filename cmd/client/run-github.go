@@ -50,40 +50,13 @@ var runGithubCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		flags := cmd.Parent().PersistentFlags()
 		cwd, _ := flags.GetString("cwd")
-
-		var (
-			md  *v1.JobMetadata
-			err error
-		)
-		if len(args) == 0 {
-			md, err = getLocalJobContext(cwd, v1.JobTrigger_TRIGGER_MANUAL)
-		} else {
-			repo, err := reporef.Parse(args[0])
-			if err != nil {
-				return err
-			}
-			md = &v1.JobMetadata{
-				Owner:      repo.Owner,
-				Repository: repo,
-			}
-		}
+		triggerName, _ := flags.GetString("trigger")
+		md, err := getGitHubJobMetadata(cwd, args, triggerName)
 		if err != nil {
 			return err
 		}
 		addUserAnnotations(md)
 
-		triggerName, _ := flags.GetString("trigger")
-		trigger, ok := v1.JobTrigger_value[fmt.Sprintf("TRIGGER_%s", strings.ToUpper(triggerName))]
-		if !ok {
-			var vs []string
-			for k := range v1.JobTrigger_value {
-				vs = append(vs, strings.ToLower(strings.TrimPrefix("TRIGGER_", k)))
-			}
-
-			return xerrors.Errorf("Invalid value for --trigger. Valid choices are %s", strings.Join(vs, "\n"))
-		}
-		md.Trigger = v1.JobTrigger(trigger)
-
 		token, _ := cmd.Flags().GetString("token")
 		req := &v1.StartGitHubJobRequest{
 			Metadata:    md,
@@ -132,6 +105,9 @@ var runGithubCmd = &cobra.Command{
 			return err
 		}
 		defer cancel()
+		if dryRun, _ := flags.GetBool("dry-run"); dryRun {
+			return validateJob(ctx, client, getGitHubValidateJobRequest(req))
+		}
 		resp, err := client.StartGitHubJob(ctx, req)
 		if err != nil {
 			if status.Code(err) == codes.NotFound {
@@ -141,6 +117,9 @@ var runGithubCmd = &cobra.Command{
 			return err
 		}
 		fmt.Println(resp.Status.Name)
+		for _, j := range resp.AdditionalJobs {
+			fmt.Println(j.Name)
+		}
 
 		follow, _ := flags.GetBool("follow")
 		withPrefix, _ := flags.GetString("follow-with-prefix")
@@ -155,6 +134,63 @@ var runGithubCmd = &cobra.Command{
 	},
 }
 
+// getGitHubJobMetadata produces the metadata of a job on a remote repository, or the repository in cwd if args is empty
+func getGitHubJobMetadata(cwd string, args []string, triggerName string) (*v1.JobMetadata, error) {
+	var (
+		md  *v1.JobMetadata
+		err error
+	)
+	if len(args) == 0 {
+		md, err = getLocalJobContext(cwd, v1.JobTrigger_TRIGGER_MANUAL)
+	} else {
+		repo, err := reporef.Parse(args[0])
+		if err != nil {
+			return nil, err
+		}
+		md = &v1.JobMetadata{
+			Owner:      repo.Owner,
+			Repository: repo,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	trigger, ok := v1.JobTrigger_value[fmt.Sprintf("TRIGGER_%s", strings.ToUpper(triggerName))]
+	if !ok {
+		var vs []string
+		for k := range v1.JobTrigger_value {
+			vs = append(vs, strings.ToLower(strings.TrimPrefix("TRIGGER_", k)))
+		}
+
+		return nil, xerrors.Errorf("Invalid value for --trigger. Valid choices are %s", strings.Join(vs, "\n"))
+	}
+	md.Trigger = v1.JobTrigger(trigger)
+
+	return md, nil
+}
+
+// getGitHubValidateJobRequest validates the job a GitHub job request would start
+func getGitHubValidateJobRequest(req *v1.StartGitHubJobRequest) *v1.ValidateJobRequest {
+	if req.Metadata.Repository.Host == "" {
+		req.Metadata.Repository.Host = "github.com"
+	}
+
+	spec := &v1.JobSpec{
+		DirectSideload: req.Sideload,
+		NameSuffix:     req.NameSuffix,
+	}
+	if req.JobYaml != nil {
+		spec.Source = &v1.JobSpec_JobYaml{JobYaml: req.JobYaml}
+	} else {
+		spec.Source = &v1.JobSpec_JobPath{JobPath: req.JobPath}
+	}
+	return &v1.ValidateJobRequest{
+		Metadata: req.Metadata,
+		Spec:     spec,
+	}
+}
+
 func compileSideload(files []string) ([]byte, error) {
 	res := bytes.NewBuffer(nil)
 	gw := gzip.NewWriter(res)
@@ -44,6 +44,9 @@ var runLocalCmd = &cobra.Command{
 		if wu, _ := getWaitUntil(); wu != nil {
 			return xerrors.Errorf("--wait-until is not supported for local jobs")
 		}
+		if dryRun, _ := cmd.Parent().PersistentFlags().GetBool("dry-run"); dryRun {
+			return xerrors.Errorf("--dry-run is not supported for local jobs - use werft run github --dry-run --job-file instead")
+		}
 
 		flags := cmd.Parent().PersistentFlags()
 		workingdir, _ := cmd.Flags().GetString("cwd")
@@ -40,6 +40,9 @@ var runPreviousJobCmd = &cobra.Command{
 		if len(annotations) > 0 {
 			return fmt.Errorf("--annotation is not supported when replaying a previous job")
 		}
+		if dryRun, _ := flags.GetBool("dry-run"); dryRun {
+			return fmt.Errorf("--dry-run is not supported when replaying a previous job")
+		}
 
 		conn := dial()
 		defer conn.Close()
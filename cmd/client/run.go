@@ -152,5 +152,6 @@ func init() {
 	runCmd.PersistentFlags().BoolP("follow", "f", false, "follow the log output once the job is running")
 	runCmd.PersistentFlags().StringToStringP("annotations", "a", map[string]string{}, "adds an annotation to the job")
 	runCmd.PersistentFlags().String("follow-with-prefix", "", "prints the log output with a prefix and disbales colors - useful for starting jobs from within jobs")
+	runCmd.PersistentFlags().Bool("dry-run", false, "validates the job and prints the rendered pod spec instead of starting the job")
 	runCmd.PersistentFlags().String("wait-until", "", "delays the execution of the job by/until some time - use a valid duration (e.g. 5h) or RFC3339 timestamp")
 }
@@ -0,0 +1,105 @@
+package cmd
+
+// Copyright © 2019 Christian Weichel
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"io/ioutil"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// scheduleCreateCmd represents the schedule create command
+var scheduleCreateCmd = &cobra.Command{
+	Use:   "create <name> <cron> [<owner>/<repo>:ref]",
+	Short: "Creates a schedule which starts a job regularly",
+	Long: `Creates a schedule which starts a job regularly. The schedule uses a cron expression, e.g. "0 4 * * *"
+to start the job every day at 4am, or "@hourly" to start it every hour.
+
+Jobs always run on the latest revision of the ref. If no repository is given, the schedule
+starts jobs on the repository and branch in the current working directory.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cwd, _ := cmd.Flags().GetString("cwd")
+		triggerName, _ := cmd.Flags().GetString("trigger")
+		md, err := getGitHubJobMetadata(cwd, args[2:], triggerName)
+		if err != nil {
+			return err
+		}
+		if md.Repository.Host == "" {
+			md.Repository.Host = "github.com"
+		}
+		annotations, _ := cmd.Flags().GetStringToString("annotations")
+		for k, v := range annotations {
+			md.Annotations = append(md.Annotations, &v1.Annotation{Key: k, Value: v})
+		}
+
+		spec := &v1.JobSpec{}
+		jobPath, _ := cmd.Flags().GetString("remote-job-path")
+		if fn, _ := cmd.Flags().GetString("job-file"); fn != "" {
+			fc, err := ioutil.ReadFile(fn)
+			if err != nil {
+				return err
+			}
+			spec.Source = &v1.JobSpec_JobYaml{JobYaml: fc}
+		} else {
+			spec.Source = &v1.JobSpec_JobPath{JobPath: jobPath}
+		}
+
+		paused, _ := cmd.Flags().GetBool("paused")
+		req := &v1.CreateScheduleRequest{
+			Schedule: &v1.Schedule{
+				Name:     args[0],
+				Cron:     args[1],
+				Metadata: md,
+				Spec:     spec,
+				Paused:   paused,
+			},
+		}
+
+		conn := dial()
+		defer conn.Close()
+		client := v1.NewWerftServiceClient(conn)
+
+		ctx, cancel, err := getRequestContext(md)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		resp, err := client.CreateSchedule(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		return prettyPrint(resp.Schedule, scheduleTpl)
+	},
+}
+
+func init() {
+	scheduleCmd.AddCommand(scheduleCreateCmd)
+
+	scheduleCreateCmd.Flags().String("cwd", ".", "working directory")
+	scheduleCreateCmd.Flags().String("trigger", "manual", "job trigger. One of push, manual")
+	scheduleCreateCmd.Flags().StringToStringP("annotations", "a", map[string]string{}, "adds an annotation to the jobs")
+	scheduleCreateCmd.Flags().String("remote-job-path", "", "path to the job in the repository. Defaults to the job the repository config selects")
+	scheduleCreateCmd.Flags().StringP("job-file", "j", "", "job file to run instead of a job in the repository")
+	scheduleCreateCmd.Flags().Bool("paused", false, "creates the schedule paused")
+}
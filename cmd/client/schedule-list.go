@@ -0,0 +1,58 @@
+package cmd
+
+// Copyright © 2019 Christian Weichel
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// scheduleListCmd represents the schedule list command
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists all schedules including their next and last run",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn := dial()
+		defer conn.Close()
+		client := v1.NewWerftServiceClient(conn)
+
+		ctx, cancel, err := getRequestContext(nil)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		resp, err := client.ListSchedules(ctx, &v1.ListSchedulesRequest{})
+		if err != nil {
+			return err
+		}
+
+		return prettyPrint(resp, `NAME	CRON	REPO	PAUSED	NEXT RUN	LAST RUN	LAST JOB
+{{- range .Schedules }}
+{{ .Name }}	{{ .Cron }}	{{ .Metadata.Repository.Owner }}/{{ .Metadata.Repository.Repo }}:{{ .Metadata.Repository.Ref }}	{{ .Paused }}	{{ .NextRun | toRFC3339 }}	{{ if .LastRun }}{{ .LastRun | toRFC3339 }}{{ else }}never{{ end }}	{{ .LastJob }}{{ .LastError -}}
+{{ end }}
+`)
+	},
+}
+
+func init() {
+	scheduleCmd.AddCommand(scheduleListCmd)
+}
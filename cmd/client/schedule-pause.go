@@ -0,0 +1,60 @@
+package cmd
+
+// Copyright © 2019 Christian Weichel
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/spf13/cobra"
+)
+
+// schedulePauseCmd represents the schedule pause command
+var schedulePauseCmd = &cobra.Command{
+	Use:   "pause <name>",
+	Short: "Pauses a schedule",
+	Long: `Pauses a schedule, i.e. it does not start jobs until it's resumed using --resume.
+Resumed schedules do not start the jobs they missed while they were paused.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn := dial()
+		defer conn.Close()
+		client := v1.NewWerftServiceClient(conn)
+
+		ctx, cancel, err := getRequestContext(nil)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		resume, _ := cmd.Flags().GetBool("resume")
+		resp, err := client.PauseSchedule(ctx, &v1.PauseScheduleRequest{Name: args[0], Paused: !resume})
+		if err != nil {
+			return err
+		}
+
+		return prettyPrint(resp.Schedule, scheduleTpl)
+	},
+}
+
+func init() {
+	scheduleCmd.AddCommand(schedulePauseCmd)
+
+	schedulePauseCmd.Flags().Bool("resume", false, "resumes a paused schedule")
+}
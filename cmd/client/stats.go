@@ -0,0 +1,109 @@
+package cmd
+
+// Copyright © 2019 Christian Weichel
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strings"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/filterexpr"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats [filter expression...]",
+	Short: "Prints job statistics per repository, branch and job spec",
+	Long: `Prints the run count, success rate, duration percentiles and mean time to recovery (MTTR) of finished jobs,
+grouped by repository, branch and job spec. Jobs can be restricted using the same filter expressions as "werft job list".
+
+The MTTR is the mean time between the first failed job of a sequence of failures and the next successful job.
+
+For example:
+  werft stats                                 statistics of all jobs of the last seven days
+  werft stats --since -30d repo.repo==werft   statistics of the werft repository over the last 30 days
+  werft stats "repo.ref =* refs/heads/release/*"  statistics of all release branches
+		`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exprs := make([]string, len(args))
+		for i, arg := range args {
+			exprs[i] = "(" + arg + ")"
+		}
+		filter, err := filterexpr.ParseExpression(strings.Join(exprs, " and "))
+		if err != nil {
+			return err
+		}
+
+		var req v1.GetStatisticsRequest
+		req.Filter = filter
+		if since, _ := cmd.Flags().GetString("since"); since != "" {
+			t, err := filterexpr.ParseTime(since)
+			if err != nil {
+				return xerrors.Errorf("invalid --since: %w", err)
+			}
+			req.From, err = ptypes.TimestampProto(t)
+			if err != nil {
+				return err
+			}
+		}
+		if until, _ := cmd.Flags().GetString("until"); until != "" {
+			t, err := filterexpr.ParseTime(until)
+			if err != nil {
+				return xerrors.Errorf("invalid --until: %w", err)
+			}
+			req.To, err = ptypes.TimestampProto(t)
+			if err != nil {
+				return err
+			}
+		}
+
+		conn := dial()
+		defer conn.Close()
+		client := v1.NewWerftServiceClient(conn)
+
+		ctx, cancel, err := getRequestContext(nil)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		resp, err := client.GetStatistics(ctx, &req)
+		if err != nil {
+			return err
+		}
+
+		return prettyPrint(resp, `REPO	REF	JOBSPEC	RUNS	SUCCESS	P50	P95	MTTR
+{{- range .Statistics }}
+{{ .Repository.Host }}/{{ .Repository.Owner }}/{{ .Repository.Repo }}	{{ .Repository.Ref }}	{{ .JobSpecName }}	{{ .RunCount }}	{{ toPercent .SuccessRate }}	{{ toDuration .DurationP50Seconds }}	{{ toDuration .DurationP95Seconds }}	{{ if .RecoveryCount }}{{ toDuration .MttrSeconds }}{{ else }}-{{ end -}}
+{{ end }}
+`)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().String("since", "-7d", "only consider jobs created after this time (RFC3339 date or relative to now, e.g. -24h)")
+	statsCmd.Flags().String("until", "", "only consider jobs created before this time (RFC3339 date or relative to now, e.g. -24h)")
+	statsCmd.Flags().StringVarP(&outputFormat, "output-format", "o", "template", "selects the output format: string, json, yaml, template")
+	statsCmd.Flags().StringVar(&outputTemplate, "output-template", "", "template to use in combination with --output-format template")
+}
@@ -0,0 +1,102 @@
+package cmd
+
+// Copyright © 2019 Christian Weichel
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/csweichel/werft/pkg/store/archive"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export <config.yaml> <archive.tar>",
+	Short: "Exports all jobs, job specs, number groups, schedules and logs to a tar archive",
+	Long: `Exports all jobs, job specs, number groups, schedules and logs to a tar archive which can be restored using "import".
+Use - as archive name to write to stdout. Archives whose name ends with .gz or .tgz are gzip-compressed.
+
+Logs of running jobs cannot be exported consistently, hence werft should be stopped while exporting.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(args[0])
+		if err != nil {
+			return err
+		}
+		stores, err := openArchiveStores(cfg)
+		if err != nil {
+			return err
+		}
+
+		var out io.WriteCloser = os.Stdout
+		if fn := args[1]; fn != "-" {
+			f, err := os.Create(fn)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+
+			if isGzipArchive(fn) {
+				gz := gzip.NewWriter(f)
+				defer gz.Close()
+				out = gz
+			}
+		}
+
+		err = archive.Export(context.Background(), out, stores)
+		if err != nil {
+			return err
+		}
+		return out.Close()
+	},
+}
+
+// openArchiveStores opens the stores configured for the server for export and import
+func openArchiveStores(cfg Config) (archive.Stores, error) {
+	jobStore, nrGroups, schedules, err := openJobStore(cfg)
+	if err != nil {
+		return archive.Stores{}, err
+	}
+	logStore, err := store.NewFileLogStore(cfg.Storage.LogStore)
+	if err != nil {
+		return archive.Stores{}, err
+	}
+	return archive.Stores{
+		Jobs:      jobStore,
+		Logs:      logStore,
+		Groups:    nrGroups,
+		Schedules: schedules,
+	}, nil
+}
+
+func isGzipArchive(fn string) bool {
+	return strings.HasSuffix(fn, ".gz") || strings.HasSuffix(fn, ".tgz")
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+}
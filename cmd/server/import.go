@@ -0,0 +1,78 @@
+package cmd
+
+// Copyright © 2019 Christian Weichel
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+
+	"github.com/csweichel/werft/pkg/store/archive"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <config.yaml> <archive.tar>",
+	Short: "Imports jobs, job specs, number groups, schedules and logs from an archive produced by export",
+	Long: `Imports jobs, job specs, number groups, schedules and logs from an archive produced by "export".
+Use - as archive name to read from stdin. Archives whose name ends with .gz or .tgz are expected to be gzip-compressed.
+
+Jobs, job specs and schedules which exist already are overwritten, existing logs are kept.
+Because export and import only use the store interfaces, they can be used to migrate between Postgres and SQLite.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(args[0])
+		if err != nil {
+			return err
+		}
+		stores, err := openArchiveStores(cfg)
+		if err != nil {
+			return err
+		}
+
+		var in io.Reader = os.Stdin
+		if fn := args[1]; fn != "-" {
+			f, err := os.Open(fn)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+
+			if isGzipArchive(fn) {
+				gz, err := gzip.NewReader(f)
+				if err != nil {
+					return err
+				}
+				defer gz.Close()
+				in = gz
+			}
+		}
+
+		return archive.Import(context.Background(), in, stores)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
@@ -36,6 +36,7 @@ import (
 	"os/signal"
 	"runtime"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -47,6 +48,7 @@ import (
 	plugin "github.com/csweichel/werft/pkg/plugin/host"
 	"github.com/csweichel/werft/pkg/store"
 	"github.com/csweichel/werft/pkg/store/postgres"
+	"github.com/csweichel/werft/pkg/store/sqlite"
 	"github.com/csweichel/werft/pkg/version"
 	"github.com/csweichel/werft/pkg/werft"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
@@ -74,48 +76,12 @@ var runCmd = &cobra.Command{
 			log.SetLevel(log.DebugLevel)
 		}
 
-		fc, err := ioutil.ReadFile(args[0])
+		cfg, err := loadConfig(args[0])
 		if err != nil {
 			return err
 		}
 
-		var cfg Config
-		err = yaml.Unmarshal(fc, &cfg)
-		if err != nil {
-			return err
-		}
-
-		log.Info("connecting to database")
-		db, err := sql.Open("postgres", cfg.Storage.JobStore)
-		if err != nil {
-			return err
-		}
-		maxConns := 10
-		maxIdleConns := 2
-		if cfg.Storage.JobStoreMaxConnections > 0 {
-			maxConns = cfg.Storage.JobStoreMaxConnections
-		}
-		if cfg.Storage.JobStoreMaxIdleConnections > 0 {
-			maxIdleConns = cfg.Storage.JobStoreMaxIdleConnections
-		}
-		log.WithField("maxOpenConns", maxConns).WithField("maxIdleConns", maxIdleConns).Debug("setting max open connections on job store DB")
-		db.SetMaxOpenConns(maxConns)
-		db.SetMaxIdleConns(maxIdleConns)
-		err = db.Ping()
-		if err != nil {
-			return err
-		}
-
-		log.Info("making sure database schema is up to date")
-		err = postgres.Migrate(db)
-		if err != nil {
-			return err
-		}
-		jobStore, err := postgres.NewJobStore(db)
-		if err != nil {
-			return err
-		}
-		nrGroups, err := postgres.NewNumberGroup(db)
+		jobStore, nrGroups, schedules, err := openJobStore(cfg)
 		if err != nil {
 			return err
 		}
@@ -154,6 +120,7 @@ var runCmd = &cobra.Command{
 			Logs:               logStore,
 			Jobs:               jobStore,
 			Groups:             nrGroups,
+			Schedules:          schedules,
 			Executor:           exec,
 			Cutter:             logcutter.DefaultCutter,
 			Config:             cfg.Werft,
@@ -284,6 +251,99 @@ var runCmd = &cobra.Command{
 	},
 }
 
+// loadConfig reads the werft server configuration from a YAML file
+func loadConfig(fn string) (Config, error) {
+	var cfg Config
+	fc, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return cfg, err
+	}
+	err = yaml.Unmarshal(fc, &cfg)
+	return cfg, err
+}
+
+// metricsJobStore is a store.Jobs which exposes Prometheus metrics
+type metricsJobStore interface {
+	store.Jobs
+	RegisterPrometheusMetrics(reg prometheus.Registerer)
+}
+
+// openJobStore opens the job store, number groups and schedule store configured by the jobsConnectionString.
+// Connection strings starting with sqlite:// use an SQLite database file, e.g. sqlite:///var/werft/jobs.db.
+// All other connection strings are passed on to Postgres.
+func openJobStore(cfg Config) (metricsJobStore, store.NumberGroup, store.Schedules, error) {
+	if fn := strings.TrimPrefix(cfg.Storage.JobStore, "sqlite://"); fn != cfg.Storage.JobStore {
+		log.WithField("path", fn).Info("opening SQLite database")
+		db, err := sqlite.Open(fn)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		err = db.Ping()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		log.Info("making sure database schema is up to date")
+		err = sqlite.Migrate(db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		jobStore, err := sqlite.NewJobStore(db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		nrGroups, err := sqlite.NewNumberGroup(db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		schedules, err := sqlite.NewScheduleStore(db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return jobStore, nrGroups, schedules, nil
+	}
+
+	log.Info("connecting to database")
+	db, err := sql.Open("postgres", cfg.Storage.JobStore)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	maxConns := 10
+	maxIdleConns := 2
+	if cfg.Storage.JobStoreMaxConnections > 0 {
+		maxConns = cfg.Storage.JobStoreMaxConnections
+	}
+	if cfg.Storage.JobStoreMaxIdleConnections > 0 {
+		maxIdleConns = cfg.Storage.JobStoreMaxIdleConnections
+	}
+	log.WithField("maxOpenConns", maxConns).WithField("maxIdleConns", maxIdleConns).Debug("setting max open connections on job store DB")
+	db.SetMaxOpenConns(maxConns)
+	db.SetMaxIdleConns(maxIdleConns)
+	err = db.Ping()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	log.Info("making sure database schema is up to date")
+	err = postgres.Migrate(db)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	jobStore, err := postgres.NewJobStore(db)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	nrGroups, err := postgres.NewNumberGroup(db)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	schedules, err := postgres.NewScheduleStore(db)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return jobStore, nrGroups, schedules, nil
+}
+
 type startWebOpts struct {
 	DebugProxy  string
 	ReadOpsOnly bool
@@ -327,7 +387,14 @@ func startWeb(service *werft.Service, uiservice v1.WerftUIServer, addr string, o
 			case "/v1.WerftService/StartLocalJob",
 				"/v1.WerftService/StartGitHubJob",
 				"/v1.WerftService/StartFromPreviousJob",
-				"/v1.WerftService/StopJob":
+				"/v1.WerftService/StopJob",
+				"/v1.WerftService/PinJob",
+				"/v1.WerftService/UnpinJob",
+				"/v1.WerftService/CreateSchedule",
+				"/v1.WerftService/DeleteSchedule",
+				"/v1.WerftService/PauseSchedule",
+				"/v1.WerftService/ApproveJob",
+				"/v1.WerftService/RejectJob":
 				return nil, status.Error(codes.Unauthenticated, "Werft installation is read-only")
 			}
 
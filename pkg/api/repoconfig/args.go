@@ -0,0 +1,168 @@
+package repoconfig
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+)
+
+// ArgType is the type of a job argument
+type ArgType string
+
+const (
+	// ArgTypeString arguments accept any value
+	ArgTypeString ArgType = "string"
+	// ArgTypeBool arguments accept true or false. Annotations without value, e.g. "/werft foo", count as true.
+	ArgTypeBool ArgType = "bool"
+	// ArgTypeInt arguments accept integers
+	ArgTypeInt ArgType = "int"
+	// ArgTypeEnum arguments accept one of the argument's values
+	ArgTypeEnum ArgType = "enum"
+)
+
+// Validate checks if value is a valid value for this argument
+func (a ArgSpec) Validate(value string) error {
+	switch a.Type {
+	case "", ArgTypeString:
+	case ArgTypeBool:
+		if value == "" {
+			break
+		}
+		if _, err := strconv.ParseBool(value); err != nil {
+			return xerrors.Errorf("%s must be true or false, not \"%s\"", a.Name, value)
+		}
+	case ArgTypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return xerrors.Errorf("%s must be an integer, not \"%s\"", a.Name, value)
+		}
+	case ArgTypeEnum:
+		var found bool
+		for _, v := range a.Values {
+			if v == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return xerrors.Errorf("%s must be one of %s, not \"%s\"", a.Name, strings.Join(a.Values, ", "), value)
+		}
+	default:
+		return xerrors.Errorf("%s has unknown type %s", a.Name, a.Type)
+	}
+
+	if a.Pattern != "" {
+		re, err := regexp.Compile("^(?:" + a.Pattern + ")$")
+		if err != nil {
+			return xerrors.Errorf("%s has invalid pattern: %w", a.Name, err)
+		}
+		if !re.MatchString(value) {
+			return xerrors.Errorf("%s must match %s, not \"%s\"", a.Name, a.Pattern, value)
+		}
+	}
+
+	return nil
+}
+
+// ValidateArgs checks the annotations of a job against the arguments its job spec declares.
+// Missing annotations are checked using the argument's default value.
+func ValidateArgs(args []ArgSpec, annotations map[string]string) error {
+	var errs []string
+	for _, a := range args {
+		value, ok := annotations[a.Name]
+		if !ok && a.Default == "" {
+			if a.Req {
+				errs = append(errs, fmt.Sprintf("%s is required", a.Name))
+			}
+			continue
+		}
+		if !ok {
+			value = a.Default
+		}
+
+		err := a.Validate(value)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return xerrors.Errorf("invalid job arguments: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ApplyArgDefaults adds the default value of all arguments which are not present in annotations
+func ApplyArgDefaults(args []ArgSpec, annotations map[string]string) {
+	for _, a := range args {
+		if _, ok := annotations[a.Name]; ok || a.Default == "" {
+			continue
+		}
+		annotations[a.Name] = a.Default
+	}
+}
+
+// ParseArgs extracts the arguments from a job spec prior to rendering it.
+// Job specs are Go templates and not necessarily valid YAML before they're rendered.
+// If that's the case, only the top-level args of the job spec are parsed.
+func ParseArgs(jobYAML []byte) ([]ArgSpec, error) {
+	var spec struct {
+		Args []ArgSpec `yaml:"args"`
+	}
+	err := parseTopLevel(jobYAML, "args", &spec)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse job args: %w", err)
+	}
+	return spec.Args, nil
+}
+
+// ParsePlugins extracts the plugin configuration from a job spec prior to rendering it, like ParseArgs does.
+func ParsePlugins(jobYAML []byte) (map[string]string, error) {
+	var spec struct {
+		Plugins map[string]string `yaml:"plugins"`
+	}
+	err := parseTopLevel(jobYAML, "plugins", &spec)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse job plugins: %w", err)
+	}
+	return spec.Plugins, nil
+}
+
+// parseTopLevel unmarshals a YAML document into out. If the document is not valid YAML,
+// only the top-level field key is unmarshalled.
+func parseTopLevel(doc []byte, key string, out interface{}) error {
+	err := yaml.Unmarshal(doc, out)
+	if err == nil {
+		return nil
+	}
+
+	blk := topLevelBlock(string(doc), key)
+	if blk == "" {
+		return nil
+	}
+	return yaml.Unmarshal([]byte(blk), out)
+}
+
+// topLevelBlock finds a top-level field of a YAML document
+func topLevelBlock(doc, key string) string {
+	var (
+		res strings.Builder
+		in  bool
+	)
+	for _, l := range strings.Split(doc, "\n") {
+		if !in {
+			if strings.HasPrefix(l, key+":") {
+				in = true
+				res.WriteString(l + "\n")
+			}
+			continue
+		}
+		if l != "" && !strings.HasPrefix(l, " ") && !strings.HasPrefix(l, "-") && !strings.HasPrefix(l, "#") {
+			break
+		}
+		res.WriteString(l + "\n")
+	}
+	return res.String()
+}
@@ -0,0 +1,87 @@
+package repoconfig_test
+
+import (
+	"testing"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateArgs(t *testing.T) {
+	args := []repoconfig.ArgSpec{
+		{Name: "publish", Type: repoconfig.ArgTypeBool},
+		{Name: "replicas", Type: repoconfig.ArgTypeInt, Default: "1"},
+		{Name: "env", Type: repoconfig.ArgTypeEnum, Values: []string{"staging", "prod"}},
+		{Name: "version", Pattern: `v\d+\.\d+`},
+		{Name: "target", Req: true},
+	}
+	tests := []struct {
+		Name        string
+		Annotations map[string]string
+		Expectation string
+	}{
+		{Name: "valid", Annotations: map[string]string{"target": "all", "publish": "true", "replicas": "3", "env": "prod", "version": "v1.2"}},
+		{Name: "flag bool", Annotations: map[string]string{"target": "all", "publish": ""}},
+		{Name: "missing required", Expectation: "invalid job arguments: target is required"},
+		{Name: "invalid bool", Annotations: map[string]string{"target": "all", "publish": "yes please"}, Expectation: `invalid job arguments: publish must be true or false, not "yes please"`},
+		{Name: "invalid int", Annotations: map[string]string{"target": "all", "replicas": "many"}, Expectation: `invalid job arguments: replicas must be an integer, not "many"`},
+		{Name: "invalid enum", Annotations: map[string]string{"target": "all", "env": "dev"}, Expectation: `invalid job arguments: env must be one of staging, prod, not "dev"`},
+		{Name: "pattern matches whole value", Annotations: map[string]string{"target": "all", "version": "v1.2-beta"}, Expectation: `invalid job arguments: version must match v\d+\.\d+, not "v1.2-beta"`},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var act string
+			err := repoconfig.ValidateArgs(args, test.Annotations)
+			if err != nil {
+				act = err.Error()
+			}
+			if act != test.Expectation {
+				t.Errorf("expected %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Expectation []repoconfig.ArgSpec
+	}{
+		{Name: "no args", Input: "pod: {}"},
+		{
+			Name:        "valid YAML",
+			Input:       "args:\n- name: foo\n  type: int\n  default: \"1\"\npod: {}",
+			Expectation: []repoconfig.ArgSpec{{Name: "foo", Type: repoconfig.ArgTypeInt, Default: "1"}},
+		},
+		{
+			Name: "template",
+			Input: `pod:
+  containers:
+  {{- if .Annotations.foo }}
+  - name: foo
+  {{- end }}
+args:
+# the foo arg
+- name: foo
+  type: enum
+  values:
+  - a
+  - b
+sidecars: []
+`,
+			Expectation: []repoconfig.ArgSpec{{Name: "foo", Type: repoconfig.ArgTypeEnum, Values: []string{"a", "b"}}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := repoconfig.ParseArgs([]byte(test.Input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("ParseArgs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,28 +1,53 @@
 package repoconfig
 
 import (
+	"regexp"
+	"strings"
+
 	werftv1 "github.com/csweichel/werft/pkg/api/v1"
 	"github.com/csweichel/werft/pkg/filterexpr"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// AnnotationChangedFilesBase names the revision the changed files of a job are computed against,
+// e.g. the revision before a push or the base of a pull request. If this annotation is not present,
+// the parent of the job's revision is used.
+const AnnotationChangedFilesBase = "changedFilesBase"
+
 // C is the struct we expect to find in the repo root which configures how we build things
 type C struct {
 	DefaultJob string          `yaml:"defaultJob"`
 	Rules      []*JobStartRule `yaml:"rules"`
+
+	// CancelSuperseded stops the running jobs of a ref and job spec when a newer job for them is started.
+	// If this is not set, the server configuration applies.
+	CancelSuperseded *bool `yaml:"cancelSuperseded,omitempty" json:",omitempty"`
+
+	// StartAllMatching starts a job for every matching rule instead of the first one only.
+	// The default job is started if no rule matches.
+	StartAllMatching bool `yaml:"startAllMatching,omitempty" json:",omitempty"`
 }
 
 // JobStartRule determines if a job will be started
 type JobStartRule struct {
 	Path string                      `yaml:"path"`
 	Expr []*werftv1.FilterExpression `yaml:"matchesAll"`
+
+	// Paths and PathsIgnore are globs which restrict the rule to changes of particular files.
+	// See MatchesChangedFiles for details.
+	Paths       []string `yaml:"paths,omitempty" json:",omitempty"`
+	PathsIgnore []string `yaml:"pathsIgnore,omitempty" json:",omitempty"`
 }
 
-// UnmarshalYAML unmarshals the filter expressions
+// UnmarshalYAML unmarshals the filter expressions. Filters can be expressed using matchesAll,
+// matches (a filter expression, see filterexpr.ParseExpression) or both, in which case both have to match.
 func (r *JobStartRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var rawJobStartRule struct {
-		Path string           `yaml:"path"`
-		Expr []JobStartRuleOr `yaml:"matchesAll"`
+		Path        string           `yaml:"path"`
+		Expr        []JobStartRuleOr `yaml:"matchesAll"`
+		Matches     string           `yaml:"matches"`
+		Paths       []string         `yaml:"paths"`
+		PathsIgnore []string         `yaml:"pathsIgnore"`
 	}
 	err := unmarshal(&rawJobStartRule)
 	if err != nil {
@@ -30,6 +55,8 @@ func (r *JobStartRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 
 	r.Path = rawJobStartRule.Path
+	r.Paths = rawJobStartRule.Paths
+	r.PathsIgnore = rawJobStartRule.PathsIgnore
 	for _, expr := range rawJobStartRule.Expr {
 		terms, err := filterexpr.Parse(expr.Or)
 		if err != nil {
@@ -37,6 +64,11 @@ func (r *JobStartRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 		r.Expr = append(r.Expr, &werftv1.FilterExpression{Terms: terms})
 	}
+	matches, err := filterexpr.ParseExpression(rawJobStartRule.Matches)
+	if err != nil {
+		return err
+	}
+	r.Expr = append(r.Expr, matches...)
 
 	return nil
 }
@@ -46,16 +78,155 @@ type JobStartRuleOr struct {
 	Or []string `yaml:"or"`
 }
 
-// TemplatePath returns the path to the job template in the repo
+// MatchesChangedFiles determines if a rule matches a set of changed files. A changed file is relevant
+// if it matches any of the Paths globs (or there are none) and none of the PathsIgnore globs. The rule
+// matches if at least one changed file is relevant. Rules without Paths and PathsIgnore match all changes.
+//
+// In the globs * matches any sequence of characters except /, ** matches any sequence of characters
+// including / and ? matches a single character other than /.
+func (r *JobStartRule) MatchesChangedFiles(changedFiles []string) bool {
+	if !r.HasPathFilter() {
+		return true
+	}
+
+	var (
+		paths  = compilePathGlobs(r.Paths)
+		ignore = compilePathGlobs(r.PathsIgnore)
+	)
+	for _, fn := range changedFiles {
+		if len(paths) > 0 && !matchesAny(paths, fn) {
+			continue
+		}
+		if matchesAny(ignore, fn) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// HasPathFilter returns true if the rule restricts the changed files it matches
+func (r *JobStartRule) HasPathFilter() bool {
+	return len(r.Paths) > 0 || len(r.PathsIgnore) > 0
+}
+
+func compilePathGlobs(globs []string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, len(globs))
+	for i, g := range globs {
+		res[i] = PathGlobToRegexp(g)
+	}
+	return res
+}
+
+func matchesAny(exprs []*regexp.Regexp, fn string) bool {
+	for _, e := range exprs {
+		if e.MatchString(fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// PathGlobToRegexp converts a path glob to an anchored regular expression. Leading slashes are ignored
+// because changed files are always relative to the repository root.
+func PathGlobToRegexp(glob string) *regexp.Regexp {
+	glob = strings.TrimLeft(glob, "/")
+
+	var res strings.Builder
+	res.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case c == '*' && strings.HasPrefix(glob[i:], "**/"):
+			// **/ matches zero or more directories
+			res.WriteString("(.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(glob[i:], "**"):
+			res.WriteString(".*")
+			i++
+		case c == '*':
+			res.WriteString("[^/]*")
+		case c == '?':
+			res.WriteString("[^/]")
+		default:
+			res.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	res.WriteString("$")
+	return regexp.MustCompile(res.String())
+}
+
+// NeedsChangedFiles returns true if any rule restricts the changed files it matches
+func (rc *C) NeedsChangedFiles() bool {
+	for _, rule := range rc.Rules {
+		if rule.HasPathFilter() {
+			return true
+		}
+	}
+	return false
+}
+
+// TemplatePath returns the path to the job template in the repo.
+// Path filters of the rules are ignored, i.e. only the job metadata is considered.
 func (rc *C) TemplatePath(md *werftv1.JobMetadata) string {
-	js := &werftv1.JobStatus{Metadata: md}
+	return firstPath(rc.templatePaths(md, nil, false, false))
+}
+
+// TemplatePathForChanges returns the path to the job template in the repo for a set of changed files.
+// Rules with path filters only match if the changes contain relevant files, see MatchesChangedFiles.
+func (rc *C) TemplatePathForChanges(md *werftv1.JobMetadata, changedFiles []string) string {
+	return firstPath(rc.templatePaths(md, changedFiles, true, false))
+}
+
+// TemplatePaths returns the paths of all job templates which should run. Unless StartAllMatching is set,
+// this is the path TemplatePath returns. Path filters of the rules are ignored.
+func (rc *C) TemplatePaths(md *werftv1.JobMetadata) []string {
+	return rc.templatePaths(md, nil, false, rc.StartAllMatching)
+}
+
+// TemplatePathsForChanges returns the paths of all job templates which should run for a set of changed files.
+// Unless StartAllMatching is set, this is the path TemplatePathForChanges returns.
+func (rc *C) TemplatePathsForChanges(md *werftv1.JobMetadata, changedFiles []string) []string {
+	return rc.templatePaths(md, changedFiles, true, rc.StartAllMatching)
+}
+
+func (rc *C) templatePaths(md *werftv1.JobMetadata, changedFiles []string, checkChanges, all bool) []string {
+	var (
+		js      = &werftv1.JobStatus{Metadata: md}
+		res     []string
+		idx     = make(map[string]struct{})
+		matched bool
+	)
 	for _, rule := range rc.Rules {
-		if filterexpr.MatchesFilter(js, rule.Expr) {
-			return rule.Path
+		if !filterexpr.MatchesFilter(js, rule.Expr) {
+			continue
+		}
+		if checkChanges && !rule.MatchesChangedFiles(changedFiles) {
+			continue
+		}
+
+		// a matching rule without path prevents the default job from starting
+		matched = true
+		if _, exists := idx[rule.Path]; rule.Path != "" && !exists {
+			idx[rule.Path] = struct{}{}
+			res = append(res, rule.Path)
+		}
+
+		if !all {
+			break
 		}
 	}
+	if !matched && rc.DefaultJob != "" {
+		res = append(res, rc.DefaultJob)
+	}
+
+	return res
+}
 
-	return rc.DefaultJob
+func firstPath(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	return paths[0]
 }
 
 // ShouldRun determines based on the repo config if the job should run
@@ -72,29 +243,89 @@ type JobSpec struct {
 	// as a Go template.
 	Pod *corev1.PodSpec `yaml:"pod"`
 
+	// Steps are a simpler alternative to Pod. Each step runs its commands in a container of its own,
+	// one after the other, and logs to a slice named after the step. A job has either a pod or steps.
+	Steps []StepSpec `yaml:"steps,omitempty"`
+
 	// Mutex makes job execution exclusive, with new ones canceling the currently running one.
 	// For example: job A is running at the moment, and job B is about to start. If A and B share the
 	// same mutex, B will cancel A.
 	Mutex string `yaml:"mutex,omitempty"`
 
-	// Args describe annotations which this job expects. This list is only used on the UI when manually
-	// starting the job.
-	// This is list is neither exhaustive (i.e. jobs can use annotations not listed here), nor binding
-	// (i.e. jobs can run even when annotations listed here are not present). What matters for a job to
-	// run is only if Kubernetes accepts the produced podspec.
+	// Args describe annotations which this job expects. Werft refuses to start a job if a required
+	// annotation is missing, or if an annotation's value does not match its argument's type or pattern.
+	// Default values are available to the job template like any other annotation.
+	// This is list is not exhaustive, i.e. jobs can use annotations not listed here.
 	Args []ArgSpec `yaml:"args,omitempty"`
 
 	// Sidecars list side car containers of the job, i.e. containers
 	// for which we don't wait that they end to end the job.
 	Sidecars []string `yaml:"sidecars,omitempty"`
 
+	// Services are containers, e.g. databases, which run alongside the job. The job's containers
+	// wait until all services are ready, and werft treats services as sidecars.
+	Services []ServiceSpec `yaml:"services,omitempty"`
+
+	// Approval makes the job wait until someone approves it, e.g. before deploying to production.
+	// Rejected jobs fail without running.
+	Approval *ApprovalSpec `yaml:"approval,omitempty"`
+
 	// Plugins list plugin-specific information
 	Plugins map[string]string `yaml:"plugins,omitempty"`
 }
 
+// StepSpec specifies a single step of a job.
+type StepSpec struct {
+	// Name identifies the step and names its log slice. Defaults to step<index>.
+	Name string `yaml:"name,omitempty"`
+	// Description is shown when the step starts
+	Description string `yaml:"description,omitempty"`
+	// Image is the container image the step runs in. The image must contain a POSIX shell.
+	Image string `yaml:"image"`
+	// Commands are shell commands which run in the workspace. The step fails with the first failing command.
+	Commands []string `yaml:"commands"`
+	// Env are the environment variables of the step
+	Env []corev1.EnvVar `yaml:"env,omitempty"`
+}
+
+// ServiceSpec specifies a service which runs alongside a job.
+type ServiceSpec struct {
+	// Name identifies the service and names its log slice
+	Name string `yaml:"name"`
+	// Image is the container image of the service
+	Image string `yaml:"image"`
+	// Env are the environment variables of the service
+	Env []corev1.EnvVar `yaml:"env,omitempty"`
+	// Ports lists the ports the service listens on. All containers of a job can reach them on localhost.
+	Ports []int32 `yaml:"ports,omitempty"`
+	// Readiness is a shell command which runs in the service's container and succeeds once the service is ready.
+	// Services without readiness command are considered ready once they've started.
+	Readiness string `yaml:"readiness,omitempty"`
+	// ReadinessTimeout is the number of seconds the job's containers wait for the service to become ready. Defaults to 60.
+	ReadinessTimeout int `yaml:"readinessTimeout,omitempty"`
+}
+
+// ApprovalSpec specifies who may approve a job. If neither approvers nor teams are listed,
+// anyone who may call the ApproveJob API can approve the job.
+type ApprovalSpec struct {
+	// Approvers are the usernames of the users who may approve the job
+	Approvers []string `yaml:"approvers,omitempty"`
+	// Teams are the teams whose members may approve the job
+	Teams []string `yaml:"teams,omitempty"`
+}
+
 // ArgSpec specifies an argument/annotation for a job.
 type ArgSpec struct {
 	Name string `yaml:"name"`
 	Req  bool   `yaml:"required"`
 	Desc string `yaml:"description"`
+
+	// Type is the type of the argument's value. Defaults to string.
+	Type ArgType `yaml:"type,omitempty"`
+	// Values lists the allowed values of enum arguments
+	Values []string `yaml:"values,omitempty"`
+	// Pattern is a regular expression the whole value has to match
+	Pattern string `yaml:"pattern,omitempty"`
+	// Default is used if the annotation is not present
+	Default string `yaml:"default,omitempty"`
 }
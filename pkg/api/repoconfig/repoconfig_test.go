@@ -7,6 +7,7 @@ import (
 
 	"github.com/csweichel/werft/pkg/api/repoconfig"
 	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/google/go-cmp/cmp"
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,6 +17,7 @@ func TestUnmarshalC(t *testing.T) {
 		Expectation string
 	}{
 		{`defaultJob: "foo.yaml"`, `{"DefaultJob":"foo.yaml","Rules":null}`},
+		{`cancelSuperseded: true`, `{"DefaultJob":"","Rules":null,"CancelSuperseded":true}`},
 		{
 			`rules:
 - path: ""
@@ -36,6 +38,12 @@ func TestUnmarshalC(t *testing.T) {
     - "name !~= 0"
 `, `{"DefaultJob":"","Rules":[{"Path":"foo.yaml","Expr":[{"terms":[{"field":"repo.ref","value":"refs/branches/","operation":3}]},{"terms":[{"field":"name","value":"0","operation":3,"negate":true}]}]}]}`,
 		},
+		{
+			`rules:
+- path: "deploy.yaml"
+  matches: "repo.ref |= refs/tags/ and trigger in (push, manual)"
+`, `{"DefaultJob":"","Rules":[{"Path":"deploy.yaml","Expr":[{"terms":[{"field":"repo.ref","value":"refs/tags/","operation":1}]},{"terms":[{"field":"trigger","value":"push"},{"field":"trigger","value":"manual"}]}]}]}`,
+		},
 	}
 
 	for idx, test := range tests {
@@ -171,3 +179,120 @@ func mustParseRule(exp string) *repoconfig.JobStartRule {
 	fmt.Printf("parsed rule: %v\n", res)
 	return &res
 }
+
+func TestTemplatePathForChanges(t *testing.T) {
+	cfg := repoconfig.C{
+		DefaultJob: "build.yaml",
+		Rules: []*repoconfig.JobStartRule{
+			mustParseRule("path: docs.yaml\npaths: [\"docs/**\", \"*.md\"]"),
+			mustParseRule("path: server.yaml\npaths: [\"components/server/**\"]\npathsIgnore: [\"**/*_test.go\"]"),
+			mustParseRule("path: ui.yaml\npathsIgnore: [\"components/server/**\", \"docs/**\"]\nmatches: \"repo.ref == refs/heads/main\""),
+		},
+	}
+	md := v1.JobMetadata{Repository: &v1.Repository{Ref: "refs/heads/main"}}
+	branch := v1.JobMetadata{Repository: &v1.Repository{Ref: "refs/heads/feature"}}
+
+	tests := []struct {
+		Name         string
+		Metadata     *v1.JobMetadata
+		ChangedFiles []string
+		Expectation  string
+	}{
+		{Name: "docs", Metadata: &md, ChangedFiles: []string{"docs/setup/install.md"}, Expectation: "docs.yaml"},
+		{Name: "top-level markdown", Metadata: &md, ChangedFiles: []string{"README.md"}, Expectation: "docs.yaml"},
+		{Name: "nested markdown", Metadata: &md, ChangedFiles: []string{"components/server/README.md"}, Expectation: "server.yaml"},
+		{Name: "server", Metadata: &md, ChangedFiles: []string{"components/server/pkg/main.go"}, Expectation: "server.yaml"},
+		{Name: "server tests only", Metadata: &md, ChangedFiles: []string{"components/server/pkg/main_test.go"}, Expectation: "build.yaml"},
+		{Name: "ui", Metadata: &md, ChangedFiles: []string{"docs/index.html", "components/ui/index.ts"}, Expectation: "docs.yaml"},
+		{Name: "ignored paths", Metadata: &md, ChangedFiles: []string{"components/ui/index.ts"}, Expectation: "ui.yaml"},
+		{Name: "metadata mismatch", Metadata: &branch, ChangedFiles: []string{"components/ui/index.ts"}, Expectation: "build.yaml"},
+		{Name: "no changes", Metadata: &md, Expectation: "build.yaml"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := cfg.TemplatePathForChanges(test.Metadata, test.ChangedFiles)
+			if act != test.Expectation {
+				t.Errorf("expected %s, actual %s", test.Expectation, act)
+			}
+		})
+	}
+
+	if !cfg.NeedsChangedFiles() {
+		t.Error("config with path filters does not need changed files")
+	}
+	if (&repoconfig.C{Rules: []*repoconfig.JobStartRule{{Path: "foo"}}}).NeedsChangedFiles() {
+		t.Error("config without path filters needs changed files")
+	}
+}
+
+func TestPathGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		Glob    string
+		Match   []string
+		NoMatch []string
+	}{
+		{Glob: "*.go", Match: []string{"main.go"}, NoMatch: []string{"pkg/main.go", "main.golang"}},
+		{Glob: "**/*.go", Match: []string{"main.go", "pkg/werft/main.go"}, NoMatch: []string{"main.js"}},
+		{Glob: "pkg/**", Match: []string{"pkg/main.go", "pkg/werft/main.go"}, NoMatch: []string{"pkg", "cmd/pkg/main.go"}},
+		{Glob: "/pkg/*/main.go", Match: []string{"pkg/werft/main.go"}, NoMatch: []string{"pkg/main.go", "pkg/a/b/main.go"}},
+		{Glob: "v?.yaml", Match: []string{"v1.yaml"}, NoMatch: []string{"v10.yaml", "v/.yaml"}},
+		{Glob: "a+b(c).txt", Match: []string{"a+b(c).txt"}, NoMatch: []string{"aab(c).txt"}},
+	}
+	for _, test := range tests {
+		t.Run(test.Glob, func(t *testing.T) {
+			re := repoconfig.PathGlobToRegexp(test.Glob)
+			for _, fn := range test.Match {
+				if !re.MatchString(fn) {
+					t.Errorf("%s does not match %s", test.Glob, fn)
+				}
+			}
+			for _, fn := range test.NoMatch {
+				if re.MatchString(fn) {
+					t.Errorf("%s matches %s", test.Glob, fn)
+				}
+			}
+		})
+	}
+}
+
+func TestTemplatePaths(t *testing.T) {
+	rules := []*repoconfig.JobStartRule{
+		mustParseRule("path: lint.yaml\nmatches: \"repo.ref |= refs/heads/\""),
+		mustParseRule("path: test.yaml\nmatches: \"repo.ref |= refs/heads/\""),
+		mustParseRule("path: docs.yaml\npaths: [\"docs/**\"]"),
+		mustParseRule("path: lint.yaml\nmatches: \"trigger == push\""),
+		mustParseRule("path: \"\"\nmatches: \"repo.ref |= refs/tags/\""),
+	}
+	branch := &v1.JobMetadata{Repository: &v1.Repository{Ref: "refs/heads/main"}, Trigger: v1.JobTrigger_TRIGGER_PUSH}
+	tag := &v1.JobMetadata{Repository: &v1.Repository{Ref: "refs/tags/v1"}}
+	other := &v1.JobMetadata{Repository: &v1.Repository{Ref: "refs/pull/1"}}
+
+	tests := []struct {
+		Name         string
+		All          bool
+		Metadata     *v1.JobMetadata
+		ChangedFiles []string
+		Expectation  []string
+	}{
+		{Name: "first match", Metadata: branch, Expectation: []string{"lint.yaml"}},
+		{Name: "all matches", All: true, Metadata: branch, Expectation: []string{"lint.yaml", "test.yaml", "docs.yaml"}},
+		{Name: "all matches with changes", All: true, Metadata: branch, ChangedFiles: []string{"main.go"}, Expectation: []string{"lint.yaml", "test.yaml"}},
+		{Name: "rule without path", All: true, Metadata: tag, ChangedFiles: []string{"main.go"}},
+		{Name: "default job", All: true, Metadata: other, ChangedFiles: []string{"main.go"}, Expectation: []string{"default.yaml"}},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			cfg := repoconfig.C{DefaultJob: "default.yaml", Rules: rules, StartAllMatching: test.All}
+
+			var act []string
+			if test.ChangedFiles != nil {
+				act = cfg.TemplatePathsForChanges(test.Metadata, test.ChangedFiles)
+			} else {
+				act = cfg.TemplatePaths(test.Metadata)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("TemplatePaths() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -142,6 +142,66 @@ func (m *MockWerftServiceClient) EXPECT() *MockWerftServiceClientMockRecorder {
 	return m.recorder
 }
 
+// ApproveJob mocks base method.
+func (m *MockWerftServiceClient) ApproveJob(ctx context.Context, in *v1.ApproveJobRequest, opts ...grpc.CallOption) (*v1.ApproveJobResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ApproveJob", varargs...)
+	ret0, _ := ret[0].(*v1.ApproveJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveJob indicates an expected call of ApproveJob.
+func (mr *MockWerftServiceClientMockRecorder) ApproveJob(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveJob", reflect.TypeOf((*MockWerftServiceClient)(nil).ApproveJob), varargs...)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockWerftServiceClient) CreateSchedule(ctx context.Context, in *v1.CreateScheduleRequest, opts ...grpc.CallOption) (*v1.CreateScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateSchedule", varargs...)
+	ret0, _ := ret[0].(*v1.CreateScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockWerftServiceClientMockRecorder) CreateSchedule(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockWerftServiceClient)(nil).CreateSchedule), varargs...)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockWerftServiceClient) DeleteSchedule(ctx context.Context, in *v1.DeleteScheduleRequest, opts ...grpc.CallOption) (*v1.DeleteScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteSchedule", varargs...)
+	ret0, _ := ret[0].(*v1.DeleteScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockWerftServiceClientMockRecorder) DeleteSchedule(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockWerftServiceClient)(nil).DeleteSchedule), varargs...)
+}
+
 // GetJob mocks base method.
 func (m *MockWerftServiceClient) GetJob(ctx context.Context, in *v1.GetJobRequest, opts ...grpc.CallOption) (*v1.GetJobResponse, error) {
 	m.ctrl.T.Helper()
@@ -162,6 +222,26 @@ func (mr *MockWerftServiceClientMockRecorder) GetJob(ctx, in interface{}, opts .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockWerftServiceClient)(nil).GetJob), varargs...)
 }
 
+// GetStatistics mocks base method.
+func (m *MockWerftServiceClient) GetStatistics(ctx context.Context, in *v1.GetStatisticsRequest, opts ...grpc.CallOption) (*v1.GetStatisticsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetStatistics", varargs...)
+	ret0, _ := ret[0].(*v1.GetStatisticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatistics indicates an expected call of GetStatistics.
+func (mr *MockWerftServiceClientMockRecorder) GetStatistics(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockWerftServiceClient)(nil).GetStatistics), varargs...)
+}
+
 // ListJobs mocks base method.
 func (m *MockWerftServiceClient) ListJobs(ctx context.Context, in *v1.ListJobsRequest, opts ...grpc.CallOption) (*v1.ListJobsResponse, error) {
 	m.ctrl.T.Helper()
@@ -182,6 +262,26 @@ func (mr *MockWerftServiceClientMockRecorder) ListJobs(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockWerftServiceClient)(nil).ListJobs), varargs...)
 }
 
+// ListSchedules mocks base method.
+func (m *MockWerftServiceClient) ListSchedules(ctx context.Context, in *v1.ListSchedulesRequest, opts ...grpc.CallOption) (*v1.ListSchedulesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSchedules", varargs...)
+	ret0, _ := ret[0].(*v1.ListSchedulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockWerftServiceClientMockRecorder) ListSchedules(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockWerftServiceClient)(nil).ListSchedules), varargs...)
+}
+
 // Listen mocks base method.
 func (m *MockWerftServiceClient) Listen(ctx context.Context, in *v1.ListenRequest, opts ...grpc.CallOption) (v1.WerftService_ListenClient, error) {
 	m.ctrl.T.Helper()
@@ -202,6 +302,66 @@ func (mr *MockWerftServiceClientMockRecorder) Listen(ctx, in interface{}, opts .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockWerftServiceClient)(nil).Listen), varargs...)
 }
 
+// PauseSchedule mocks base method.
+func (m *MockWerftServiceClient) PauseSchedule(ctx context.Context, in *v1.PauseScheduleRequest, opts ...grpc.CallOption) (*v1.PauseScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PauseSchedule", varargs...)
+	ret0, _ := ret[0].(*v1.PauseScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseSchedule indicates an expected call of PauseSchedule.
+func (mr *MockWerftServiceClientMockRecorder) PauseSchedule(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseSchedule", reflect.TypeOf((*MockWerftServiceClient)(nil).PauseSchedule), varargs...)
+}
+
+// PinJob mocks base method.
+func (m *MockWerftServiceClient) PinJob(ctx context.Context, in *v1.PinJobRequest, opts ...grpc.CallOption) (*v1.PinJobResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PinJob", varargs...)
+	ret0, _ := ret[0].(*v1.PinJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinJob indicates an expected call of PinJob.
+func (mr *MockWerftServiceClientMockRecorder) PinJob(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinJob", reflect.TypeOf((*MockWerftServiceClient)(nil).PinJob), varargs...)
+}
+
+// RejectJob mocks base method.
+func (m *MockWerftServiceClient) RejectJob(ctx context.Context, in *v1.RejectJobRequest, opts ...grpc.CallOption) (*v1.RejectJobResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RejectJob", varargs...)
+	ret0, _ := ret[0].(*v1.RejectJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectJob indicates an expected call of RejectJob.
+func (mr *MockWerftServiceClientMockRecorder) RejectJob(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectJob", reflect.TypeOf((*MockWerftServiceClient)(nil).RejectJob), varargs...)
+}
+
 // StartFromPreviousJob mocks base method.
 func (m *MockWerftServiceClient) StartFromPreviousJob(ctx context.Context, in *v1.StartFromPreviousJobRequest, opts ...grpc.CallOption) (*v1.StartJobResponse, error) {
 	m.ctrl.T.Helper()
@@ -342,6 +502,46 @@ func (mr *MockWerftServiceClientMockRecorder) Subscribe(ctx, in interface{}, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockWerftServiceClient)(nil).Subscribe), varargs...)
 }
 
+// UnpinJob mocks base method.
+func (m *MockWerftServiceClient) UnpinJob(ctx context.Context, in *v1.UnpinJobRequest, opts ...grpc.CallOption) (*v1.UnpinJobResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnpinJob", varargs...)
+	ret0, _ := ret[0].(*v1.UnpinJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnpinJob indicates an expected call of UnpinJob.
+func (mr *MockWerftServiceClientMockRecorder) UnpinJob(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinJob", reflect.TypeOf((*MockWerftServiceClient)(nil).UnpinJob), varargs...)
+}
+
+// ValidateJob mocks base method.
+func (m *MockWerftServiceClient) ValidateJob(ctx context.Context, in *v1.ValidateJobRequest, opts ...grpc.CallOption) (*v1.ValidateJobResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ValidateJob", varargs...)
+	ret0, _ := ret[0].(*v1.ValidateJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateJob indicates an expected call of ValidateJob.
+func (mr *MockWerftServiceClientMockRecorder) ValidateJob(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateJob", reflect.TypeOf((*MockWerftServiceClient)(nil).ValidateJob), varargs...)
+}
+
 // MockWerftService_StartLocalJobClient is a mock of WerftService_StartLocalJobClient interface.
 type MockWerftService_StartLocalJobClient struct {
 	ctrl     *gomock.Controller
@@ -748,6 +948,51 @@ func (m *MockWerftServiceServer) EXPECT() *MockWerftServiceServerMockRecorder {
 	return m.recorder
 }
 
+// ApproveJob mocks base method.
+func (m *MockWerftServiceServer) ApproveJob(arg0 context.Context, arg1 *v1.ApproveJobRequest) (*v1.ApproveJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveJob", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ApproveJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveJob indicates an expected call of ApproveJob.
+func (mr *MockWerftServiceServerMockRecorder) ApproveJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveJob", reflect.TypeOf((*MockWerftServiceServer)(nil).ApproveJob), arg0, arg1)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockWerftServiceServer) CreateSchedule(arg0 context.Context, arg1 *v1.CreateScheduleRequest) (*v1.CreateScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*v1.CreateScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockWerftServiceServerMockRecorder) CreateSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockWerftServiceServer)(nil).CreateSchedule), arg0, arg1)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockWerftServiceServer) DeleteSchedule(arg0 context.Context, arg1 *v1.DeleteScheduleRequest) (*v1.DeleteScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*v1.DeleteScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockWerftServiceServerMockRecorder) DeleteSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockWerftServiceServer)(nil).DeleteSchedule), arg0, arg1)
+}
+
 // GetJob mocks base method.
 func (m *MockWerftServiceServer) GetJob(arg0 context.Context, arg1 *v1.GetJobRequest) (*v1.GetJobResponse, error) {
 	m.ctrl.T.Helper()
@@ -763,6 +1008,21 @@ func (mr *MockWerftServiceServerMockRecorder) GetJob(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockWerftServiceServer)(nil).GetJob), arg0, arg1)
 }
 
+// GetStatistics mocks base method.
+func (m *MockWerftServiceServer) GetStatistics(arg0 context.Context, arg1 *v1.GetStatisticsRequest) (*v1.GetStatisticsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatistics", arg0, arg1)
+	ret0, _ := ret[0].(*v1.GetStatisticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatistics indicates an expected call of GetStatistics.
+func (mr *MockWerftServiceServerMockRecorder) GetStatistics(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockWerftServiceServer)(nil).GetStatistics), arg0, arg1)
+}
+
 // ListJobs mocks base method.
 func (m *MockWerftServiceServer) ListJobs(arg0 context.Context, arg1 *v1.ListJobsRequest) (*v1.ListJobsResponse, error) {
 	m.ctrl.T.Helper()
@@ -778,6 +1038,21 @@ func (mr *MockWerftServiceServerMockRecorder) ListJobs(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockWerftServiceServer)(nil).ListJobs), arg0, arg1)
 }
 
+// ListSchedules mocks base method.
+func (m *MockWerftServiceServer) ListSchedules(arg0 context.Context, arg1 *v1.ListSchedulesRequest) (*v1.ListSchedulesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ListSchedulesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockWerftServiceServerMockRecorder) ListSchedules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockWerftServiceServer)(nil).ListSchedules), arg0, arg1)
+}
+
 // Listen mocks base method.
 func (m *MockWerftServiceServer) Listen(arg0 *v1.ListenRequest, arg1 v1.WerftService_ListenServer) error {
 	m.ctrl.T.Helper()
@@ -792,6 +1067,51 @@ func (mr *MockWerftServiceServerMockRecorder) Listen(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockWerftServiceServer)(nil).Listen), arg0, arg1)
 }
 
+// PauseSchedule mocks base method.
+func (m *MockWerftServiceServer) PauseSchedule(arg0 context.Context, arg1 *v1.PauseScheduleRequest) (*v1.PauseScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*v1.PauseScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseSchedule indicates an expected call of PauseSchedule.
+func (mr *MockWerftServiceServerMockRecorder) PauseSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseSchedule", reflect.TypeOf((*MockWerftServiceServer)(nil).PauseSchedule), arg0, arg1)
+}
+
+// PinJob mocks base method.
+func (m *MockWerftServiceServer) PinJob(arg0 context.Context, arg1 *v1.PinJobRequest) (*v1.PinJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinJob", arg0, arg1)
+	ret0, _ := ret[0].(*v1.PinJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinJob indicates an expected call of PinJob.
+func (mr *MockWerftServiceServerMockRecorder) PinJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinJob", reflect.TypeOf((*MockWerftServiceServer)(nil).PinJob), arg0, arg1)
+}
+
+// RejectJob mocks base method.
+func (m *MockWerftServiceServer) RejectJob(arg0 context.Context, arg1 *v1.RejectJobRequest) (*v1.RejectJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectJob", arg0, arg1)
+	ret0, _ := ret[0].(*v1.RejectJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectJob indicates an expected call of RejectJob.
+func (mr *MockWerftServiceServerMockRecorder) RejectJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectJob", reflect.TypeOf((*MockWerftServiceServer)(nil).RejectJob), arg0, arg1)
+}
+
 // StartFromPreviousJob mocks base method.
 func (m *MockWerftServiceServer) StartFromPreviousJob(arg0 context.Context, arg1 *v1.StartFromPreviousJobRequest) (*v1.StartJobResponse, error) {
 	m.ctrl.T.Helper()
@@ -895,6 +1215,36 @@ func (mr *MockWerftServiceServerMockRecorder) Subscribe(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockWerftServiceServer)(nil).Subscribe), arg0, arg1)
 }
 
+// UnpinJob mocks base method.
+func (m *MockWerftServiceServer) UnpinJob(arg0 context.Context, arg1 *v1.UnpinJobRequest) (*v1.UnpinJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinJob", arg0, arg1)
+	ret0, _ := ret[0].(*v1.UnpinJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnpinJob indicates an expected call of UnpinJob.
+func (mr *MockWerftServiceServerMockRecorder) UnpinJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinJob", reflect.TypeOf((*MockWerftServiceServer)(nil).UnpinJob), arg0, arg1)
+}
+
+// ValidateJob mocks base method.
+func (m *MockWerftServiceServer) ValidateJob(arg0 context.Context, arg1 *v1.ValidateJobRequest) (*v1.ValidateJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateJob", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ValidateJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateJob indicates an expected call of ValidateJob.
+func (mr *MockWerftServiceServerMockRecorder) ValidateJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateJob", reflect.TypeOf((*MockWerftServiceServer)(nil).ValidateJob), arg0, arg1)
+}
+
 // MockWerftService_StartLocalJobServer is a mock of WerftService_StartLocalJobServer interface.
 type MockWerftService_StartLocalJobServer struct {
 	ctrl     *gomock.Controller
@@ -28,11 +28,15 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type FilterOp int32
 
 const (
-	FilterOp_OP_EQUALS      FilterOp = 0
-	FilterOp_OP_STARTS_WITH FilterOp = 1
-	FilterOp_OP_ENDS_WITH   FilterOp = 2
-	FilterOp_OP_CONTAINS    FilterOp = 3
-	FilterOp_OP_EXISTS      FilterOp = 4
+	FilterOp_OP_EQUALS       FilterOp = 0
+	FilterOp_OP_STARTS_WITH  FilterOp = 1
+	FilterOp_OP_ENDS_WITH    FilterOp = 2
+	FilterOp_OP_CONTAINS     FilterOp = 3
+	FilterOp_OP_EXISTS       FilterOp = 4
+	FilterOp_OP_LESS_THAN    FilterOp = 5
+	FilterOp_OP_GREATER_THAN FilterOp = 6
+	FilterOp_OP_REGEX        FilterOp = 7
+	FilterOp_OP_GLOB         FilterOp = 8
 )
 
 var FilterOp_name = map[int32]string{
@@ -41,14 +45,22 @@ var FilterOp_name = map[int32]string{
 	2: "OP_ENDS_WITH",
 	3: "OP_CONTAINS",
 	4: "OP_EXISTS",
+	5: "OP_LESS_THAN",
+	6: "OP_GREATER_THAN",
+	7: "OP_REGEX",
+	8: "OP_GLOB",
 }
 
 var FilterOp_value = map[string]int32{
-	"OP_EQUALS":      0,
-	"OP_STARTS_WITH": 1,
-	"OP_ENDS_WITH":   2,
-	"OP_CONTAINS":    3,
-	"OP_EXISTS":      4,
+	"OP_EQUALS":       0,
+	"OP_STARTS_WITH":  1,
+	"OP_ENDS_WITH":    2,
+	"OP_CONTAINS":     3,
+	"OP_EXISTS":       4,
+	"OP_LESS_THAN":    5,
+	"OP_GREATER_THAN": 6,
+	"OP_REGEX":        7,
+	"OP_GLOB":         8,
 }
 
 func (x FilterOp) String() string {
@@ -209,6 +221,37 @@ func (LogSliceType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_9fe744feedd6d332, []int{4}
 }
 
+type DiagnosticSeverity int32
+
+const (
+	DiagnosticSeverity_SEVERITY_UNKNOWN DiagnosticSeverity = 0
+	DiagnosticSeverity_SEVERITY_ERROR   DiagnosticSeverity = 1
+	DiagnosticSeverity_SEVERITY_WARNING DiagnosticSeverity = 2
+	DiagnosticSeverity_SEVERITY_INFO    DiagnosticSeverity = 3
+)
+
+var DiagnosticSeverity_name = map[int32]string{
+	0: "SEVERITY_UNKNOWN",
+	1: "SEVERITY_ERROR",
+	2: "SEVERITY_WARNING",
+	3: "SEVERITY_INFO",
+}
+
+var DiagnosticSeverity_value = map[string]int32{
+	"SEVERITY_UNKNOWN": 0,
+	"SEVERITY_ERROR":   1,
+	"SEVERITY_WARNING": 2,
+	"SEVERITY_INFO":    3,
+}
+
+func (x DiagnosticSeverity) String() string {
+	return proto.EnumName(DiagnosticSeverity_name, int32(x))
+}
+
+func (DiagnosticSeverity) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{5}
+}
+
 type StartLocalJobRequest struct {
 	// Types that are valid to be assigned to Content:
 	//	*StartLocalJobRequest_Metadata
@@ -335,10 +378,13 @@ func (*StartLocalJobRequest) XXX_OneofWrappers() []interface{} {
 }
 
 type StartJobResponse struct {
-	Status               *JobStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Status *JobStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// additional_jobs lists the jobs which were started in addition to status,
+	// e.g. when the repository config starts a job for all matching rules.
+	AdditionalJobs       []*JobStatus `protobuf:"bytes,2,rep,name=additional_jobs,json=additionalJobs,proto3" json:"additional_jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *StartJobResponse) Reset()         { *m = StartJobResponse{} }
@@ -373,6 +419,13 @@ func (m *StartJobResponse) GetStatus() *JobStatus {
 	return nil
 }
 
+func (m *StartJobResponse) GetAdditionalJobs() []*JobStatus {
+	if m != nil {
+		return m.AdditionalJobs
+	}
+	return nil
+}
+
 type StartGitHubJobRequest struct {
 	Metadata             *JobMetadata         `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	JobPath              string               `protobuf:"bytes,2,opt,name=job_path,json=jobPath,proto3" json:"job_path,omitempty"`
@@ -1358,16 +1411,21 @@ func (*ListenResponse) XXX_OneofWrappers() []interface{} {
 }
 
 type JobStatus struct {
-	Name                 string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Metadata             *JobMetadata   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Phase                JobPhase       `protobuf:"varint,3,opt,name=phase,proto3,enum=v1.JobPhase" json:"phase,omitempty"`
-	Conditions           *JobConditions `protobuf:"bytes,4,opt,name=conditions,proto3" json:"conditions,omitempty"`
-	Details              string         `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
-	Results              []*JobResult   `protobuf:"bytes,6,rep,name=results,proto3" json:"results,omitempty"`
-	Spec                 *JobSpec       `protobuf:"bytes,7,opt,name=spec,proto3" json:"spec,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Name       string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Metadata   *JobMetadata   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Phase      JobPhase       `protobuf:"varint,3,opt,name=phase,proto3,enum=v1.JobPhase" json:"phase,omitempty"`
+	Conditions *JobConditions `protobuf:"bytes,4,opt,name=conditions,proto3" json:"conditions,omitempty"`
+	Details    string         `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
+	Results    []*JobResult   `protobuf:"bytes,6,rep,name=results,proto3" json:"results,omitempty"`
+	Spec       *JobSpec       `protobuf:"bytes,7,opt,name=spec,proto3" json:"spec,omitempty"`
+	// pinned jobs are never garbage collected. This field is maintained by the job store and cannot be changed
+	// by storing a job - use PinJob and UnpinJob instead.
+	Pinned bool `protobuf:"varint,8,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// approval is present for jobs which need a human to approve them before they run
+	Approval             *JobApproval `protobuf:"bytes,9,opt,name=approval,proto3" json:"approval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *JobStatus) Reset()         { *m = JobStatus{} }
@@ -1444,6 +1502,20 @@ func (m *JobStatus) GetSpec() *JobSpec {
 	return nil
 }
 
+func (m *JobStatus) GetPinned() bool {
+	if m != nil {
+		return m.Pinned
+	}
+	return false
+}
+
+func (m *JobStatus) GetApproval() *JobApproval {
+	if m != nil {
+		return m.Approval
+	}
+	return nil
+}
+
 type JobMetadata struct {
 	Owner                string               `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
 	Repository           *Repository          `protobuf:"bytes,2,opt,name=repository,proto3" json:"repository,omitempty"`
@@ -1658,14 +1730,16 @@ func (m *Annotation) GetValue() string {
 }
 
 type JobConditions struct {
-	Success              bool                 `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	FailureCount         int32                `protobuf:"varint,2,opt,name=failure_count,json=failureCount,proto3" json:"failure_count,omitempty"`
-	CanReplay            bool                 `protobuf:"varint,3,opt,name=can_replay,json=canReplay,proto3" json:"can_replay,omitempty"`
-	WaitUntil            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=wait_until,json=waitUntil,proto3" json:"wait_until,omitempty"`
-	DidExecute           bool                 `protobuf:"varint,5,opt,name=did_execute,json=didExecute,proto3" json:"did_execute,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Success      bool                 `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	FailureCount int32                `protobuf:"varint,2,opt,name=failure_count,json=failureCount,proto3" json:"failure_count,omitempty"`
+	CanReplay    bool                 `protobuf:"varint,3,opt,name=can_replay,json=canReplay,proto3" json:"can_replay,omitempty"`
+	WaitUntil    *timestamp.Timestamp `protobuf:"bytes,4,opt,name=wait_until,json=waitUntil,proto3" json:"wait_until,omitempty"`
+	DidExecute   bool                 `protobuf:"varint,5,opt,name=did_execute,json=didExecute,proto3" json:"did_execute,omitempty"`
+	// awaiting_approval is set while a job in PHASE_WAITING waits for someone to approve or reject it
+	AwaitingApproval     bool     `protobuf:"varint,6,opt,name=awaiting_approval,json=awaitingApproval,proto3" json:"awaiting_approval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *JobConditions) Reset()         { *m = JobConditions{} }
@@ -1728,6 +1802,134 @@ func (m *JobConditions) GetDidExecute() bool {
 	return false
 }
 
+func (m *JobConditions) GetAwaitingApproval() bool {
+	if m != nil {
+		return m.AwaitingApproval
+	}
+	return false
+}
+
+type JobApproval struct {
+	// approvers are the users who may approve the job
+	Approvers []string `protobuf:"bytes,1,rep,name=approvers,proto3" json:"approvers,omitempty"`
+	// teams are the teams whose members may approve the job
+	Teams []string `protobuf:"bytes,2,rep,name=teams,proto3" json:"teams,omitempty"`
+	// decisions is the audit trail of who approved or rejected the job and when
+	Decisions            []*ApprovalDecision `protobuf:"bytes,3,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *JobApproval) Reset()         { *m = JobApproval{} }
+func (m *JobApproval) String() string { return proto.CompactTextString(m) }
+func (*JobApproval) ProtoMessage()    {}
+func (*JobApproval) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{23}
+}
+
+func (m *JobApproval) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobApproval.Unmarshal(m, b)
+}
+func (m *JobApproval) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobApproval.Marshal(b, m, deterministic)
+}
+func (m *JobApproval) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobApproval.Merge(m, src)
+}
+func (m *JobApproval) XXX_Size() int {
+	return xxx_messageInfo_JobApproval.Size(m)
+}
+func (m *JobApproval) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobApproval.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobApproval proto.InternalMessageInfo
+
+func (m *JobApproval) GetApprovers() []string {
+	if m != nil {
+		return m.Approvers
+	}
+	return nil
+}
+
+func (m *JobApproval) GetTeams() []string {
+	if m != nil {
+		return m.Teams
+	}
+	return nil
+}
+
+func (m *JobApproval) GetDecisions() []*ApprovalDecision {
+	if m != nil {
+		return m.Decisions
+	}
+	return nil
+}
+
+type ApprovalDecision struct {
+	User                 string               `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Approved             bool                 `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	Comment              string               `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ApprovalDecision) Reset()         { *m = ApprovalDecision{} }
+func (m *ApprovalDecision) String() string { return proto.CompactTextString(m) }
+func (*ApprovalDecision) ProtoMessage()    {}
+func (*ApprovalDecision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{24}
+}
+
+func (m *ApprovalDecision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApprovalDecision.Unmarshal(m, b)
+}
+func (m *ApprovalDecision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApprovalDecision.Marshal(b, m, deterministic)
+}
+func (m *ApprovalDecision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApprovalDecision.Merge(m, src)
+}
+func (m *ApprovalDecision) XXX_Size() int {
+	return xxx_messageInfo_ApprovalDecision.Size(m)
+}
+func (m *ApprovalDecision) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApprovalDecision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApprovalDecision proto.InternalMessageInfo
+
+func (m *ApprovalDecision) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ApprovalDecision) GetApproved() bool {
+	if m != nil {
+		return m.Approved
+	}
+	return false
+}
+
+func (m *ApprovalDecision) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *ApprovalDecision) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
 type JobResult struct {
 	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Payload              string   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
@@ -1742,7 +1944,7 @@ func (m *JobResult) Reset()         { *m = JobResult{} }
 func (m *JobResult) String() string { return proto.CompactTextString(m) }
 func (*JobResult) ProtoMessage()    {}
 func (*JobResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9fe744feedd6d332, []int{23}
+	return fileDescriptor_9fe744feedd6d332, []int{25}
 }
 
 func (m *JobResult) XXX_Unmarshal(b []byte) error {
@@ -1804,7 +2006,7 @@ func (m *LogSliceEvent) Reset()         { *m = LogSliceEvent{} }
 func (m *LogSliceEvent) String() string { return proto.CompactTextString(m) }
 func (*LogSliceEvent) ProtoMessage()    {}
 func (*LogSliceEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9fe744feedd6d332, []int{24}
+	return fileDescriptor_9fe744feedd6d332, []int{26}
 }
 
 func (m *LogSliceEvent) XXX_Unmarshal(b []byte) error {
@@ -1857,7 +2059,7 @@ func (m *StopJobRequest) Reset()         { *m = StopJobRequest{} }
 func (m *StopJobRequest) String() string { return proto.CompactTextString(m) }
 func (*StopJobRequest) ProtoMessage()    {}
 func (*StopJobRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9fe744feedd6d332, []int{25}
+	return fileDescriptor_9fe744feedd6d332, []int{27}
 }
 
 func (m *StopJobRequest) XXX_Unmarshal(b []byte) error {
@@ -1895,7 +2097,7 @@ func (m *StopJobResponse) Reset()         { *m = StopJobResponse{} }
 func (m *StopJobResponse) String() string { return proto.CompactTextString(m) }
 func (*StopJobResponse) ProtoMessage()    {}
 func (*StopJobResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9fe744feedd6d332, []int{26}
+	return fileDescriptor_9fe744feedd6d332, []int{28}
 }
 
 func (m *StopJobResponse) XXX_Unmarshal(b []byte) error {
@@ -1916,252 +2118,1476 @@ func (m *StopJobResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StopJobResponse proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("v1.FilterOp", FilterOp_name, FilterOp_value)
-	proto.RegisterEnum("v1.ListenRequestLogs", ListenRequestLogs_name, ListenRequestLogs_value)
-	proto.RegisterEnum("v1.JobTrigger", JobTrigger_name, JobTrigger_value)
-	proto.RegisterEnum("v1.JobPhase", JobPhase_name, JobPhase_value)
-	proto.RegisterEnum("v1.LogSliceType", LogSliceType_name, LogSliceType_value)
-	proto.RegisterType((*StartLocalJobRequest)(nil), "v1.StartLocalJobRequest")
-	proto.RegisterType((*StartJobResponse)(nil), "v1.StartJobResponse")
-	proto.RegisterType((*StartGitHubJobRequest)(nil), "v1.StartGitHubJobRequest")
-	proto.RegisterType((*StartJobRequest)(nil), "v1.StartJobRequest")
-	proto.RegisterType((*StartJobRequest2)(nil), "v1.StartJobRequest2")
-	proto.RegisterType((*JobSpec)(nil), "v1.JobSpec")
-	proto.RegisterType((*JobSpec_FromRepo)(nil), "v1.JobSpec.FromRepo")
-	proto.RegisterType((*StartFromPreviousJobRequest)(nil), "v1.StartFromPreviousJobRequest")
-	proto.RegisterType((*ListJobsRequest)(nil), "v1.ListJobsRequest")
-	proto.RegisterType((*FilterExpression)(nil), "v1.FilterExpression")
-	proto.RegisterType((*FilterTerm)(nil), "v1.FilterTerm")
-	proto.RegisterType((*OrderExpression)(nil), "v1.OrderExpression")
-	proto.RegisterType((*ListJobsResponse)(nil), "v1.ListJobsResponse")
-	proto.RegisterType((*SubscribeRequest)(nil), "v1.SubscribeRequest")
-	proto.RegisterType((*SubscribeResponse)(nil), "v1.SubscribeResponse")
-	proto.RegisterType((*GetJobRequest)(nil), "v1.GetJobRequest")
-	proto.RegisterType((*GetJobResponse)(nil), "v1.GetJobResponse")
-	proto.RegisterType((*ListenRequest)(nil), "v1.ListenRequest")
-	proto.RegisterType((*ListenResponse)(nil), "v1.ListenResponse")
-	proto.RegisterType((*JobStatus)(nil), "v1.JobStatus")
-	proto.RegisterType((*JobMetadata)(nil), "v1.JobMetadata")
-	proto.RegisterType((*Repository)(nil), "v1.Repository")
-	proto.RegisterType((*Annotation)(nil), "v1.Annotation")
-	proto.RegisterType((*JobConditions)(nil), "v1.JobConditions")
-	proto.RegisterType((*JobResult)(nil), "v1.JobResult")
-	proto.RegisterType((*LogSliceEvent)(nil), "v1.LogSliceEvent")
-	proto.RegisterType((*StopJobRequest)(nil), "v1.StopJobRequest")
-	proto.RegisterType((*StopJobResponse)(nil), "v1.StopJobResponse")
+type ApproveJobRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Comment              string   `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("werft.proto", fileDescriptor_9fe744feedd6d332)
+func (m *ApproveJobRequest) Reset()         { *m = ApproveJobRequest{} }
+func (m *ApproveJobRequest) String() string { return proto.CompactTextString(m) }
+func (*ApproveJobRequest) ProtoMessage()    {}
+func (*ApproveJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{29}
 }
 
-var fileDescriptor_9fe744feedd6d332 = []byte{
-	// 1937 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x4b, 0x73, 0x1b, 0x4b,
-	0x15, 0xd6, 0xfb, 0x71, 0xf4, 0xf0, 0xb8, 0xe3, 0x50, 0xba, 0x0e, 0x54, 0x92, 0xb9, 0x49, 0x25,
-	0xd7, 0x80, 0x7c, 0xe3, 0x9b, 0xe2, 0x12, 0x8a, 0x05, 0xb2, 0xad, 0x58, 0x0e, 0x8a, 0x24, 0x7a,
-	0x64, 0x0c, 0x14, 0x55, 0xc3, 0x68, 0xd4, 0x92, 0x26, 0x19, 0x4d, 0x0f, 0x33, 0x2d, 0x3b, 0xae,
-	0x62, 0x4f, 0x15, 0x1b, 0x56, 0xb0, 0x84, 0xdf, 0x02, 0x3b, 0x7e, 0x09, 0x1b, 0x56, 0xfc, 0x02,
-	0xaa, 0x1f, 0xf3, 0x90, 0xfc, 0x08, 0x81, 0xaa, 0xbb, 0x53, 0x7f, 0x7d, 0xfa, 0x3c, 0xbe, 0x3e,
-	0x7d, 0xce, 0x19, 0x41, 0xed, 0x92, 0x04, 0x33, 0xd6, 0xf6, 0x03, 0xca, 0x28, 0xca, 0x5d, 0xbc,
-	0xd8, 0x7d, 0x38, 0xa7, 0x74, 0xee, 0x92, 0x7d, 0x81, 0x4c, 0x56, 0xb3, 0x7d, 0xe6, 0x2c, 0x49,
-	0xc8, 0xac, 0xa5, 0x2f, 0x85, 0xf4, 0x7f, 0x66, 0x61, 0xc7, 0x60, 0x56, 0xc0, 0xfa, 0xd4, 0xb6,
-	0xdc, 0x37, 0x74, 0x82, 0xc9, 0x6f, 0x57, 0x24, 0x64, 0xe8, 0xfb, 0x50, 0x59, 0x12, 0x66, 0x4d,
-	0x2d, 0x66, 0xb5, 0xb2, 0x8f, 0xb2, 0xcf, 0x6b, 0x07, 0x5b, 0xed, 0x8b, 0x17, 0xed, 0x37, 0x74,
-	0xf2, 0x56, 0xc1, 0xbd, 0x0c, 0x8e, 0x45, 0xd0, 0x63, 0xa8, 0xd9, 0xd4, 0x9b, 0x39, 0x73, 0xf3,
-	0xca, 0x5a, 0xba, 0xad, 0xdc, 0xa3, 0xec, 0xf3, 0x7a, 0x2f, 0x83, 0x41, 0x82, 0xbf, 0xb4, 0x96,
-	0x2e, 0x7a, 0x00, 0x95, 0x77, 0x74, 0x22, 0xf7, 0xf3, 0x6a, 0xbf, 0xfc, 0x8e, 0x4e, 0xc4, 0xe6,
-	0x53, 0x68, 0x5c, 0xd2, 0xe0, 0x7d, 0xe8, 0x5b, 0x36, 0x31, 0x99, 0x15, 0xb4, 0x0a, 0x4a, 0xa2,
-	0x1e, 0xc3, 0x63, 0x2b, 0x40, 0x6d, 0x40, 0x6b, 0x62, 0xe6, 0x94, 0x7a, 0xa4, 0x55, 0x7c, 0x94,
-	0x7d, 0x5e, 0xe9, 0x65, 0xb0, 0x96, 0x96, 0x3d, 0xa6, 0x1e, 0x39, 0xac, 0x42, 0xd9, 0xa6, 0x1e,
-	0x23, 0x1e, 0xd3, 0x5f, 0x81, 0x26, 0x02, 0x15, 0x31, 0x86, 0x3e, 0xf5, 0x42, 0x82, 0x9e, 0x42,
-	0x29, 0x64, 0x16, 0x5b, 0x85, 0x2a, 0xc4, 0x86, 0x0a, 0xd1, 0x10, 0x20, 0x56, 0x9b, 0xfa, 0x9f,
-	0x72, 0x70, 0x5f, 0x9c, 0x3d, 0x71, 0x58, 0x6f, 0x35, 0x49, 0xb1, 0xf4, 0xdd, 0x8f, 0xb2, 0x94,
-	0xe2, 0xe8, 0x33, 0x49, 0x80, 0x6f, 0xb1, 0x85, 0x20, 0xa8, 0x2a, 0xc2, 0x1f, 0x59, 0x6c, 0x11,
-	0x6d, 0x25, 0xdc, 0x24, 0xcc, 0x3c, 0x86, 0xfa, 0xdc, 0x61, 0x8b, 0xd5, 0xc4, 0x64, 0xf4, 0x3d,
-	0xf1, 0x04, 0x31, 0x55, 0x5c, 0x93, 0xd8, 0x98, 0x43, 0x68, 0x17, 0x2a, 0xa1, 0x33, 0x25, 0x2e,
-	0xb5, 0xa6, 0x82, 0x8b, 0x3a, 0x8e, 0xd7, 0xe8, 0x15, 0xc0, 0xa5, 0xe5, 0x30, 0x73, 0xe5, 0x31,
-	0xc7, 0x6d, 0x95, 0x84, 0x8f, 0xbb, 0x6d, 0x99, 0x16, 0xed, 0x28, 0x2d, 0xda, 0xe3, 0x28, 0x2d,
-	0x70, 0x95, 0x4b, 0x9f, 0x71, 0x61, 0xf4, 0x10, 0x6a, 0x9e, 0xb5, 0x24, 0x66, 0xb8, 0x9a, 0xcd,
-	0x9c, 0x0f, 0xad, 0xb2, 0x30, 0x0c, 0x1c, 0x32, 0x04, 0xa2, 0xff, 0x2b, 0x0b, 0x5b, 0x09, 0xa7,
-	0xdf, 0x18, 0x23, 0xe9, 0x70, 0x0b, 0x77, 0x86, 0x5b, 0xfc, 0x3f, 0xc2, 0x2d, 0x5d, 0x0b, 0xf7,
-	0x37, 0xe9, 0x0c, 0x12, 0xd1, 0x1e, 0x7c, 0x5a, 0xb8, 0x0f, 0xa1, 0x10, 0xfa, 0xc4, 0x16, 0xa1,
-	0xd6, 0x0e, 0x6a, 0x51, 0xb2, 0xf9, 0xc4, 0xc6, 0x62, 0x43, 0xff, 0x7b, 0x0e, 0xca, 0x0a, 0x59,
-	0x7b, 0x2e, 0xb9, 0xcd, 0xe7, 0xf2, 0x20, 0x45, 0x1c, 0x67, 0xa7, 0xaa, 0x36, 0x05, 0x75, 0x7b,
-	0x50, 0x08, 0x88, 0x4f, 0x05, 0x37, 0xb5, 0x83, 0x9d, 0x94, 0x99, 0xf6, 0xeb, 0x80, 0x2e, 0x31,
-	0xf1, 0x69, 0x2f, 0x83, 0x85, 0x0c, 0x7a, 0x06, 0x5b, 0x53, 0x27, 0x20, 0x36, 0x33, 0x37, 0x32,
-	0xa8, 0x29, 0x61, 0x23, 0x21, 0xb6, 0xc1, 0x0f, 0x24, 0x62, 0xa5, 0x47, 0xf9, 0xdb, 0xb4, 0xe3,
-	0x3a, 0x17, 0x8d, 0x8f, 0x7e, 0x2c, 0x8f, 0x76, 0x0f, 0xa1, 0x12, 0x1d, 0x45, 0xba, 0x72, 0x5e,
-	0x92, 0xd9, 0xe4, 0xea, 0x39, 0x1e, 0x3a, 0x8c, 0x06, 0x57, 0xca, 0x69, 0x04, 0x85, 0x54, 0xca,
-	0x88, 0xdf, 0x87, 0x15, 0x28, 0x85, 0x74, 0x15, 0xd8, 0x44, 0xff, 0x4b, 0x16, 0x1e, 0x88, 0x7b,
-	0xe2, 0x3a, 0x47, 0x01, 0xb9, 0x70, 0xe8, 0x2a, 0x4c, 0x65, 0xe8, 0x63, 0xa8, 0xfb, 0x0a, 0x35,
-	0xdf, 0xd1, 0x89, 0xb0, 0x54, 0xc5, 0x35, 0x3f, 0x91, 0xbc, 0xf6, 0xe6, 0x72, 0xd7, 0xdf, 0xdc,
-	0x7a, 0xa2, 0xe5, 0x3f, 0x21, 0xd1, 0xf4, 0x3f, 0x67, 0x61, 0xab, 0xef, 0x84, 0x3c, 0x8f, 0xc2,
-	0xc8, 0xa9, 0xef, 0x41, 0x69, 0xe6, 0xb8, 0x8c, 0x04, 0xad, 0x6c, 0xc2, 0xeb, 0x6b, 0x81, 0x74,
-	0x3f, 0xf8, 0x01, 0x09, 0x43, 0x87, 0x7a, 0x58, 0xc9, 0xa0, 0x2f, 0xa0, 0x48, 0x83, 0x29, 0x09,
-	0x5a, 0x39, 0x21, 0x7c, 0x8f, 0x0b, 0x0f, 0x39, 0x90, 0x92, 0x95, 0x12, 0x68, 0x07, 0x8a, 0x21,
-	0x27, 0x43, 0xb8, 0x58, 0xc4, 0x72, 0xc1, 0x51, 0xd7, 0x59, 0x3a, 0x4c, 0xe4, 0x48, 0x11, 0xcb,
-	0x85, 0xfe, 0x43, 0xd0, 0x36, 0x4d, 0xa2, 0x27, 0x50, 0x64, 0x24, 0x58, 0x86, 0xca, 0xaf, 0x66,
-	0xe2, 0xd7, 0x98, 0x04, 0x4b, 0x2c, 0x37, 0xf5, 0xdf, 0x01, 0x24, 0x20, 0xd7, 0x3e, 0x73, 0x88,
-	0x3b, 0x55, 0xd4, 0xca, 0x05, 0x47, 0x2f, 0x2c, 0x77, 0x45, 0x14, 0x9b, 0x72, 0x81, 0xf6, 0xa0,
-	0x4a, 0x7d, 0x12, 0x58, 0xcc, 0xa1, 0x9e, 0xf0, 0xb1, 0x79, 0x50, 0x4f, 0x6c, 0x0c, 0x7d, 0x9c,
-	0x6c, 0xa3, 0x6f, 0x41, 0xc9, 0x23, 0x73, 0x8b, 0x11, 0xe1, 0x76, 0x05, 0xab, 0x95, 0xde, 0x85,
-	0xad, 0x8d, 0xe8, 0x6f, 0x71, 0xe1, 0xdb, 0x50, 0xb5, 0x42, 0x9b, 0x78, 0x53, 0xc7, 0x9b, 0x0b,
-	0x37, 0x2a, 0x38, 0x01, 0xf4, 0x21, 0x68, 0xc9, 0xb5, 0xa8, 0x0e, 0xb1, 0x03, 0x45, 0x46, 0x99,
-	0xe5, 0x0a, 0x3d, 0x45, 0x2c, 0x17, 0xbc, 0x6f, 0x04, 0x24, 0x5c, 0xb9, 0x4c, 0x5d, 0xc0, 0x66,
-	0xdf, 0x90, 0x9b, 0xfa, 0x4f, 0x40, 0x33, 0x56, 0x93, 0xd0, 0x0e, 0x9c, 0x09, 0xf9, 0x9f, 0x2e,
-	0x5a, 0xff, 0x11, 0x6c, 0xa7, 0x34, 0x24, 0x5d, 0x4b, 0x59, 0xbf, 0xb9, 0x6b, 0x29, 0xeb, 0x9f,
-	0x43, 0xe3, 0x84, 0xa4, 0x4b, 0x33, 0x82, 0x02, 0x7f, 0x74, 0x8a, 0x12, 0xf1, 0x5b, 0xff, 0x1a,
-	0x9a, 0x91, 0xd0, 0xa7, 0x69, 0x5f, 0x40, 0x83, 0x93, 0x45, 0xbc, 0x3b, 0xb4, 0xa3, 0x16, 0x94,
-	0x57, 0xfe, 0xd4, 0x62, 0x24, 0x54, 0x6c, 0x47, 0x4b, 0xf4, 0x05, 0x14, 0x5c, 0x3a, 0x0f, 0xd5,
-	0x8d, 0xdf, 0xe7, 0x36, 0xd6, 0xd4, 0xf5, 0xe9, 0x3c, 0xc4, 0x42, 0x44, 0xa7, 0xd0, 0x8c, 0xb6,
-	0x94, 0x8b, 0xcf, 0xa0, 0x24, 0xf5, 0xdc, 0xe8, 0x62, 0x2f, 0x83, 0xd5, 0x36, 0x7f, 0x27, 0xa1,
-	0xeb, 0xd8, 0x44, 0x55, 0xdc, 0x6d, 0x61, 0x86, 0xce, 0x0d, 0x8e, 0x75, 0x2f, 0x88, 0xc7, 0x7a,
-	0x19, 0x2c, 0x25, 0xd2, 0x93, 0xc2, 0xef, 0x73, 0x50, 0x8d, 0xb5, 0xdd, 0x18, 0x57, 0xba, 0xea,
-	0xe7, 0x3e, 0x56, 0xf5, 0x75, 0x28, 0xfa, 0x0b, 0x2b, 0x24, 0xe9, 0xec, 0x7e, 0x43, 0x27, 0x23,
-	0x8e, 0x61, 0xb9, 0x85, 0x5e, 0x00, 0x9f, 0x94, 0xa6, 0x0e, 0x4f, 0xf3, 0x50, 0x15, 0xee, 0x6d,
-	0x25, 0x78, 0x14, 0x6f, 0xe0, 0x94, 0x10, 0xe7, 0x76, 0x4a, 0x98, 0xe5, 0xb8, 0xa1, 0xa8, 0xd8,
-	0x55, 0x1c, 0x2d, 0xd1, 0x33, 0x28, 0xcb, 0x4b, 0x0a, 0x55, 0x91, 0x8e, 0xf8, 0xc1, 0x02, 0xc5,
-	0xd1, 0x6e, 0xdc, 0x8f, 0xca, 0xb7, 0xf5, 0xa3, 0xbf, 0xe5, 0xa0, 0x96, 0x0a, 0x8a, 0xbf, 0x06,
-	0x7a, 0xe9, 0x89, 0xdc, 0x15, 0xaf, 0x4a, 0x2c, 0x50, 0x1b, 0x20, 0x88, 0x4b, 0xb4, 0xe2, 0x63,
-	0xb3, 0x70, 0xa7, 0x24, 0xd0, 0x73, 0x28, 0xb3, 0xc0, 0x99, 0xcf, 0x49, 0xa0, 0x28, 0x69, 0x2a,
-	0xcb, 0x63, 0x89, 0xe2, 0x68, 0x1b, 0xbd, 0x84, 0xb2, 0x1d, 0x10, 0x8b, 0x91, 0xa9, 0xe2, 0xe4,
-	0xae, 0x0a, 0x1b, 0x89, 0xa2, 0x1f, 0x40, 0x65, 0xe6, 0x78, 0x4e, 0xb8, 0x20, 0xd3, 0xff, 0x62,
-	0x02, 0x88, 0x65, 0xd1, 0x97, 0x50, 0xb3, 0x3c, 0x8f, 0x32, 0x4b, 0xde, 0x42, 0x29, 0x29, 0x78,
-	0x9d, 0x18, 0xc6, 0x69, 0x11, 0xa4, 0x43, 0x83, 0xb7, 0x61, 0xce, 0x95, 0x29, 0x92, 0x44, 0xf6,
-	0xb6, 0xda, 0x3b, 0xc9, 0xe2, 0x80, 0xbf, 0xb0, 0xbf, 0x66, 0x01, 0x12, 0x22, 0x78, 0x3a, 0x2d,
-	0x68, 0xc8, 0xa2, 0x74, 0xe2, 0xbf, 0x13, 0x5a, 0x73, 0x69, 0x5a, 0x91, 0xea, 0x84, 0x79, 0x29,
-	0x29, 0x3a, 0x9f, 0x06, 0xf9, 0x80, 0xcc, 0xd4, 0x0c, 0xc8, 0x7f, 0xf2, 0x61, 0x88, 0x37, 0x2e,
-	0x5e, 0x35, 0x54, 0x1e, 0xc4, 0x6b, 0xf4, 0x14, 0x9a, 0x53, 0x32, 0xb3, 0x56, 0x2e, 0x33, 0x27,
-	0x81, 0xe5, 0xd9, 0x0b, 0x35, 0xd4, 0x34, 0x14, 0x7a, 0x28, 0x40, 0xfd, 0x25, 0x40, 0x12, 0x20,
-	0x37, 0xf1, 0x9e, 0x5c, 0x29, 0xff, 0xf8, 0xcf, 0x9b, 0x0b, 0xb7, 0xfe, 0x8f, 0x2c, 0x34, 0xd6,
-	0xb2, 0x93, 0x67, 0x64, 0xb8, 0xb2, 0x6d, 0x12, 0xca, 0x71, 0xba, 0x82, 0xa3, 0x25, 0xfa, 0x1c,
-	0x1a, 0x33, 0xcb, 0x71, 0x57, 0x01, 0x31, 0x6d, 0xba, 0xf2, 0x98, 0xd0, 0x54, 0xc4, 0x75, 0x05,
-	0x1e, 0x71, 0x0c, 0x7d, 0x07, 0xc0, 0xb6, 0x3c, 0x33, 0x20, 0xbe, 0x6b, 0x5d, 0x89, 0xa8, 0x2b,
-	0xb8, 0x6a, 0x5b, 0x1e, 0x16, 0xc0, 0x46, 0xc3, 0x2d, 0x7c, 0xe2, 0x64, 0x37, 0x75, 0xa6, 0x26,
-	0xf9, 0x40, 0xec, 0x15, 0x53, 0x9f, 0x0b, 0x18, 0xa6, 0xce, 0xb4, 0x2b, 0x11, 0xfd, 0x52, 0x3c,
-	0x78, 0xf9, 0x3c, 0x38, 0xef, 0xec, 0xca, 0x8f, 0x1f, 0x3c, 0xff, 0xcd, 0x43, 0xf3, 0xad, 0x2b,
-	0x31, 0xf7, 0xa8, 0x39, 0x55, 0x2d, 0xd1, 0x23, 0xa8, 0x4d, 0x09, 0x2f, 0xd0, 0x7e, 0xdc, 0xc1,
-	0xaa, 0x38, 0x0d, 0xf1, 0x1b, 0xb2, 0x17, 0x96, 0xe7, 0x11, 0x97, 0xbf, 0xec, 0x3c, 0xbf, 0xa1,
-	0x68, 0xad, 0xdb, 0xd0, 0x58, 0xab, 0x47, 0x37, 0x56, 0x9b, 0x27, 0xca, 0xa1, 0x9c, 0x78, 0x2c,
-	0x5a, 0xba, 0x88, 0x8d, 0xaf, 0x7c, 0x72, 0xdd, 0xc5, 0xfc, 0x9a, 0x8b, 0xfa, 0x13, 0x68, 0x1a,
-	0x8c, 0xfa, 0x1f, 0xe9, 0x04, 0xdb, 0x7c, 0x96, 0x57, 0x52, 0xb2, 0xce, 0xee, 0x99, 0x50, 0x89,
-	0xda, 0x30, 0x6a, 0x40, 0x75, 0x38, 0x32, 0xbb, 0x3f, 0x3b, 0xeb, 0xf4, 0x0d, 0x2d, 0x83, 0x10,
-	0x34, 0x87, 0x23, 0xd3, 0x18, 0x77, 0xf0, 0xd8, 0x30, 0xcf, 0x4f, 0xc7, 0x3d, 0x2d, 0x8b, 0x34,
-	0xa8, 0x73, 0x91, 0xc1, 0xb1, 0x42, 0x72, 0x68, 0x0b, 0x6a, 0xc3, 0x91, 0x79, 0x34, 0x1c, 0x8c,
-	0x3b, 0xa7, 0x03, 0x43, 0xcb, 0x47, 0x5a, 0x7e, 0x71, 0x6a, 0x8c, 0x0d, 0xad, 0xb0, 0xf7, 0x73,
-	0xd8, 0xbe, 0x56, 0xf5, 0xd1, 0x36, 0x34, 0xfa, 0xc3, 0x13, 0xc3, 0x3c, 0x3e, 0x35, 0x3a, 0x87,
-	0xfd, 0xee, 0xb1, 0x96, 0x89, 0xa1, 0xb3, 0x81, 0xd1, 0x3f, 0x3d, 0xea, 0x1e, 0x6b, 0x59, 0x54,
-	0x87, 0x8a, 0x80, 0x70, 0xe7, 0x5c, 0xcb, 0x71, 0xbd, 0x62, 0xd5, 0x1b, 0xbf, 0xed, 0x6b, 0xf9,
-	0xbd, 0x5f, 0x03, 0x24, 0xe5, 0x04, 0xdd, 0x83, 0xad, 0x31, 0x3e, 0x3d, 0x39, 0xe9, 0x62, 0xf3,
-	0x6c, 0xf0, 0xd3, 0xc1, 0xf0, 0x7c, 0x20, 0x03, 0x88, 0xc0, 0xb7, 0x9d, 0xc1, 0x59, 0xa7, 0x2f,
-	0x03, 0x88, 0xb0, 0xd1, 0x99, 0xc1, 0x03, 0x48, 0x1d, 0x3d, 0xee, 0xf6, 0xbb, 0xe3, 0xee, 0xb1,
-	0x96, 0xdf, 0xfb, 0x63, 0x16, 0x2a, 0x51, 0x01, 0xe7, 0xae, 0x8d, 0x7a, 0x1d, 0xa3, 0x9b, 0x52,
-	0x7d, 0x0f, 0xb6, 0x24, 0x34, 0xc2, 0xdd, 0x51, 0x07, 0x9f, 0x0e, 0x4e, 0xb4, 0x2c, 0xb7, 0x27,
-	0x41, 0xc1, 0x19, 0xc7, 0x72, 0xc9, 0x59, 0x7c, 0x36, 0x18, 0x70, 0x28, 0x8f, 0x9a, 0x00, 0x12,
-	0x3a, 0x1e, 0x0e, 0xba, 0x5a, 0x21, 0x11, 0x39, 0xea, 0x77, 0x3b, 0x83, 0xb3, 0x91, 0x56, 0x4c,
-	0xa0, 0xf3, 0xce, 0xa9, 0x50, 0x54, 0xda, 0xfb, 0x43, 0x16, 0xea, 0xe9, 0x94, 0xe0, 0x2e, 0x08,
-	0xa6, 0xcc, 0xce, 0x61, 0x67, 0xc0, 0x55, 0x71, 0x16, 0xb7, 0xa0, 0x26, 0x41, 0x71, 0x5c, 0xcb,
-	0x26, 0x80, 0xf0, 0x49, 0x3a, 0x24, 0x01, 0x7e, 0x65, 0xdd, 0xc1, 0x58, 0x3a, 0x24, 0x21, 0xe5,
-	0x50, 0xbc, 0x7e, 0xdd, 0x39, 0xed, 0x6b, 0x45, 0xce, 0x99, 0x5c, 0xe3, 0xae, 0x71, 0xd6, 0x1f,
-	0x6b, 0xa5, 0x83, 0x7f, 0x17, 0xa0, 0x7e, 0x4e, 0x82, 0x19, 0x33, 0x48, 0x70, 0xe1, 0xd8, 0x04,
-	0x1d, 0x41, 0x63, 0xed, 0x2f, 0x06, 0xd4, 0xe2, 0x29, 0x7c, 0xd3, 0xbf, 0x0e, 0xbb, 0x3b, 0xf1,
-	0x4e, 0x2a, 0x0f, 0xf5, 0xcc, 0xf3, 0x2c, 0x3a, 0xe2, 0x49, 0x9c, 0xfe, 0x04, 0x47, 0x9f, 0xc5,
-	0xb2, 0x9b, 0x9f, 0xe5, 0xb7, 0xa9, 0x41, 0x43, 0xf5, 0x67, 0xc7, 0xc6, 0x97, 0x01, 0x7a, 0x18,
-	0xcb, 0xdf, 0xfc, 0xcd, 0x70, 0xab, 0xc2, 0xaf, 0xa1, 0x12, 0xa1, 0xe8, 0xde, 0xba, 0xcc, 0xdd,
-	0x07, 0x5f, 0x41, 0x35, 0x42, 0x0f, 0xd0, 0xce, 0x0d, 0x27, 0x0f, 0xee, 0xb2, 0x19, 0x8d, 0xa9,
-	0xd2, 0xe6, 0xc6, 0xb7, 0x84, 0x3c, 0xb8, 0x39, 0xc9, 0xea, 0x19, 0xf4, 0x63, 0xa8, 0xc6, 0xc3,
-	0xa4, 0xb2, 0xb9, 0x31, 0x9d, 0xee, 0xde, 0xdf, 0x40, 0xa3, 0xb3, 0x5f, 0x66, 0xd1, 0x0b, 0x28,
-	0xc9, 0x49, 0x11, 0x89, 0xc1, 0x64, 0x6d, 0xb4, 0xdc, 0x45, 0x69, 0x28, 0x36, 0xf8, 0x15, 0x94,
-	0xe4, 0xf3, 0x96, 0x47, 0xd6, 0x9e, 0xba, 0x3c, 0xb2, 0x3e, 0xd8, 0x09, 0x3b, 0x2f, 0xa1, 0xac,
-	0xea, 0x10, 0x42, 0x92, 0x81, 0x74, 0xe9, 0xda, 0xbd, 0xb7, 0x86, 0x45, 0xe7, 0x0e, 0x9f, 0xfd,
-	0xea, 0xa9, 0xfc, 0x3a, 0x6b, 0xdb, 0x74, 0xb9, 0x6f, 0x87, 0x97, 0xc4, 0xb1, 0x17, 0xc4, 0xdd,
-	0x17, 0x7f, 0x88, 0xed, 0xfb, 0xef, 0xe7, 0xfb, 0x96, 0xef, 0xec, 0x5f, 0xbc, 0x98, 0x94, 0x44,
-	0xab, 0xf8, 0xea, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xc3, 0xc3, 0x95, 0xba, 0x2b, 0x13, 0x00,
-	0x00,
+func (m *ApproveJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveJobRequest.Unmarshal(m, b)
+}
+func (m *ApproveJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveJobRequest.Marshal(b, m, deterministic)
+}
+func (m *ApproveJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveJobRequest.Merge(m, src)
+}
+func (m *ApproveJobRequest) XXX_Size() int {
+	return xxx_messageInfo_ApproveJobRequest.Size(m)
+}
+func (m *ApproveJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveJobRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+var xxx_messageInfo_ApproveJobRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (m *ApproveJobRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
 
-// WerftServiceClient is the client API for WerftService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type WerftServiceClient interface {
-	// StartLocalJob starts a job by uploading the workspace content directly. The incoming requests are expected in the following order:
-	//   1. metadata
-	//   2. all bytes constituting the werft/config.yaml
-	//   3. all bytes constituting the job YAML that will be executed (that the config.yaml points to)
-	//   4. all bytes constituting the gzipped workspace tar stream
-	//   5. the workspace tar stream done marker
-	StartLocalJob(ctx context.Context, opts ...grpc.CallOption) (WerftService_StartLocalJobClient, error)
-	// StartGitHubJob starts a job on a Git context, possibly with a custom job.
-	StartGitHubJob(ctx context.Context, in *StartGitHubJobRequest, opts ...grpc.CallOption) (*StartJobResponse, error)
-	// StartFromPreviousJob starts a new job based on a previous one.
-	// If the previous job does not have the can-replay condition set this call will result in an error.
-	StartFromPreviousJob(ctx context.Context, in *StartFromPreviousJobRequest, opts ...grpc.CallOption) (*StartJobResponse, error)
-	// StartJobRequest starts a new job based on its specification.
-	StartJob(ctx context.Context, in *StartJobRequest, opts ...grpc.CallOption) (*StartJobResponse, error)
-	// StartJob2 starts a new job based on its specification.
-	StartJob2(ctx context.Context, in *StartJobRequest2, opts ...grpc.CallOption) (*StartJobResponse, error)
-	// Searches for jobs known to this instance
-	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
-	// Subscribe listens to new jobs/job updates
-	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (WerftService_SubscribeClient, error)
-	// GetJob retrieves details of a single job
-	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
-	// Listen listens to job updates and log output of a running job
-	Listen(ctx context.Context, in *ListenRequest, opts ...grpc.CallOption) (WerftService_ListenClient, error)
-	// StopJob stops a currently running job
-	StopJob(ctx context.Context, in *StopJobRequest, opts ...grpc.CallOption) (*StopJobResponse, error)
+func (m *ApproveJobRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
 }
 
-type werftServiceClient struct {
-	cc grpc.ClientConnInterface
+type ApproveJobResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func NewWerftServiceClient(cc grpc.ClientConnInterface) WerftServiceClient {
-	return &werftServiceClient{cc}
+func (m *ApproveJobResponse) Reset()         { *m = ApproveJobResponse{} }
+func (m *ApproveJobResponse) String() string { return proto.CompactTextString(m) }
+func (*ApproveJobResponse) ProtoMessage()    {}
+func (*ApproveJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{30}
 }
 
-func (c *werftServiceClient) StartLocalJob(ctx context.Context, opts ...grpc.CallOption) (WerftService_StartLocalJobClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_WerftService_serviceDesc.Streams[0], "/v1.WerftService/StartLocalJob", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &werftServiceStartLocalJobClient{stream}
-	return x, nil
+func (m *ApproveJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveJobResponse.Unmarshal(m, b)
+}
+func (m *ApproveJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveJobResponse.Marshal(b, m, deterministic)
+}
+func (m *ApproveJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveJobResponse.Merge(m, src)
+}
+func (m *ApproveJobResponse) XXX_Size() int {
+	return xxx_messageInfo_ApproveJobResponse.Size(m)
+}
+func (m *ApproveJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveJobResponse.DiscardUnknown(m)
 }
 
-type WerftService_StartLocalJobClient interface {
-	Send(*StartLocalJobRequest) error
-	CloseAndRecv() (*StartJobResponse, error)
-	grpc.ClientStream
+var xxx_messageInfo_ApproveJobResponse proto.InternalMessageInfo
+
+type RejectJobRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Comment              string   `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type werftServiceStartLocalJobClient struct {
-	grpc.ClientStream
+func (m *RejectJobRequest) Reset()         { *m = RejectJobRequest{} }
+func (m *RejectJobRequest) String() string { return proto.CompactTextString(m) }
+func (*RejectJobRequest) ProtoMessage()    {}
+func (*RejectJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{31}
 }
 
-func (x *werftServiceStartLocalJobClient) Send(m *StartLocalJobRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *RejectJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectJobRequest.Unmarshal(m, b)
+}
+func (m *RejectJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectJobRequest.Marshal(b, m, deterministic)
+}
+func (m *RejectJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectJobRequest.Merge(m, src)
+}
+func (m *RejectJobRequest) XXX_Size() int {
+	return xxx_messageInfo_RejectJobRequest.Size(m)
+}
+func (m *RejectJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectJobRequest.DiscardUnknown(m)
 }
 
-func (x *werftServiceStartLocalJobClient) CloseAndRecv() (*StartJobResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(StartJobResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+var xxx_messageInfo_RejectJobRequest proto.InternalMessageInfo
+
+func (m *RejectJobRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return m, nil
+	return ""
+}
+
+func (m *RejectJobRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+type RejectJobResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectJobResponse) Reset()         { *m = RejectJobResponse{} }
+func (m *RejectJobResponse) String() string { return proto.CompactTextString(m) }
+func (*RejectJobResponse) ProtoMessage()    {}
+func (*RejectJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{32}
+}
+
+func (m *RejectJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectJobResponse.Unmarshal(m, b)
+}
+func (m *RejectJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectJobResponse.Marshal(b, m, deterministic)
+}
+func (m *RejectJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectJobResponse.Merge(m, src)
+}
+func (m *RejectJobResponse) XXX_Size() int {
+	return xxx_messageInfo_RejectJobResponse.Size(m)
+}
+func (m *RejectJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectJobResponse proto.InternalMessageInfo
+
+type PinJobRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PinJobRequest) Reset()         { *m = PinJobRequest{} }
+func (m *PinJobRequest) String() string { return proto.CompactTextString(m) }
+func (*PinJobRequest) ProtoMessage()    {}
+func (*PinJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{33}
+}
+
+func (m *PinJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PinJobRequest.Unmarshal(m, b)
+}
+func (m *PinJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PinJobRequest.Marshal(b, m, deterministic)
+}
+func (m *PinJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinJobRequest.Merge(m, src)
+}
+func (m *PinJobRequest) XXX_Size() int {
+	return xxx_messageInfo_PinJobRequest.Size(m)
+}
+func (m *PinJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PinJobRequest proto.InternalMessageInfo
+
+func (m *PinJobRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type PinJobResponse struct {
+	Status               *JobStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PinJobResponse) Reset()         { *m = PinJobResponse{} }
+func (m *PinJobResponse) String() string { return proto.CompactTextString(m) }
+func (*PinJobResponse) ProtoMessage()    {}
+func (*PinJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{34}
+}
+
+func (m *PinJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PinJobResponse.Unmarshal(m, b)
+}
+func (m *PinJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PinJobResponse.Marshal(b, m, deterministic)
+}
+func (m *PinJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinJobResponse.Merge(m, src)
+}
+func (m *PinJobResponse) XXX_Size() int {
+	return xxx_messageInfo_PinJobResponse.Size(m)
+}
+func (m *PinJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PinJobResponse proto.InternalMessageInfo
+
+func (m *PinJobResponse) GetStatus() *JobStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type UnpinJobRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnpinJobRequest) Reset()         { *m = UnpinJobRequest{} }
+func (m *UnpinJobRequest) String() string { return proto.CompactTextString(m) }
+func (*UnpinJobRequest) ProtoMessage()    {}
+func (*UnpinJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{35}
+}
+
+func (m *UnpinJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnpinJobRequest.Unmarshal(m, b)
+}
+func (m *UnpinJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnpinJobRequest.Marshal(b, m, deterministic)
+}
+func (m *UnpinJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnpinJobRequest.Merge(m, src)
+}
+func (m *UnpinJobRequest) XXX_Size() int {
+	return xxx_messageInfo_UnpinJobRequest.Size(m)
+}
+func (m *UnpinJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnpinJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnpinJobRequest proto.InternalMessageInfo
+
+func (m *UnpinJobRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type UnpinJobResponse struct {
+	Status               *JobStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UnpinJobResponse) Reset()         { *m = UnpinJobResponse{} }
+func (m *UnpinJobResponse) String() string { return proto.CompactTextString(m) }
+func (*UnpinJobResponse) ProtoMessage()    {}
+func (*UnpinJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{36}
+}
+
+func (m *UnpinJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnpinJobResponse.Unmarshal(m, b)
+}
+func (m *UnpinJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnpinJobResponse.Marshal(b, m, deterministic)
+}
+func (m *UnpinJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnpinJobResponse.Merge(m, src)
+}
+func (m *UnpinJobResponse) XXX_Size() int {
+	return xxx_messageInfo_UnpinJobResponse.Size(m)
+}
+func (m *UnpinJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnpinJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnpinJobResponse proto.InternalMessageInfo
+
+func (m *UnpinJobResponse) GetStatus() *JobStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type GetStatisticsRequest struct {
+	Filter []*FilterExpression `protobuf:"bytes,1,rep,name=filter,proto3" json:"filter,omitempty"`
+	// from is the beginning of the time window (inclusive) of job creation. Defaults to the beginning of time.
+	From *timestamp.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// to is the end of the time window (exclusive) of job creation. Defaults to now.
+	To                   *timestamp.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetStatisticsRequest) Reset()         { *m = GetStatisticsRequest{} }
+func (m *GetStatisticsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStatisticsRequest) ProtoMessage()    {}
+func (*GetStatisticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{37}
+}
+
+func (m *GetStatisticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatisticsRequest.Unmarshal(m, b)
+}
+func (m *GetStatisticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatisticsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStatisticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatisticsRequest.Merge(m, src)
+}
+func (m *GetStatisticsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStatisticsRequest.Size(m)
+}
+func (m *GetStatisticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatisticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatisticsRequest proto.InternalMessageInfo
+
+func (m *GetStatisticsRequest) GetFilter() []*FilterExpression {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *GetStatisticsRequest) GetFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *GetStatisticsRequest) GetTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+type GetStatisticsResponse struct {
+	Statistics           []*JobStatistics `protobuf:"bytes,1,rep,name=statistics,proto3" json:"statistics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetStatisticsResponse) Reset()         { *m = GetStatisticsResponse{} }
+func (m *GetStatisticsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStatisticsResponse) ProtoMessage()    {}
+func (*GetStatisticsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{38}
+}
+
+func (m *GetStatisticsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatisticsResponse.Unmarshal(m, b)
+}
+func (m *GetStatisticsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatisticsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStatisticsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatisticsResponse.Merge(m, src)
+}
+func (m *GetStatisticsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStatisticsResponse.Size(m)
+}
+func (m *GetStatisticsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatisticsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatisticsResponse proto.InternalMessageInfo
+
+func (m *GetStatisticsResponse) GetStatistics() []*JobStatistics {
+	if m != nil {
+		return m.Statistics
+	}
+	return nil
+}
+
+type JobStatistics struct {
+	// repository identifies the repository and branch (ref) of the jobs. The revision is not set.
+	Repository   *Repository `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	JobSpecName  string      `protobuf:"bytes,2,opt,name=job_spec_name,json=jobSpecName,proto3" json:"job_spec_name,omitempty"`
+	RunCount     int32       `protobuf:"varint,3,opt,name=run_count,json=runCount,proto3" json:"run_count,omitempty"`
+	SuccessCount int32       `protobuf:"varint,4,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	SuccessRate  float64     `protobuf:"fixed64,5,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	// duration_p50_seconds is the median time between job creation and finish
+	DurationP50Seconds float64 `protobuf:"fixed64,6,opt,name=duration_p50_seconds,json=durationP50Seconds,proto3" json:"duration_p50_seconds,omitempty"`
+	DurationP95Seconds float64 `protobuf:"fixed64,7,opt,name=duration_p95_seconds,json=durationP95Seconds,proto3" json:"duration_p95_seconds,omitempty"`
+	// mttr_seconds is the mean time to recovery, i.e. the mean time between the first failed job and
+	// the next successful one.
+	MttrSeconds float64 `protobuf:"fixed64,8,opt,name=mttr_seconds,json=mttrSeconds,proto3" json:"mttr_seconds,omitempty"`
+	// recovery_count is the number of recoveries the MTTR is based on
+	RecoveryCount        int32    `protobuf:"varint,9,opt,name=recovery_count,json=recoveryCount,proto3" json:"recovery_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobStatistics) Reset()         { *m = JobStatistics{} }
+func (m *JobStatistics) String() string { return proto.CompactTextString(m) }
+func (*JobStatistics) ProtoMessage()    {}
+func (*JobStatistics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{39}
+}
+
+func (m *JobStatistics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobStatistics.Unmarshal(m, b)
+}
+func (m *JobStatistics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobStatistics.Marshal(b, m, deterministic)
+}
+func (m *JobStatistics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobStatistics.Merge(m, src)
+}
+func (m *JobStatistics) XXX_Size() int {
+	return xxx_messageInfo_JobStatistics.Size(m)
+}
+func (m *JobStatistics) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobStatistics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobStatistics proto.InternalMessageInfo
+
+func (m *JobStatistics) GetRepository() *Repository {
+	if m != nil {
+		return m.Repository
+	}
+	return nil
+}
+
+func (m *JobStatistics) GetJobSpecName() string {
+	if m != nil {
+		return m.JobSpecName
+	}
+	return ""
+}
+
+func (m *JobStatistics) GetRunCount() int32 {
+	if m != nil {
+		return m.RunCount
+	}
+	return 0
+}
+
+func (m *JobStatistics) GetSuccessCount() int32 {
+	if m != nil {
+		return m.SuccessCount
+	}
+	return 0
+}
+
+func (m *JobStatistics) GetSuccessRate() float64 {
+	if m != nil {
+		return m.SuccessRate
+	}
+	return 0
+}
+
+func (m *JobStatistics) GetDurationP50Seconds() float64 {
+	if m != nil {
+		return m.DurationP50Seconds
+	}
+	return 0
+}
+
+func (m *JobStatistics) GetDurationP95Seconds() float64 {
+	if m != nil {
+		return m.DurationP95Seconds
+	}
+	return 0
+}
+
+func (m *JobStatistics) GetMttrSeconds() float64 {
+	if m != nil {
+		return m.MttrSeconds
+	}
+	return 0
+}
+
+func (m *JobStatistics) GetRecoveryCount() int32 {
+	if m != nil {
+		return m.RecoveryCount
+	}
+	return 0
+}
+
+type ValidateJobRequest struct {
+	Metadata             *JobMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Spec                 *JobSpec     `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ValidateJobRequest) Reset()         { *m = ValidateJobRequest{} }
+func (m *ValidateJobRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateJobRequest) ProtoMessage()    {}
+func (*ValidateJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{40}
+}
+
+func (m *ValidateJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateJobRequest.Unmarshal(m, b)
+}
+func (m *ValidateJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateJobRequest.Marshal(b, m, deterministic)
+}
+func (m *ValidateJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateJobRequest.Merge(m, src)
+}
+func (m *ValidateJobRequest) XXX_Size() int {
+	return xxx_messageInfo_ValidateJobRequest.Size(m)
+}
+func (m *ValidateJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateJobRequest proto.InternalMessageInfo
+
+func (m *ValidateJobRequest) GetMetadata() *JobMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ValidateJobRequest) GetSpec() *JobSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+type ValidateJobResponse struct {
+	// valid is true if the job could be started
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// name is the name the job would have, without its number
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// job_path is the path of the validated job spec. It's empty if the job YAML was part of the request.
+	JobPath string `protobuf:"bytes,3,opt,name=job_path,json=jobPath,proto3" json:"job_path,omitempty"`
+	// pod_spec is the rendered pod spec as YAML. Values of secrets the job template uses are replaced by placeholders.
+	PodSpec              string           `protobuf:"bytes,4,opt,name=pod_spec,json=podSpec,proto3" json:"pod_spec,omitempty"`
+	Diagnostics          []*JobDiagnostic `protobuf:"bytes,5,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ValidateJobResponse) Reset()         { *m = ValidateJobResponse{} }
+func (m *ValidateJobResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateJobResponse) ProtoMessage()    {}
+func (*ValidateJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{41}
+}
+
+func (m *ValidateJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateJobResponse.Unmarshal(m, b)
+}
+func (m *ValidateJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateJobResponse.Marshal(b, m, deterministic)
+}
+func (m *ValidateJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateJobResponse.Merge(m, src)
+}
+func (m *ValidateJobResponse) XXX_Size() int {
+	return xxx_messageInfo_ValidateJobResponse.Size(m)
+}
+func (m *ValidateJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateJobResponse proto.InternalMessageInfo
+
+func (m *ValidateJobResponse) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *ValidateJobResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ValidateJobResponse) GetJobPath() string {
+	if m != nil {
+		return m.JobPath
+	}
+	return ""
+}
+
+func (m *ValidateJobResponse) GetPodSpec() string {
+	if m != nil {
+		return m.PodSpec
+	}
+	return ""
+}
+
+func (m *ValidateJobResponse) GetDiagnostics() []*JobDiagnostic {
+	if m != nil {
+		return m.Diagnostics
+	}
+	return nil
+}
+
+type JobDiagnostic struct {
+	Severity DiagnosticSeverity `protobuf:"varint,1,opt,name=severity,proto3,enum=v1.DiagnosticSeverity" json:"severity,omitempty"`
+	// stage names the step of the job preparation which produced this diagnostic, e.g. template or podspec
+	Stage                string   `protobuf:"bytes,2,opt,name=stage,proto3" json:"stage,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobDiagnostic) Reset()         { *m = JobDiagnostic{} }
+func (m *JobDiagnostic) String() string { return proto.CompactTextString(m) }
+func (*JobDiagnostic) ProtoMessage()    {}
+func (*JobDiagnostic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{42}
+}
+
+func (m *JobDiagnostic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobDiagnostic.Unmarshal(m, b)
+}
+func (m *JobDiagnostic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobDiagnostic.Marshal(b, m, deterministic)
+}
+func (m *JobDiagnostic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobDiagnostic.Merge(m, src)
+}
+func (m *JobDiagnostic) XXX_Size() int {
+	return xxx_messageInfo_JobDiagnostic.Size(m)
+}
+func (m *JobDiagnostic) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobDiagnostic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobDiagnostic proto.InternalMessageInfo
+
+func (m *JobDiagnostic) GetSeverity() DiagnosticSeverity {
+	if m != nil {
+		return m.Severity
+	}
+	return DiagnosticSeverity_SEVERITY_UNKNOWN
+}
+
+func (m *JobDiagnostic) GetStage() string {
+	if m != nil {
+		return m.Stage
+	}
+	return ""
+}
+
+func (m *JobDiagnostic) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type Schedule struct {
+	// name identifies the schedule
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// cron is the cron expression of the schedule, e.g. "0 4 * * *" or "@daily"
+	Cron string `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	// metadata and spec of the jobs this schedule starts, like in StartJob2
+	Metadata *JobMetadata         `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Spec     *JobSpec             `protobuf:"bytes,4,opt,name=spec,proto3" json:"spec,omitempty"`
+	Paused   bool                 `protobuf:"varint,5,opt,name=paused,proto3" json:"paused,omitempty"`
+	Created  *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	// next_run is the time the schedule starts a job next
+	NextRun *timestamp.Timestamp `protobuf:"bytes,7,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	// last_run is the time the schedule last started a job
+	LastRun *timestamp.Timestamp `protobuf:"bytes,8,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	// last_job is the name of the job started by the last run. It's empty if that job could not be started.
+	LastJob string `protobuf:"bytes,9,opt,name=last_job,json=lastJob,proto3" json:"last_job,omitempty"`
+	// last_error is the reason the last run could not start a job
+	LastError            string   `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Schedule) Reset()         { *m = Schedule{} }
+func (m *Schedule) String() string { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()    {}
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{43}
+}
+
+func (m *Schedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Schedule.Unmarshal(m, b)
+}
+func (m *Schedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Schedule.Marshal(b, m, deterministic)
+}
+func (m *Schedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schedule.Merge(m, src)
+}
+func (m *Schedule) XXX_Size() int {
+	return xxx_messageInfo_Schedule.Size(m)
+}
+func (m *Schedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Schedule proto.InternalMessageInfo
+
+func (m *Schedule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Schedule) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *Schedule) GetMetadata() *JobMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Schedule) GetSpec() *JobSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (m *Schedule) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *Schedule) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *Schedule) GetNextRun() *timestamp.Timestamp {
+	if m != nil {
+		return m.NextRun
+	}
+	return nil
+}
+
+func (m *Schedule) GetLastRun() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastRun
+	}
+	return nil
+}
+
+func (m *Schedule) GetLastJob() string {
+	if m != nil {
+		return m.LastJob
+	}
+	return ""
+}
+
+func (m *Schedule) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+type CreateScheduleRequest struct {
+	Schedule             *Schedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CreateScheduleRequest) Reset()         { *m = CreateScheduleRequest{} }
+func (m *CreateScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateScheduleRequest) ProtoMessage()    {}
+func (*CreateScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{44}
+}
+
+func (m *CreateScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateScheduleRequest.Unmarshal(m, b)
+}
+func (m *CreateScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateScheduleRequest.Merge(m, src)
+}
+func (m *CreateScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateScheduleRequest.Size(m)
+}
+func (m *CreateScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateScheduleRequest proto.InternalMessageInfo
+
+func (m *CreateScheduleRequest) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type CreateScheduleResponse struct {
+	Schedule             *Schedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CreateScheduleResponse) Reset()         { *m = CreateScheduleResponse{} }
+func (m *CreateScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateScheduleResponse) ProtoMessage()    {}
+func (*CreateScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{45}
+}
+
+func (m *CreateScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateScheduleResponse.Unmarshal(m, b)
+}
+func (m *CreateScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateScheduleResponse.Merge(m, src)
+}
+func (m *CreateScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateScheduleResponse.Size(m)
+}
+func (m *CreateScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateScheduleResponse proto.InternalMessageInfo
+
+func (m *CreateScheduleResponse) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type ListSchedulesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSchedulesRequest) Reset()         { *m = ListSchedulesRequest{} }
+func (m *ListSchedulesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSchedulesRequest) ProtoMessage()    {}
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{46}
+}
+
+func (m *ListSchedulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSchedulesRequest.Unmarshal(m, b)
+}
+func (m *ListSchedulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSchedulesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSchedulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchedulesRequest.Merge(m, src)
+}
+func (m *ListSchedulesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSchedulesRequest.Size(m)
+}
+func (m *ListSchedulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchedulesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSchedulesRequest proto.InternalMessageInfo
+
+type ListSchedulesResponse struct {
+	Schedules            []*Schedule `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListSchedulesResponse) Reset()         { *m = ListSchedulesResponse{} }
+func (m *ListSchedulesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSchedulesResponse) ProtoMessage()    {}
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{47}
+}
+
+func (m *ListSchedulesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSchedulesResponse.Unmarshal(m, b)
+}
+func (m *ListSchedulesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSchedulesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSchedulesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchedulesResponse.Merge(m, src)
+}
+func (m *ListSchedulesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSchedulesResponse.Size(m)
+}
+func (m *ListSchedulesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchedulesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSchedulesResponse proto.InternalMessageInfo
+
+func (m *ListSchedulesResponse) GetSchedules() []*Schedule {
+	if m != nil {
+		return m.Schedules
+	}
+	return nil
+}
+
+type DeleteScheduleRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteScheduleRequest) Reset()         { *m = DeleteScheduleRequest{} }
+func (m *DeleteScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteScheduleRequest) ProtoMessage()    {}
+func (*DeleteScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{48}
+}
+
+func (m *DeleteScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteScheduleRequest.Unmarshal(m, b)
+}
+func (m *DeleteScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteScheduleRequest.Merge(m, src)
+}
+func (m *DeleteScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteScheduleRequest.Size(m)
+}
+func (m *DeleteScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteScheduleRequest proto.InternalMessageInfo
+
+func (m *DeleteScheduleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteScheduleResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteScheduleResponse) Reset()         { *m = DeleteScheduleResponse{} }
+func (m *DeleteScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteScheduleResponse) ProtoMessage()    {}
+func (*DeleteScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{49}
+}
+
+func (m *DeleteScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteScheduleResponse.Unmarshal(m, b)
+}
+func (m *DeleteScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteScheduleResponse.Merge(m, src)
+}
+func (m *DeleteScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteScheduleResponse.Size(m)
+}
+func (m *DeleteScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteScheduleResponse proto.InternalMessageInfo
+
+type PauseScheduleRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// paused pauses the schedule if true, and resumes it otherwise
+	Paused               bool     `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseScheduleRequest) Reset()         { *m = PauseScheduleRequest{} }
+func (m *PauseScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*PauseScheduleRequest) ProtoMessage()    {}
+func (*PauseScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{50}
+}
+
+func (m *PauseScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseScheduleRequest.Unmarshal(m, b)
+}
+func (m *PauseScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *PauseScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseScheduleRequest.Merge(m, src)
+}
+func (m *PauseScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_PauseScheduleRequest.Size(m)
+}
+func (m *PauseScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseScheduleRequest proto.InternalMessageInfo
+
+func (m *PauseScheduleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PauseScheduleRequest) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+type PauseScheduleResponse struct {
+	Schedule             *Schedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PauseScheduleResponse) Reset()         { *m = PauseScheduleResponse{} }
+func (m *PauseScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*PauseScheduleResponse) ProtoMessage()    {}
+func (*PauseScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9fe744feedd6d332, []int{51}
+}
+
+func (m *PauseScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseScheduleResponse.Unmarshal(m, b)
+}
+func (m *PauseScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *PauseScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseScheduleResponse.Merge(m, src)
+}
+func (m *PauseScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_PauseScheduleResponse.Size(m)
+}
+func (m *PauseScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseScheduleResponse proto.InternalMessageInfo
+
+func (m *PauseScheduleResponse) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("v1.FilterOp", FilterOp_name, FilterOp_value)
+	proto.RegisterEnum("v1.ListenRequestLogs", ListenRequestLogs_name, ListenRequestLogs_value)
+	proto.RegisterEnum("v1.JobTrigger", JobTrigger_name, JobTrigger_value)
+	proto.RegisterEnum("v1.JobPhase", JobPhase_name, JobPhase_value)
+	proto.RegisterEnum("v1.LogSliceType", LogSliceType_name, LogSliceType_value)
+	proto.RegisterEnum("v1.DiagnosticSeverity", DiagnosticSeverity_name, DiagnosticSeverity_value)
+	proto.RegisterType((*StartLocalJobRequest)(nil), "v1.StartLocalJobRequest")
+	proto.RegisterType((*StartJobResponse)(nil), "v1.StartJobResponse")
+	proto.RegisterType((*StartGitHubJobRequest)(nil), "v1.StartGitHubJobRequest")
+	proto.RegisterType((*StartJobRequest)(nil), "v1.StartJobRequest")
+	proto.RegisterType((*StartJobRequest2)(nil), "v1.StartJobRequest2")
+	proto.RegisterType((*JobSpec)(nil), "v1.JobSpec")
+	proto.RegisterType((*JobSpec_FromRepo)(nil), "v1.JobSpec.FromRepo")
+	proto.RegisterType((*StartFromPreviousJobRequest)(nil), "v1.StartFromPreviousJobRequest")
+	proto.RegisterType((*ListJobsRequest)(nil), "v1.ListJobsRequest")
+	proto.RegisterType((*FilterExpression)(nil), "v1.FilterExpression")
+	proto.RegisterType((*FilterTerm)(nil), "v1.FilterTerm")
+	proto.RegisterType((*OrderExpression)(nil), "v1.OrderExpression")
+	proto.RegisterType((*ListJobsResponse)(nil), "v1.ListJobsResponse")
+	proto.RegisterType((*SubscribeRequest)(nil), "v1.SubscribeRequest")
+	proto.RegisterType((*SubscribeResponse)(nil), "v1.SubscribeResponse")
+	proto.RegisterType((*GetJobRequest)(nil), "v1.GetJobRequest")
+	proto.RegisterType((*GetJobResponse)(nil), "v1.GetJobResponse")
+	proto.RegisterType((*ListenRequest)(nil), "v1.ListenRequest")
+	proto.RegisterType((*ListenResponse)(nil), "v1.ListenResponse")
+	proto.RegisterType((*JobStatus)(nil), "v1.JobStatus")
+	proto.RegisterType((*JobMetadata)(nil), "v1.JobMetadata")
+	proto.RegisterType((*Repository)(nil), "v1.Repository")
+	proto.RegisterType((*Annotation)(nil), "v1.Annotation")
+	proto.RegisterType((*JobConditions)(nil), "v1.JobConditions")
+	proto.RegisterType((*JobApproval)(nil), "v1.JobApproval")
+	proto.RegisterType((*ApprovalDecision)(nil), "v1.ApprovalDecision")
+	proto.RegisterType((*JobResult)(nil), "v1.JobResult")
+	proto.RegisterType((*LogSliceEvent)(nil), "v1.LogSliceEvent")
+	proto.RegisterType((*StopJobRequest)(nil), "v1.StopJobRequest")
+	proto.RegisterType((*StopJobResponse)(nil), "v1.StopJobResponse")
+	proto.RegisterType((*ApproveJobRequest)(nil), "v1.ApproveJobRequest")
+	proto.RegisterType((*ApproveJobResponse)(nil), "v1.ApproveJobResponse")
+	proto.RegisterType((*RejectJobRequest)(nil), "v1.RejectJobRequest")
+	proto.RegisterType((*RejectJobResponse)(nil), "v1.RejectJobResponse")
+	proto.RegisterType((*PinJobRequest)(nil), "v1.PinJobRequest")
+	proto.RegisterType((*PinJobResponse)(nil), "v1.PinJobResponse")
+	proto.RegisterType((*UnpinJobRequest)(nil), "v1.UnpinJobRequest")
+	proto.RegisterType((*UnpinJobResponse)(nil), "v1.UnpinJobResponse")
+	proto.RegisterType((*GetStatisticsRequest)(nil), "v1.GetStatisticsRequest")
+	proto.RegisterType((*GetStatisticsResponse)(nil), "v1.GetStatisticsResponse")
+	proto.RegisterType((*JobStatistics)(nil), "v1.JobStatistics")
+	proto.RegisterType((*ValidateJobRequest)(nil), "v1.ValidateJobRequest")
+	proto.RegisterType((*ValidateJobResponse)(nil), "v1.ValidateJobResponse")
+	proto.RegisterType((*JobDiagnostic)(nil), "v1.JobDiagnostic")
+	proto.RegisterType((*Schedule)(nil), "v1.Schedule")
+	proto.RegisterType((*CreateScheduleRequest)(nil), "v1.CreateScheduleRequest")
+	proto.RegisterType((*CreateScheduleResponse)(nil), "v1.CreateScheduleResponse")
+	proto.RegisterType((*ListSchedulesRequest)(nil), "v1.ListSchedulesRequest")
+	proto.RegisterType((*ListSchedulesResponse)(nil), "v1.ListSchedulesResponse")
+	proto.RegisterType((*DeleteScheduleRequest)(nil), "v1.DeleteScheduleRequest")
+	proto.RegisterType((*DeleteScheduleResponse)(nil), "v1.DeleteScheduleResponse")
+	proto.RegisterType((*PauseScheduleRequest)(nil), "v1.PauseScheduleRequest")
+	proto.RegisterType((*PauseScheduleResponse)(nil), "v1.PauseScheduleResponse")
+}
+
+func init() {
+	proto.RegisterFile("werft.proto", fileDescriptor_9fe744feedd6d332)
+}
+
+var fileDescriptor_9fe744feedd6d332 = []byte{
+	// 2908 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
+	0x11, 0x26, 0x40, 0x3c, 0x1b, 0x0f, 0x2e, 0x87, 0xa0, 0x02, 0x42, 0x71, 0x49, 0x5a, 0x59, 0x25,
+	0x99, 0x4e, 0x48, 0x91, 0xb6, 0xec, 0xc8, 0x95, 0x54, 0x19, 0x24, 0x21, 0x92, 0x0a, 0x0c, 0x20,
+	0x03, 0xd0, 0xb2, 0x53, 0xa9, 0xda, 0x2c, 0x76, 0x87, 0xe0, 0x4a, 0xc0, 0xee, 0x7a, 0x77, 0x96,
+	0x12, 0xab, 0xf2, 0x07, 0x92, 0x4a, 0x55, 0x4e, 0xc9, 0x31, 0x8f, 0x6b, 0xfe, 0x45, 0x72, 0xce,
+	0xff, 0xc8, 0x25, 0x67, 0x9f, 0x53, 0xf3, 0xd8, 0x17, 0x08, 0x89, 0xa4, 0x52, 0x95, 0xdb, 0xf6,
+	0xd7, 0x3d, 0x3d, 0x3d, 0x3d, 0x3d, 0xdd, 0x3d, 0x03, 0x40, 0xe5, 0x35, 0xf1, 0x4e, 0xe9, 0x96,
+	0xeb, 0x39, 0xd4, 0x41, 0xd9, 0xf3, 0x9d, 0xd6, 0x9d, 0x89, 0xe3, 0x4c, 0xa6, 0x64, 0x9b, 0x23,
+	0xe3, 0xe0, 0x74, 0x9b, 0x5a, 0x33, 0xe2, 0x53, 0x7d, 0xe6, 0x0a, 0x21, 0xf5, 0xdf, 0x19, 0x68,
+	0x0c, 0xa9, 0xee, 0xd1, 0xae, 0x63, 0xe8, 0xd3, 0xe7, 0xce, 0x18, 0x93, 0xef, 0x02, 0xe2, 0x53,
+	0xf4, 0x63, 0x28, 0xcd, 0x08, 0xd5, 0x4d, 0x9d, 0xea, 0xcd, 0xcc, 0xdd, 0xcc, 0xa3, 0xca, 0xee,
+	0xca, 0xd6, 0xf9, 0xce, 0xd6, 0x73, 0x67, 0xfc, 0x95, 0x84, 0x8f, 0x96, 0x70, 0x24, 0x82, 0xee,
+	0x41, 0xc5, 0x70, 0xec, 0x53, 0x6b, 0xa2, 0x5d, 0xe8, 0xb3, 0x69, 0x33, 0x7b, 0x37, 0xf3, 0xa8,
+	0x7a, 0xb4, 0x84, 0x41, 0x80, 0xdf, 0xea, 0xb3, 0x29, 0xba, 0x0d, 0xa5, 0x97, 0xce, 0x58, 0xf0,
+	0x97, 0x25, 0xbf, 0xf8, 0xd2, 0x19, 0x73, 0xe6, 0x03, 0xa8, 0xbd, 0x76, 0xbc, 0x57, 0xbe, 0xab,
+	0x1b, 0x44, 0xa3, 0xba, 0xd7, 0xcc, 0x49, 0x89, 0x6a, 0x04, 0x8f, 0x74, 0x0f, 0x6d, 0x01, 0x4a,
+	0x89, 0x69, 0xa6, 0x63, 0x93, 0x66, 0xfe, 0x6e, 0xe6, 0x51, 0xe9, 0x68, 0x09, 0x2b, 0x49, 0xd9,
+	0x03, 0xc7, 0x26, 0x7b, 0x65, 0x28, 0x1a, 0x8e, 0x4d, 0x89, 0x4d, 0xd5, 0xef, 0x40, 0xe1, 0x0b,
+	0xe5, 0x6b, 0xf4, 0x5d, 0xc7, 0xf6, 0x09, 0x7a, 0x00, 0x05, 0x9f, 0xea, 0x34, 0xf0, 0xe5, 0x12,
+	0x6b, 0x72, 0x89, 0x43, 0x0e, 0x62, 0xc9, 0x44, 0x9f, 0xc1, 0x8a, 0x6e, 0x9a, 0x16, 0xb5, 0x1c,
+	0x5b, 0x9f, 0x6a, 0x2f, 0x9d, 0xb1, 0xdf, 0xcc, 0xde, 0x5d, 0xbe, 0x2c, 0x5f, 0x8f, 0xa5, 0x9e,
+	0x3b, 0x63, 0x5f, 0xfd, 0x63, 0x16, 0xd6, 0xf9, 0x9c, 0x87, 0x16, 0x3d, 0x0a, 0xc6, 0x09, 0xef,
+	0x7e, 0x7c, 0xa5, 0x77, 0x13, 0xbe, 0xdd, 0x10, 0x8e, 0x73, 0x75, 0x7a, 0xc6, 0x1d, 0x5b, 0xe6,
+	0x6e, 0x1b, 0xe8, 0xf4, 0x0c, 0x6d, 0xcc, 0xfb, 0x34, 0xf6, 0xe8, 0x3d, 0xa8, 0x4e, 0x2c, 0x7a,
+	0x16, 0x8c, 0x35, 0xea, 0xbc, 0x22, 0x36, 0x77, 0x68, 0x19, 0x57, 0x04, 0x36, 0x62, 0x10, 0x6a,
+	0x41, 0xc9, 0xb7, 0x4c, 0x32, 0x75, 0x74, 0x93, 0xfb, 0xb0, 0x8a, 0x23, 0x1a, 0x3d, 0x05, 0x78,
+	0xad, 0x5b, 0x54, 0x0b, 0x6c, 0x6a, 0x4d, 0x9b, 0x05, 0x6e, 0x63, 0x6b, 0x4b, 0x84, 0xd3, 0x56,
+	0x18, 0x4e, 0x5b, 0xa3, 0x30, 0x9c, 0x70, 0x99, 0x49, 0x9f, 0x30, 0x61, 0x74, 0x07, 0x2a, 0xb6,
+	0x3e, 0x23, 0x9a, 0x1f, 0x9c, 0x9e, 0x5a, 0x6f, 0x9a, 0x45, 0x3e, 0x31, 0x30, 0x68, 0xc8, 0x11,
+	0xf5, 0x3f, 0x19, 0x58, 0x89, 0xf7, 0xe2, 0xff, 0xe6, 0x91, 0xe4, 0x72, 0x73, 0xef, 0x5c, 0x6e,
+	0xfe, 0x7f, 0x58, 0x6e, 0xe1, 0xd2, 0x72, 0x7f, 0x0d, 0xca, 0xdc, 0x6a, 0x77, 0x6f, 0xb6, 0xdc,
+	0x3b, 0x90, 0xf3, 0x5d, 0x62, 0xf0, 0xa5, 0x56, 0x76, 0x2b, 0x61, 0xd0, 0xb9, 0xc4, 0xc0, 0x9c,
+	0xa1, 0xfe, 0x33, 0x0b, 0x45, 0x89, 0xa4, 0x8e, 0x59, 0x76, 0xfe, 0x98, 0xdd, 0x4e, 0x38, 0x8e,
+	0x79, 0xa7, 0x7c, 0xb4, 0x14, 0xbb, 0x6e, 0x13, 0x72, 0x1e, 0x71, 0x1d, 0xee, 0x9b, 0xca, 0x6e,
+	0x23, 0x31, 0xcd, 0xd6, 0x33, 0xcf, 0x99, 0x61, 0xe2, 0x3a, 0x47, 0x4b, 0x98, 0xcb, 0xa0, 0x87,
+	0xb0, 0x62, 0x5a, 0x1e, 0x31, 0xa8, 0x36, 0x17, 0x41, 0x75, 0x01, 0x0f, 0x63, 0xc7, 0xd6, 0xd8,
+	0x80, 0x58, 0xac, 0x70, 0x77, 0xf9, 0x6d, 0xda, 0x71, 0x95, 0x89, 0x46, 0x43, 0xaf, 0x8a, 0xa3,
+	0xd6, 0x1e, 0x94, 0xc2, 0xa1, 0x48, 0x95, 0xc6, 0x0b, 0x67, 0xd6, 0x99, 0x7a, 0x86, 0xfb, 0x16,
+	0x75, 0xbc, 0x0b, 0x69, 0x34, 0x82, 0x5c, 0x22, 0x64, 0xf8, 0xf7, 0x5e, 0x09, 0x0a, 0xbe, 0x13,
+	0x78, 0x06, 0x51, 0xff, 0x9c, 0x81, 0xdb, 0x7c, 0x9f, 0x98, 0xce, 0x81, 0x47, 0xce, 0x2d, 0x27,
+	0xf0, 0x13, 0x11, 0x7a, 0x0f, 0xaa, 0xae, 0x44, 0x59, 0x0e, 0xe0, 0x33, 0x95, 0x71, 0xc5, 0x8d,
+	0x25, 0x2f, 0x9d, 0xb9, 0xec, 0xe5, 0x33, 0x97, 0x0e, 0xb4, 0xe5, 0x1b, 0x04, 0x9a, 0xfa, 0xa7,
+	0x0c, 0xac, 0x74, 0x2d, 0x9f, 0xc5, 0x91, 0x1f, 0x1a, 0xf5, 0x23, 0x28, 0x9c, 0x5a, 0x53, 0x4a,
+	0xbc, 0x66, 0x26, 0xf6, 0xeb, 0x33, 0x8e, 0x74, 0xde, 0xb8, 0x1e, 0xf1, 0x7d, 0xcb, 0xb1, 0xb1,
+	0x94, 0x41, 0x1f, 0x41, 0xde, 0xf1, 0x4c, 0xe2, 0xc9, 0xf4, 0xb5, 0xc6, 0x84, 0xfb, 0x9e, 0x99,
+	0x92, 0x15, 0x12, 0xa8, 0x01, 0x79, 0x9f, 0x39, 0x83, 0x9b, 0x98, 0xc7, 0x82, 0x60, 0xe8, 0xd4,
+	0x9a, 0x59, 0x94, 0xc7, 0x48, 0x1e, 0x0b, 0x42, 0xfd, 0x09, 0x28, 0xf3, 0x53, 0xa2, 0x0f, 0x21,
+	0x4f, 0x89, 0x37, 0xf3, 0xa5, 0x5d, 0xf5, 0xd8, 0xae, 0x11, 0xf1, 0x66, 0x58, 0x30, 0xd5, 0xdf,
+	0x00, 0xc4, 0x20, 0xd3, 0x7e, 0x6a, 0x91, 0xa9, 0x29, 0x5d, 0x2b, 0x08, 0x86, 0x9e, 0xeb, 0xd3,
+	0x80, 0x48, 0x6f, 0x0a, 0x02, 0x6d, 0x42, 0xd9, 0x71, 0x89, 0xa7, 0xb3, 0x74, 0xcb, 0x6d, 0xac,
+	0xef, 0x56, 0xe3, 0x39, 0xfa, 0x2e, 0x8e, 0xd9, 0xe8, 0x16, 0x14, 0x6c, 0x32, 0xd1, 0x29, 0xe1,
+	0x66, 0x97, 0xb0, 0xa4, 0xd4, 0x0e, 0xac, 0xcc, 0xad, 0xfe, 0x2d, 0x26, 0xfc, 0x10, 0xca, 0xba,
+	0x6f, 0x10, 0xdb, 0xb4, 0xec, 0x09, 0x37, 0xa3, 0x84, 0x63, 0x40, 0xed, 0x83, 0x12, 0x6f, 0x8b,
+	0xac, 0x2c, 0x0d, 0xc8, 0x53, 0x87, 0xea, 0x53, 0xae, 0x27, 0x8f, 0x05, 0xc1, 0xea, 0x8d, 0x47,
+	0xfc, 0x60, 0x4a, 0x17, 0xd7, 0x0f, 0xc9, 0x54, 0xbf, 0x04, 0x65, 0x18, 0x8c, 0x7d, 0xc3, 0xb3,
+	0xc6, 0xe4, 0xbd, 0x36, 0x5a, 0xfd, 0x02, 0x56, 0x13, 0x1a, 0xe2, 0x6a, 0x27, 0x67, 0x5f, 0x5c,
+	0xed, 0xe4, 0xec, 0xf7, 0xa1, 0x76, 0x48, 0x92, 0xa9, 0x19, 0x41, 0x8e, 0x1d, 0x3a, 0xe9, 0x12,
+	0xfe, 0xad, 0x7e, 0x0e, 0xf5, 0x50, 0xe8, 0x66, 0xda, 0xcf, 0xa0, 0xc6, 0x9c, 0x45, 0xec, 0x77,
+	0x68, 0x47, 0x4d, 0x28, 0x06, 0xae, 0xa9, 0x53, 0xe2, 0x4b, 0x6f, 0x87, 0x24, 0xfa, 0x08, 0x72,
+	0x53, 0x67, 0xe2, 0xcb, 0x1d, 0x5f, 0x67, 0x73, 0xa4, 0xd4, 0x75, 0x9d, 0x89, 0x8f, 0xb9, 0x88,
+	0xea, 0x40, 0x3d, 0x64, 0x49, 0x13, 0x1f, 0x42, 0x41, 0xe8, 0x59, 0x68, 0xe2, 0xd1, 0x12, 0x96,
+	0x6c, 0x76, 0x4e, 0xfc, 0xa9, 0x65, 0x10, 0x99, 0x71, 0x57, 0xf9, 0x34, 0xce, 0x64, 0xc8, 0xb0,
+	0xce, 0x39, 0xb1, 0xe9, 0xd1, 0x12, 0x16, 0x12, 0xc9, 0x0e, 0xe3, 0x5f, 0x59, 0x28, 0x47, 0xda,
+	0x16, 0xae, 0x2b, 0x99, 0xf5, 0xb3, 0x57, 0x65, 0x7d, 0x15, 0xf2, 0xee, 0x99, 0xee, 0x93, 0x64,
+	0x74, 0x3f, 0x77, 0xc6, 0x03, 0x86, 0x61, 0xc1, 0x42, 0x3b, 0xc0, 0x3a, 0x2c, 0xd1, 0x74, 0xf8,
+	0xcd, 0x5c, 0x6c, 0xed, 0x73, 0x67, 0xbc, 0x1f, 0x31, 0x70, 0x42, 0x88, 0xf9, 0xd6, 0x24, 0x54,
+	0xb7, 0xa6, 0x3e, 0xcf, 0xd8, 0x65, 0x1c, 0x92, 0xe8, 0x21, 0x14, 0xc5, 0x26, 0xf9, 0x32, 0x49,
+	0x87, 0xfe, 0xc1, 0x1c, 0xc5, 0x21, 0x37, 0xaa, 0x47, 0xc5, 0xb7, 0xd4, 0x23, 0x76, 0xe0, 0x5c,
+	0xcb, 0xb6, 0x89, 0xd9, 0x2c, 0x89, 0x03, 0x27, 0x28, 0xb6, 0x7e, 0xdd, 0x75, 0x3d, 0xe7, 0x5c,
+	0x9f, 0x36, 0xcb, 0xa9, 0xf5, 0xb7, 0x25, 0x8c, 0x23, 0x01, 0xf5, 0x1f, 0x59, 0xa8, 0x24, 0x3c,
+	0xc3, 0x8e, 0x94, 0xf3, 0xda, 0xe6, 0x07, 0x80, 0x1f, 0x4d, 0x4e, 0xa0, 0x2d, 0x00, 0x2f, 0xca,
+	0xf3, 0xd2, 0xa9, 0xf3, 0xd9, 0x3f, 0x21, 0x81, 0x1e, 0x41, 0x91, 0x7a, 0xd6, 0x64, 0x42, 0x3c,
+	0xe9, 0xd7, 0xba, 0xb4, 0x60, 0x24, 0x50, 0x1c, 0xb2, 0xd1, 0xa7, 0x50, 0x34, 0x3c, 0xa2, 0x53,
+	0x62, 0x36, 0x73, 0x57, 0xa6, 0xe9, 0x50, 0x14, 0x7d, 0x06, 0xa5, 0x53, 0xcb, 0xb6, 0xfc, 0x33,
+	0x62, 0x5e, 0xa3, 0x8d, 0x88, 0x64, 0xd1, 0x63, 0xa8, 0xe8, 0xb6, 0xed, 0x50, 0x5d, 0x6c, 0x65,
+	0x21, 0xce, 0x9a, 0xed, 0x08, 0xc6, 0x49, 0x11, 0xa4, 0x42, 0x8d, 0xd5, 0x72, 0xe6, 0x70, 0x8d,
+	0x47, 0x9a, 0x28, 0x90, 0x95, 0x97, 0x62, 0x2b, 0x7a, 0xec, 0x98, 0xfe, 0x25, 0x03, 0x10, 0x3b,
+	0x82, 0xc5, 0xe4, 0x99, 0xe3, 0xd3, 0x30, 0x26, 0xd9, 0x77, 0xec, 0xd6, 0x6c, 0xd2, 0xad, 0x48,
+	0x96, 0xd3, 0x65, 0x21, 0xc9, 0xbe, 0x91, 0x02, 0xcb, 0x1e, 0x39, 0x95, 0x8d, 0x24, 0xfb, 0x64,
+	0x1d, 0x15, 0xab, 0x7e, 0x2c, 0xf5, 0xc8, 0x60, 0x8a, 0x68, 0xf4, 0x00, 0xea, 0x26, 0x39, 0xd5,
+	0x83, 0x29, 0xd5, 0xc6, 0x9e, 0x6e, 0x1b, 0x67, 0xb2, 0x33, 0xaa, 0x49, 0x74, 0x8f, 0x83, 0xea,
+	0xa7, 0x00, 0xf1, 0x02, 0xd9, 0x14, 0xaf, 0xc8, 0x85, 0xb4, 0x8f, 0x7d, 0x2e, 0xce, 0xfe, 0xea,
+	0xf7, 0x19, 0xa8, 0xa5, 0x42, 0x9c, 0x85, 0xb5, 0x1f, 0x18, 0x06, 0xf1, 0x45, 0x2f, 0x5f, 0xc2,
+	0x21, 0x89, 0xee, 0x43, 0xed, 0x54, 0xb7, 0xa6, 0x81, 0x47, 0x34, 0xc3, 0x09, 0x6c, 0xca, 0x35,
+	0xe5, 0x71, 0x55, 0x82, 0xfb, 0x0c, 0x43, 0x1f, 0x00, 0x18, 0xba, 0xad, 0x79, 0xc4, 0x9d, 0xea,
+	0x17, 0x7c, 0xd5, 0x25, 0x5c, 0x36, 0x74, 0x1b, 0x73, 0x60, 0xae, 0x6a, 0xe7, 0x6e, 0xd8, 0x1e,
+	0x9a, 0x96, 0xa9, 0x91, 0x37, 0xc4, 0x08, 0xa8, 0xbc, 0xab, 0x60, 0x30, 0x2d, 0xb3, 0x23, 0x10,
+	0xf4, 0x31, 0xac, 0xea, 0x4c, 0xdc, 0xb2, 0x27, 0x5a, 0x74, 0x3a, 0x0a, 0x5c, 0x4c, 0x09, 0x19,
+	0xe1, 0xf1, 0x50, 0x03, 0x7e, 0x26, 0x42, 0x92, 0x17, 0x26, 0xfe, 0x4d, 0x3c, 0x51, 0x69, 0xcb,
+	0x38, 0x06, 0x78, 0x11, 0x22, 0xfa, 0x4c, 0xdc, 0x56, 0xca, 0x58, 0x10, 0x68, 0x17, 0xca, 0x26,
+	0x31, 0xf8, 0x26, 0xb1, 0x3c, 0x1a, 0x15, 0x93, 0x50, 0xe9, 0x81, 0x64, 0xe2, 0x58, 0x4c, 0xfd,
+	0x7d, 0x06, 0x94, 0x79, 0x3e, 0x8b, 0x91, 0xc0, 0x8f, 0xce, 0x23, 0xff, 0x66, 0x11, 0x21, 0xe7,
+	0x37, 0x65, 0xea, 0x8e, 0x68, 0xb6, 0x45, 0x86, 0x33, 0x9b, 0x11, 0x9b, 0xca, 0xb0, 0x0a, 0x49,
+	0xb4, 0x05, 0x39, 0x76, 0x31, 0xbd, 0x86, 0x63, 0xb9, 0x9c, 0xfa, 0x1a, 0xca, 0x51, 0x5a, 0x62,
+	0x66, 0xd0, 0x0b, 0x37, 0x4a, 0xb4, 0xec, 0x9b, 0x4d, 0xe5, 0xea, 0x17, 0xbc, 0xdf, 0x94, 0xf7,
+	0x03, 0x49, 0xa2, 0xbb, 0x50, 0x31, 0x09, 0x2b, 0x8c, 0x6e, 0xd4, 0x39, 0x94, 0x71, 0x12, 0x62,
+	0x4b, 0x30, 0xce, 0x74, 0xdb, 0x26, 0x53, 0x96, 0x51, 0x99, 0xe3, 0x22, 0x5a, 0x35, 0xa0, 0x96,
+	0xaa, 0x03, 0x0b, 0xb3, 0xfc, 0x87, 0xd2, 0xa0, 0x2c, 0xcf, 0x2f, 0x4a, 0xb2, 0x78, 0x8c, 0x2e,
+	0x5c, 0x72, 0xd9, 0xc4, 0xe5, 0x94, 0x89, 0xea, 0x87, 0x50, 0x1f, 0x52, 0xc7, 0xbd, 0xa2, 0x02,
+	0xaf, 0xc2, 0x4a, 0x24, 0x25, 0xea, 0x9b, 0xda, 0x86, 0x55, 0xb1, 0x49, 0xe4, 0xdd, 0x63, 0x93,
+	0x3b, 0x91, 0x4d, 0xed, 0x84, 0xda, 0x00, 0x94, 0x54, 0x21, 0x15, 0x7f, 0x09, 0x0a, 0x26, 0x2f,
+	0x89, 0x41, 0xdf, 0x5b, 0xef, 0x1a, 0xac, 0x26, 0x34, 0x48, 0xb5, 0xf7, 0xa1, 0x36, 0xb0, 0xec,
+	0xab, 0x3b, 0x8d, 0x50, 0xe8, 0x46, 0xb7, 0x76, 0xf5, 0x01, 0xac, 0x9c, 0xd8, 0xee, 0x95, 0xfa,
+	0x9f, 0x82, 0x12, 0x8b, 0xdd, 0x6c, 0x86, 0xbf, 0x66, 0xa0, 0x71, 0x48, 0x28, 0x43, 0x2d, 0x9f,
+	0x5a, 0xc6, 0x7b, 0x76, 0xe5, 0x5b, 0x90, 0x3b, 0xf5, 0x9c, 0x59, 0x33, 0x7b, 0x75, 0xf4, 0x33,
+	0x39, 0xb4, 0x09, 0x59, 0xea, 0x5c, 0xe3, 0xea, 0x90, 0xa5, 0x8e, 0xfa, 0x1c, 0xd6, 0xe7, 0x2c,
+	0x94, 0x4b, 0xdc, 0x01, 0xf0, 0x23, 0x54, 0x9a, 0xb9, 0x9a, 0x58, 0xa6, 0x14, 0x4f, 0x08, 0xa9,
+	0xdf, 0x67, 0xa1, 0x96, 0xe2, 0xce, 0x15, 0xdf, 0xcc, 0x95, 0xc5, 0xf7, 0x52, 0xc9, 0xca, 0x5e,
+	0x2a, 0x59, 0xe8, 0x36, 0x94, 0xbd, 0xc0, 0x96, 0xa9, 0x5a, 0x5c, 0x3e, 0x4a, 0x5e, 0x60, 0x8b,
+	0x34, 0x7d, 0x1f, 0x6a, 0x32, 0xad, 0x4b, 0x01, 0x71, 0x0f, 0xa9, 0x4a, 0x50, 0x08, 0xdd, 0x83,
+	0x90, 0xd6, 0x3c, 0x5d, 0xa6, 0xdc, 0x0c, 0xae, 0x48, 0x0c, 0xb3, 0x06, 0xef, 0x31, 0x34, 0xcc,
+	0x40, 0xdc, 0x0e, 0x34, 0xf7, 0xc9, 0x63, 0xcd, 0x27, 0xac, 0x43, 0xf2, 0x79, 0xda, 0xcd, 0x60,
+	0x14, 0xf2, 0x06, 0x4f, 0x1e, 0x0f, 0x05, 0x27, 0x3d, 0xe2, 0xe9, 0x93, 0x68, 0x44, 0x71, 0x6e,
+	0xc4, 0xd3, 0x27, 0xe1, 0x88, 0x7b, 0x50, 0x9d, 0x51, 0xea, 0x45, 0x92, 0x25, 0x61, 0x06, 0xc3,
+	0x42, 0x91, 0x07, 0x50, 0xf7, 0x88, 0xc1, 0x92, 0xf5, 0x85, 0x5c, 0x4f, 0x99, 0xaf, 0xa7, 0x16,
+	0xa2, 0x7c, 0x41, 0xea, 0x18, 0xd0, 0xd7, 0xfa, 0xd4, 0x62, 0xad, 0xe9, 0xfb, 0xbe, 0x98, 0x5c,
+	0xf9, 0x84, 0xf0, 0xf7, 0x0c, 0xac, 0xa5, 0x26, 0x89, 0x2f, 0x32, 0xe7, 0x0c, 0x96, 0x55, 0x55,
+	0x10, 0xd1, 0x41, 0xca, 0x26, 0x0e, 0xff, 0xc6, 0xfc, 0xdb, 0x42, 0xea, 0x51, 0xc6, 0x75, 0x4c,
+	0xbe, 0xef, 0xb2, 0x7d, 0x28, 0xba, 0x8e, 0xc9, 0x66, 0x47, 0x9f, 0xb0, 0xf2, 0xa8, 0x4f, 0x6c,
+	0x47, 0x04, 0x62, 0x3e, 0x15, 0x88, 0x07, 0x11, 0x07, 0x27, 0xa5, 0x54, 0x1f, 0x6a, 0x29, 0x2e,
+	0xda, 0x85, 0x92, 0x4f, 0xce, 0x89, 0x67, 0x51, 0x11, 0x86, 0xf5, 0xdd, 0x5b, 0x4c, 0x45, 0x2c,
+	0x31, 0x94, 0x5c, 0x1c, 0xc9, 0xc9, 0x1b, 0xee, 0x24, 0xea, 0x2c, 0x38, 0xc1, 0x52, 0xd8, 0x8c,
+	0xf8, 0x3e, 0xc3, 0xe5, 0x22, 0x24, 0xc9, 0xc2, 0xbf, 0x34, 0x34, 0xce, 0x88, 0x19, 0x4c, 0xc9,
+	0xc2, 0xec, 0x87, 0x20, 0x67, 0x78, 0x4e, 0x78, 0xeb, 0xe7, 0xdf, 0xa9, 0x4d, 0x5a, 0xbe, 0xee,
+	0x26, 0xe5, 0xde, 0xd5, 0x57, 0xeb, 0x81, 0x2f, 0x5b, 0xcb, 0x12, 0x96, 0x54, 0xb2, 0x55, 0x2d,
+	0x5c, 0xbf, 0x55, 0x7d, 0x02, 0x25, 0x9b, 0xbc, 0xa1, 0x9a, 0x17, 0xd8, 0xcd, 0xe2, 0xd5, 0xc3,
+	0x98, 0x2c, 0x0e, 0x6c, 0x36, 0x6c, 0xaa, 0xfb, 0x62, 0x58, 0xe9, 0xea, 0x61, 0x4c, 0x96, 0x0d,
+	0xdb, 0x90, 0xc3, 0xd8, 0xd3, 0x49, 0x59, 0x78, 0x96, 0xd1, 0xec, 0xd9, 0xe4, 0x03, 0x00, 0xce,
+	0x22, 0x9e, 0xe7, 0x78, 0x4d, 0xe0, 0xcc, 0x32, 0x43, 0x3a, 0x0c, 0x50, 0xdb, 0xb0, 0xbe, 0xcf,
+	0x4d, 0x0e, 0xbd, 0x1f, 0x9e, 0x80, 0x47, 0x50, 0xf2, 0x25, 0x24, 0x4f, 0x00, 0xbf, 0x24, 0x45,
+	0x62, 0x11, 0x57, 0xdd, 0x83, 0x5b, 0xf3, 0x2a, 0x64, 0x7c, 0x5f, 0x5f, 0xc7, 0x2d, 0x68, 0xb0,
+	0xfb, 0x64, 0xc8, 0x09, 0x93, 0xbd, 0xba, 0x0f, 0xeb, 0x73, 0xb8, 0x54, 0xbd, 0x09, 0xe5, 0x70,
+	0x70, 0x98, 0x61, 0xd3, 0xba, 0x63, 0xb6, 0xfa, 0x31, 0xac, 0x1f, 0x90, 0x29, 0xb9, 0xbc, 0xc6,
+	0x45, 0x25, 0xab, 0x09, 0xb7, 0xe6, 0x85, 0x65, 0x45, 0xdd, 0x83, 0xc6, 0x80, 0x85, 0xc4, 0x35,
+	0xb4, 0x24, 0x82, 0x29, 0x9b, 0x0c, 0x26, 0xe6, 0xee, 0x39, 0x1d, 0x37, 0x75, 0xd5, 0xe6, 0xdf,
+	0x32, 0x50, 0x0a, 0x1f, 0x62, 0x50, 0x0d, 0xca, 0xfd, 0x81, 0xd6, 0xf9, 0xc5, 0x49, 0xbb, 0x3b,
+	0x54, 0x96, 0x10, 0x82, 0x7a, 0x7f, 0xa0, 0x0d, 0x47, 0x6d, 0x3c, 0x1a, 0x6a, 0x2f, 0x8e, 0x47,
+	0x47, 0x4a, 0x06, 0x29, 0x50, 0x65, 0x22, 0xbd, 0x03, 0x89, 0x64, 0xd1, 0x0a, 0x54, 0xfa, 0x03,
+	0x6d, 0xbf, 0xdf, 0x1b, 0xb5, 0x8f, 0x7b, 0x43, 0x65, 0x39, 0xd4, 0xf2, 0xcd, 0xf1, 0x70, 0x34,
+	0x54, 0x72, 0x72, 0x44, 0xb7, 0x33, 0x1c, 0x6a, 0xa3, 0xa3, 0x76, 0x4f, 0xc9, 0xa3, 0x35, 0x58,
+	0xe9, 0x0f, 0xb4, 0x43, 0xdc, 0x69, 0x8f, 0x3a, 0x58, 0x80, 0x05, 0x54, 0x85, 0x52, 0x7f, 0xa0,
+	0xe1, 0xce, 0x61, 0xe7, 0x1b, 0xa5, 0x88, 0x2a, 0x50, 0x64, 0x22, 0xdd, 0xfe, 0x9e, 0x52, 0xda,
+	0xfc, 0x1a, 0x56, 0x2f, 0xbd, 0x1c, 0xa0, 0x55, 0xa8, 0x75, 0xfb, 0x87, 0x43, 0xed, 0xe0, 0x78,
+	0xd8, 0xde, 0xeb, 0x76, 0x0e, 0x94, 0xa5, 0x08, 0x3a, 0xe9, 0x0d, 0xbb, 0xc7, 0xfb, 0x9d, 0x03,
+	0x25, 0xc3, 0xb4, 0x72, 0x08, 0xb7, 0x5f, 0x28, 0x59, 0x66, 0x19, 0xa7, 0x8e, 0x46, 0x5f, 0x75,
+	0x95, 0xe5, 0xcd, 0x5f, 0x01, 0xc4, 0xb7, 0x49, 0x66, 0xd5, 0x08, 0x1f, 0x1f, 0x1e, 0x76, 0xb0,
+	0x76, 0xd2, 0xfb, 0x79, 0xaf, 0xff, 0xa2, 0x27, 0x5c, 0x10, 0x82, 0x5f, 0xb5, 0x7b, 0x27, 0xed,
+	0xae, 0x70, 0x41, 0x88, 0x0d, 0x4e, 0x86, 0xcc, 0x05, 0x89, 0xa1, 0x07, 0x9d, 0x6e, 0x67, 0xd4,
+	0x39, 0x50, 0x96, 0x37, 0xff, 0x90, 0x81, 0x52, 0xf8, 0x08, 0xc0, 0x4c, 0x1b, 0x1c, 0xb5, 0x87,
+	0x9d, 0x84, 0xea, 0x35, 0x58, 0x11, 0xd0, 0x00, 0x77, 0x06, 0x6d, 0x7c, 0xdc, 0x3b, 0x54, 0x32,
+	0x6c, 0x3e, 0x01, 0x72, 0xaf, 0x33, 0x2c, 0x1b, 0x8f, 0xc5, 0x27, 0xbd, 0x1e, 0x83, 0x96, 0x51,
+	0x1d, 0x40, 0x40, 0x07, 0xfd, 0x5e, 0x47, 0xc9, 0xc5, 0x22, 0xfb, 0xdd, 0x4e, 0xbb, 0x77, 0x32,
+	0x50, 0xf2, 0x31, 0xf4, 0xa2, 0x7d, 0xcc, 0x15, 0x15, 0x36, 0x7f, 0x97, 0x81, 0x6a, 0xb2, 0xbd,
+	0x65, 0x26, 0x70, 0x4f, 0x69, 0xed, 0xbd, 0x76, 0x8f, 0xa9, 0x62, 0x5e, 0x5c, 0x81, 0x8a, 0x00,
+	0xf9, 0x70, 0x25, 0x13, 0x03, 0xdc, 0x26, 0x61, 0x90, 0x00, 0xd8, 0xa6, 0x77, 0x7a, 0x23, 0x61,
+	0x90, 0x80, 0xa4, 0x41, 0x11, 0xfd, 0xac, 0x7d, 0xdc, 0x55, 0xf2, 0xcc, 0x67, 0x82, 0xc6, 0x9d,
+	0xe1, 0x49, 0x77, 0xa4, 0x14, 0x36, 0x27, 0x80, 0x2e, 0xe7, 0x7c, 0xd4, 0x00, 0x65, 0xd8, 0xf9,
+	0xba, 0x83, 0x8f, 0x47, 0xdf, 0xa6, 0x77, 0x21, 0x42, 0x3b, 0x18, 0xf7, 0xb1, 0x92, 0x49, 0x49,
+	0xbe, 0x68, 0xe3, 0x5e, 0xe4, 0xab, 0x08, 0x3d, 0xee, 0x3d, 0xeb, 0x2b, 0xcb, 0xbb, 0xbf, 0x05,
+	0xa8, 0xbe, 0x60, 0x3f, 0xb6, 0x0d, 0x89, 0x77, 0x6e, 0x19, 0x04, 0xed, 0x43, 0x2d, 0xf5, 0x3b,
+	0x1a, 0x6a, 0xf2, 0xb3, 0xb1, 0xe0, 0xa7, 0xb5, 0x56, 0x23, 0xe2, 0x24, 0x9b, 0xe1, 0xa5, 0x47,
+	0x19, 0xb4, 0x0f, 0xf5, 0xf4, 0xef, 0x45, 0x68, 0x23, 0x92, 0x9d, 0xff, 0x0d, 0xe9, 0x6d, 0x6a,
+	0x50, 0x1f, 0x1a, 0x8b, 0x9e, 0xb1, 0xd1, 0x9d, 0x48, 0x7e, 0xf1, 0x03, 0xf7, 0x5b, 0x15, 0x7e,
+	0x0e, 0xa5, 0x10, 0x45, 0x6b, 0x69, 0x99, 0x77, 0x0f, 0x7c, 0x0a, 0xe5, 0x10, 0xdd, 0x45, 0x8d,
+	0x05, 0x23, 0x77, 0xdf, 0x35, 0x67, 0xf8, 0xa6, 0x2a, 0xe6, 0x9c, 0x7b, 0xf8, 0x6e, 0x35, 0xd2,
+	0x60, 0x34, 0xf0, 0xa7, 0x50, 0x8e, 0x5e, 0x3e, 0xe5, 0x9c, 0x73, 0x4f, 0xa9, 0xad, 0xf5, 0x39,
+	0x34, 0x1c, 0xfb, 0x38, 0x83, 0x76, 0xa0, 0x20, 0x9e, 0x35, 0x11, 0x6f, 0x41, 0x52, 0xef, 0xa0,
+	0x2d, 0x94, 0x84, 0xa2, 0x09, 0x3f, 0x81, 0x82, 0xc8, 0x23, 0x62, 0x48, 0x2a, 0xa7, 0xb4, 0x50,
+	0x12, 0x4a, 0xcc, 0xf3, 0x29, 0x14, 0xe5, 0xe5, 0x0d, 0x21, 0xe1, 0x81, 0xe4, 0x7d, 0xaf, 0xb5,
+	0x96, 0xc2, 0xa2, 0xa9, 0x9e, 0xf1, 0x97, 0xd9, 0x44, 0xff, 0xdd, 0x94, 0x16, 0x5d, 0xba, 0x81,
+	0xb4, 0x36, 0x16, 0x70, 0x22, 0x3d, 0x3b, 0x50, 0x10, 0x57, 0x2a, 0x61, 0x72, 0xea, 0x0e, 0xd6,
+	0x42, 0x49, 0x28, 0xb9, 0x1f, 0xe1, 0x2d, 0x49, 0xec, 0xc7, 0xdc, 0xd5, 0xaa, 0xd5, 0x48, 0x83,
+	0xd1, 0xc0, 0x2f, 0xa1, 0x92, 0x68, 0x2b, 0x11, 0x6f, 0xcb, 0x2e, 0x37, 0xb3, 0xad, 0x1f, 0x5c,
+	0xc2, 0x23, 0x0d, 0xc7, 0x50, 0x4f, 0xd7, 0x6e, 0x71, 0x28, 0x16, 0xb6, 0x04, 0xad, 0xd6, 0x22,
+	0x56, 0xd2, 0x81, 0xa9, 0x52, 0x2d, 0x1c, 0xb8, 0xa8, 0xaa, 0xb7, 0x36, 0x16, 0x70, 0x92, 0x26,
+	0xa5, 0x0b, 0xb0, 0x30, 0x69, 0x61, 0x05, 0x6f, 0xb5, 0x16, 0xb1, 0x92, 0x26, 0xa5, 0xaa, 0xad,
+	0x30, 0x69, 0x51, 0x11, 0x6f, 0x6d, 0x2c, 0xe0, 0x44, 0x7a, 0x7e, 0x06, 0x10, 0x5f, 0xdc, 0xd1,
+	0x7a, 0xfc, 0xa0, 0x93, 0xf4, 0xf2, 0xad, 0x79, 0x38, 0x1a, 0xfe, 0x05, 0x94, 0xa3, 0xfb, 0xb9,
+	0x38, 0x36, 0xf3, 0x17, 0xfe, 0xd6, 0xfa, 0x1c, 0x1a, 0x8e, 0xdd, 0x7b, 0xf8, 0xcb, 0x07, 0xe2,
+	0x17, 0xae, 0x2d, 0xc3, 0x99, 0x6d, 0x1b, 0xfe, 0x6b, 0x62, 0x19, 0x67, 0x64, 0xba, 0xcd, 0xff,
+	0x8c, 0xb0, 0xed, 0xbe, 0x9a, 0x6c, 0xeb, 0xae, 0xb5, 0x7d, 0xbe, 0x33, 0x2e, 0xf0, 0xfe, 0xf0,
+	0x93, 0xff, 0x0e, 0x00, 0x4b, 0x77, 0xdd, 0xc6, 0xa7, 0x20, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// WerftServiceClient is the client API for WerftService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WerftServiceClient interface {
+	// StartLocalJob starts a job by uploading the workspace content directly. The incoming requests are expected in the following order:
+	//   1. metadata
+	//   2. all bytes constituting the werft/config.yaml
+	//   3. all bytes constituting the job YAML that will be executed (that the config.yaml points to)
+	//   4. all bytes constituting the gzipped workspace tar stream
+	//   5. the workspace tar stream done marker
+	StartLocalJob(ctx context.Context, opts ...grpc.CallOption) (WerftService_StartLocalJobClient, error)
+	// StartGitHubJob starts a job on a Git context, possibly with a custom job.
+	StartGitHubJob(ctx context.Context, in *StartGitHubJobRequest, opts ...grpc.CallOption) (*StartJobResponse, error)
+	// StartFromPreviousJob starts a new job based on a previous one.
+	// If the previous job does not have the can-replay condition set this call will result in an error.
+	StartFromPreviousJob(ctx context.Context, in *StartFromPreviousJobRequest, opts ...grpc.CallOption) (*StartJobResponse, error)
+	// StartJobRequest starts a new job based on its specification.
+	StartJob(ctx context.Context, in *StartJobRequest, opts ...grpc.CallOption) (*StartJobResponse, error)
+	// StartJob2 starts a new job based on its specification.
+	StartJob2(ctx context.Context, in *StartJobRequest2, opts ...grpc.CallOption) (*StartJobResponse, error)
+	// Searches for jobs known to this instance
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// Subscribe listens to new jobs/job updates
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (WerftService_SubscribeClient, error)
+	// GetJob retrieves details of a single job
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
+	// Listen listens to job updates and log output of a running job
+	Listen(ctx context.Context, in *ListenRequest, opts ...grpc.CallOption) (WerftService_ListenClient, error)
+	// StopJob stops a currently running job
+	StopJob(ctx context.Context, in *StopJobRequest, opts ...grpc.CallOption) (*StopJobResponse, error)
+	// GetStatistics computes aggregate statistics of finished jobs, grouped by repository, branch and job spec
+	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error)
+	// PinJob protects a job and its logs from garbage collection
+	PinJob(ctx context.Context, in *PinJobRequest, opts ...grpc.CallOption) (*PinJobResponse, error)
+	// UnpinJob removes the garbage collection protection of a previously pinned job
+	UnpinJob(ctx context.Context, in *UnpinJobRequest, opts ...grpc.CallOption) (*UnpinJobResponse, error)
+	// ValidateJob prepares a job like StartJob2 would, but instead of starting the job it returns the
+	// rendered pod spec and all problems found while preparing the job.
+	ValidateJob(ctx context.Context, in *ValidateJobRequest, opts ...grpc.CallOption) (*ValidateJobResponse, error)
+	// CreateSchedule creates a schedule which starts a job regularly
+	CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*CreateScheduleResponse, error)
+	// ListSchedules lists all schedules including their next and last run
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	// DeleteSchedule removes a schedule. Jobs the schedule started already are not affected.
+	DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*DeleteScheduleResponse, error)
+	// PauseSchedule pauses or resumes a schedule. Resumed schedules do not start the runs they missed while they were paused.
+	PauseSchedule(ctx context.Context, in *PauseScheduleRequest, opts ...grpc.CallOption) (*PauseScheduleResponse, error)
+	// ApproveJob starts a job which is awaiting approval. Only the approvers and teams listed in the job's approval may approve it.
+	ApproveJob(ctx context.Context, in *ApproveJobRequest, opts ...grpc.CallOption) (*ApproveJobResponse, error)
+	// RejectJob fails a job which is awaiting approval. Only the approvers and teams listed in the job's approval may reject it.
+	RejectJob(ctx context.Context, in *RejectJobRequest, opts ...grpc.CallOption) (*RejectJobResponse, error)
+}
+
+type werftServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWerftServiceClient(cc grpc.ClientConnInterface) WerftServiceClient {
+	return &werftServiceClient{cc}
+}
+
+func (c *werftServiceClient) StartLocalJob(ctx context.Context, opts ...grpc.CallOption) (WerftService_StartLocalJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WerftService_serviceDesc.Streams[0], "/v1.WerftService/StartLocalJob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &werftServiceStartLocalJobClient{stream}
+	return x, nil
+}
+
+type WerftService_StartLocalJobClient interface {
+	Send(*StartLocalJobRequest) error
+	CloseAndRecv() (*StartJobResponse, error)
+	grpc.ClientStream
+}
+
+type werftServiceStartLocalJobClient struct {
+	grpc.ClientStream
+}
+
+func (x *werftServiceStartLocalJobClient) Send(m *StartLocalJobRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *werftServiceStartLocalJobClient) CloseAndRecv() (*StartJobResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(StartJobResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 func (c *werftServiceClient) StartGitHubJob(ctx context.Context, in *StartGitHubJobRequest, opts ...grpc.CallOption) (*StartJobResponse, error) {
@@ -2291,6 +3717,96 @@ func (c *werftServiceClient) StopJob(ctx context.Context, in *StopJobRequest, op
 	return out, nil
 }
 
+func (c *werftServiceClient) GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error) {
+	out := new(GetStatisticsResponse)
+	err := c.cc.Invoke(ctx, "/v1.WerftService/GetStatistics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *werftServiceClient) PinJob(ctx context.Context, in *PinJobRequest, opts ...grpc.CallOption) (*PinJobResponse, error) {
+	out := new(PinJobResponse)
+	err := c.cc.Invoke(ctx, "/v1.WerftService/PinJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *werftServiceClient) UnpinJob(ctx context.Context, in *UnpinJobRequest, opts ...grpc.CallOption) (*UnpinJobResponse, error) {
+	out := new(UnpinJobResponse)
+	err := c.cc.Invoke(ctx, "/v1.WerftService/UnpinJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *werftServiceClient) ValidateJob(ctx context.Context, in *ValidateJobRequest, opts ...grpc.CallOption) (*ValidateJobResponse, error) {
+	out := new(ValidateJobResponse)
+	err := c.cc.Invoke(ctx, "/v1.WerftService/ValidateJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *werftServiceClient) CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*CreateScheduleResponse, error) {
+	out := new(CreateScheduleResponse)
+	err := c.cc.Invoke(ctx, "/v1.WerftService/CreateSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *werftServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, "/v1.WerftService/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *werftServiceClient) DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*DeleteScheduleResponse, error) {
+	out := new(DeleteScheduleResponse)
+	err := c.cc.Invoke(ctx, "/v1.WerftService/DeleteSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *werftServiceClient) PauseSchedule(ctx context.Context, in *PauseScheduleRequest, opts ...grpc.CallOption) (*PauseScheduleResponse, error) {
+	out := new(PauseScheduleResponse)
+	err := c.cc.Invoke(ctx, "/v1.WerftService/PauseSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *werftServiceClient) ApproveJob(ctx context.Context, in *ApproveJobRequest, opts ...grpc.CallOption) (*ApproveJobResponse, error) {
+	out := new(ApproveJobResponse)
+	err := c.cc.Invoke(ctx, "/v1.WerftService/ApproveJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *werftServiceClient) RejectJob(ctx context.Context, in *RejectJobRequest, opts ...grpc.CallOption) (*RejectJobResponse, error) {
+	out := new(RejectJobResponse)
+	err := c.cc.Invoke(ctx, "/v1.WerftService/RejectJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WerftServiceServer is the server API for WerftService service.
 type WerftServiceServer interface {
 	// StartLocalJob starts a job by uploading the workspace content directly. The incoming requests are expected in the following order:
@@ -2319,6 +3835,27 @@ type WerftServiceServer interface {
 	Listen(*ListenRequest, WerftService_ListenServer) error
 	// StopJob stops a currently running job
 	StopJob(context.Context, *StopJobRequest) (*StopJobResponse, error)
+	// GetStatistics computes aggregate statistics of finished jobs, grouped by repository, branch and job spec
+	GetStatistics(context.Context, *GetStatisticsRequest) (*GetStatisticsResponse, error)
+	// PinJob protects a job and its logs from garbage collection
+	PinJob(context.Context, *PinJobRequest) (*PinJobResponse, error)
+	// UnpinJob removes the garbage collection protection of a previously pinned job
+	UnpinJob(context.Context, *UnpinJobRequest) (*UnpinJobResponse, error)
+	// ValidateJob prepares a job like StartJob2 would, but instead of starting the job it returns the
+	// rendered pod spec and all problems found while preparing the job.
+	ValidateJob(context.Context, *ValidateJobRequest) (*ValidateJobResponse, error)
+	// CreateSchedule creates a schedule which starts a job regularly
+	CreateSchedule(context.Context, *CreateScheduleRequest) (*CreateScheduleResponse, error)
+	// ListSchedules lists all schedules including their next and last run
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	// DeleteSchedule removes a schedule. Jobs the schedule started already are not affected.
+	DeleteSchedule(context.Context, *DeleteScheduleRequest) (*DeleteScheduleResponse, error)
+	// PauseSchedule pauses or resumes a schedule. Resumed schedules do not start the runs they missed while they were paused.
+	PauseSchedule(context.Context, *PauseScheduleRequest) (*PauseScheduleResponse, error)
+	// ApproveJob starts a job which is awaiting approval. Only the approvers and teams listed in the job's approval may approve it.
+	ApproveJob(context.Context, *ApproveJobRequest) (*ApproveJobResponse, error)
+	// RejectJob fails a job which is awaiting approval. Only the approvers and teams listed in the job's approval may reject it.
+	RejectJob(context.Context, *RejectJobRequest) (*RejectJobResponse, error)
 }
 
 // UnimplementedWerftServiceServer can be embedded to have forward compatible implementations.
@@ -2355,6 +3892,36 @@ func (*UnimplementedWerftServiceServer) Listen(req *ListenRequest, srv WerftServ
 func (*UnimplementedWerftServiceServer) StopJob(ctx context.Context, req *StopJobRequest) (*StopJobResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopJob not implemented")
 }
+func (*UnimplementedWerftServiceServer) GetStatistics(ctx context.Context, req *GetStatisticsRequest) (*GetStatisticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatistics not implemented")
+}
+func (*UnimplementedWerftServiceServer) PinJob(ctx context.Context, req *PinJobRequest) (*PinJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinJob not implemented")
+}
+func (*UnimplementedWerftServiceServer) UnpinJob(ctx context.Context, req *UnpinJobRequest) (*UnpinJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpinJob not implemented")
+}
+func (*UnimplementedWerftServiceServer) ValidateJob(ctx context.Context, req *ValidateJobRequest) (*ValidateJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateJob not implemented")
+}
+func (*UnimplementedWerftServiceServer) CreateSchedule(ctx context.Context, req *CreateScheduleRequest) (*CreateScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSchedule not implemented")
+}
+func (*UnimplementedWerftServiceServer) ListSchedules(ctx context.Context, req *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (*UnimplementedWerftServiceServer) DeleteSchedule(ctx context.Context, req *DeleteScheduleRequest) (*DeleteScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSchedule not implemented")
+}
+func (*UnimplementedWerftServiceServer) PauseSchedule(ctx context.Context, req *PauseScheduleRequest) (*PauseScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseSchedule not implemented")
+}
+func (*UnimplementedWerftServiceServer) ApproveJob(ctx context.Context, req *ApproveJobRequest) (*ApproveJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveJob not implemented")
+}
+func (*UnimplementedWerftServiceServer) RejectJob(ctx context.Context, req *RejectJobRequest) (*RejectJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectJob not implemented")
+}
 
 func RegisterWerftServiceServer(s *grpc.Server, srv WerftServiceServer) {
 	s.RegisterService(&_WerftService_serviceDesc, srv)
@@ -2554,6 +4121,186 @@ func _WerftService_StopJob_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WerftService_GetStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WerftServiceServer).GetStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WerftService/GetStatistics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WerftServiceServer).GetStatistics(ctx, req.(*GetStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WerftService_PinJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WerftServiceServer).PinJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WerftService/PinJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WerftServiceServer).PinJob(ctx, req.(*PinJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WerftService_UnpinJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WerftServiceServer).UnpinJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WerftService/UnpinJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WerftServiceServer).UnpinJob(ctx, req.(*UnpinJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WerftService_ValidateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WerftServiceServer).ValidateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WerftService/ValidateJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WerftServiceServer).ValidateJob(ctx, req.(*ValidateJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WerftService_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WerftServiceServer).CreateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WerftService/CreateSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WerftServiceServer).CreateSchedule(ctx, req.(*CreateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WerftService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WerftServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WerftService/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WerftServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WerftService_DeleteSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WerftServiceServer).DeleteSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WerftService/DeleteSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WerftServiceServer).DeleteSchedule(ctx, req.(*DeleteScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WerftService_PauseSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WerftServiceServer).PauseSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WerftService/PauseSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WerftServiceServer).PauseSchedule(ctx, req.(*PauseScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WerftService_ApproveJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WerftServiceServer).ApproveJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WerftService/ApproveJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WerftServiceServer).ApproveJob(ctx, req.(*ApproveJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WerftService_RejectJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WerftServiceServer).RejectJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.WerftService/RejectJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WerftServiceServer).RejectJob(ctx, req.(*RejectJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _WerftService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.WerftService",
 	HandlerType: (*WerftServiceServer)(nil),
@@ -2586,6 +4333,46 @@ var _WerftService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StopJob",
 			Handler:    _WerftService_StopJob_Handler,
 		},
+		{
+			MethodName: "GetStatistics",
+			Handler:    _WerftService_GetStatistics_Handler,
+		},
+		{
+			MethodName: "PinJob",
+			Handler:    _WerftService_PinJob_Handler,
+		},
+		{
+			MethodName: "UnpinJob",
+			Handler:    _WerftService_UnpinJob_Handler,
+		},
+		{
+			MethodName: "ValidateJob",
+			Handler:    _WerftService_ValidateJob_Handler,
+		},
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _WerftService_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _WerftService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "DeleteSchedule",
+			Handler:    _WerftService_DeleteSchedule_Handler,
+		},
+		{
+			MethodName: "PauseSchedule",
+			Handler:    _WerftService_PauseSchedule_Handler,
+		},
+		{
+			MethodName: "ApproveJob",
+			Handler:    _WerftService_ApproveJob_Handler,
+		},
+		{
+			MethodName: "RejectJob",
+			Handler:    _WerftService_RejectJob_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
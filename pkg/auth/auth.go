@@ -23,3 +23,16 @@ type Interceptor interface {
 	Unary() grpc.UnaryServerInterceptor
 	Stream() grpc.StreamServerInterceptor
 }
+
+type authResponseKey struct{}
+
+// WithAuthResponse returns a context which carries the authentication of the caller
+func WithAuthResponse(ctx context.Context, resp *AuthResponse) context.Context {
+	return context.WithValue(ctx, authResponseKey{}, resp)
+}
+
+// AuthResponseFromContext returns the authentication of the caller, or nil if the caller is not authenticated
+func AuthResponseFromContext(ctx context.Context) *AuthResponse {
+	resp, _ := ctx.Value(authResponseKey{}).(*AuthResponse)
+	return resp
+}
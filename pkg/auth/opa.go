@@ -59,6 +59,9 @@ func (i *opaInterceptor) Unary() grpc.UnaryServerInterceptor {
 		if err != nil {
 			return nil, err
 		}
+		if auth != nil {
+			ctx = WithAuthResponse(ctx, auth)
+		}
 
 		return handler(ctx, req)
 	}
@@ -99,16 +99,25 @@ type Executor struct {
 	mu          sync.RWMutex
 }
 
-// waitingJob is a job which doesn't run yet, but waits until it can start (e.g. based on time)
+// waitingJob is a job which doesn't run yet, but waits until it can start (e.g. based on time or approval)
 type waitingJob struct {
-	Cancel func(reason string)
-	Start  func()
-	Mutex  string
-	Status *werftv1.JobStatus
+	Cancel  func(reason string)
+	Start   func()
+	Approve func(decision *werftv1.ApprovalDecision)
+	Mutex   string
+	Status  *werftv1.JobStatus
 }
 
+// pendingApprovalPodKey is the secret key under which the pod of a job awaiting approval is kept
+const pendingApprovalPodKey = "pod.json"
+
 // Run starts the executor and returns immediately
 func (js *Executor) Run() {
+	err := js.restorePendingApprovals()
+	if err != nil {
+		log.WithError(err).Error("cannot restore jobs awaiting approval")
+	}
+
 	go js.monitorJobs()
 	go js.doHousekeeping()
 }
@@ -122,6 +131,7 @@ type startOptions struct {
 	CanReplay    bool
 	WaitUntil    time.Time
 	Sidecars     []string
+	Approval     *werftv1.JobApproval
 }
 
 // StartOpt configures a job at startup
@@ -188,6 +198,13 @@ func WithSidecars(names []string) StartOpt {
 	}
 }
 
+// WithApproval makes a job wait until someone approves it. Rejecting the job fails it.
+func WithApproval(approval *werftv1.JobApproval) StartOpt {
+	return func(opts *startOptions) {
+		opts.Approval = approval
+	}
+}
+
 // Start starts a new job
 func (js *Executor) Start(podspec corev1.PodSpec, metadata werftv1.JobMetadata, options ...StartOpt) (status *werftv1.JobStatus, err error) {
 	opts := startOptions{
@@ -213,6 +230,13 @@ func (js *Executor) Start(podspec corev1.PodSpec, metadata werftv1.JobMetadata,
 	if len(opts.Sidecars) > 0 {
 		annotations[js.labels.AnnotationSidecars] = strings.Join(opts.Sidecars, " ")
 	}
+	if opts.Approval != nil {
+		approval, err := (&jsonpb.Marshaler{}).MarshalToString(opts.Approval)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot marshal approval: %w", err)
+		}
+		annotations[js.labels.AnnotationApproval] = approval
+	}
 
 	metadata.Created = ptypes.TimestampNow()
 	mdjson, err := (&jsonpb.Marshaler{
@@ -277,76 +301,213 @@ func (js *Executor) Start(podspec corev1.PodSpec, metadata werftv1.JobMetadata,
 		js.mu.Unlock()
 	}
 
-	startJob := func() (*werftv1.JobStatus, error) {
-		if log.GetLevel() == log.DebugLevel {
-			dbg, _ := json.MarshalIndent(poddesc, "", "  ")
-			log.Debugf("scheduling job\n%s", dbg)
+	// Register the go routine to start the job when its time comes.
+	// When a waiting job is canceled manually or by a mutex it's deleted from the store.
+	// Jobs which await approval wait until someone approves or rejects them, regardless of opts.WaitUntil.
+	// Because that can take hours, we keep them in a secret from which Run restores them when werft restarts.
+	// The pod may contain values of secrets the job template used, hence a config map would not do.
+	log.WithField("wait-until", opts.WaitUntil).WithField("approval", opts.Approval != nil).Debug("waiting until")
+	if opts.Approval != nil || (!opts.WaitUntil.IsZero() && opts.WaitUntil.After(time.Now())) {
+		if opts.Approval != nil {
+			err = js.storePendingApproval(poddesc)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot store job awaiting approval: %w", err)
+			}
 		}
 
-		job, err := js.Client.CoreV1().Pods(js.Config.Namespace).Create(context.Background(), &poddesc, metav1.CreateOptions{})
+		status, err := js.wait(poddesc, opts.WaitUntil)
 		if err != nil {
 			return nil, err
 		}
 
-		return getStatus(job, js.labels)
+		// normally we'd see a Kubernetes event as the job would start immediately. This Kubernetes event would propagate
+		// throughout the system. However, waiting jobs do not produce Kubernetes events right away, hence we have to
+		// call OnUpdate ourselves.
+		js.OnUpdate(&poddesc, status)
+
+		return status, nil
+	}
+
+	return js.createPod(poddesc)
+}
+
+// createPod starts a job by creating its pod
+func (js *Executor) createPod(poddesc corev1.Pod) (*werftv1.JobStatus, error) {
+	if log.GetLevel() == log.DebugLevel {
+		dbg, _ := json.MarshalIndent(poddesc, "", "  ")
+		log.Debugf("scheduling job\n%s", dbg)
 	}
 
-	// Register the go routine to start the job when its time comes.
-	// Werft will tell us again about this job upon startup (pass set of waiting jobs into NewExecutor).
-	// When a waiting job is canceled manually or by a mutex it's deleted from the store.
-	log.WithField("wait-until", opts.WaitUntil).Debug("waiting until")
-	if !opts.WaitUntil.IsZero() && opts.WaitUntil.After(time.Now()) {
-		status, err := getStatus(&poddesc, js.labels)
+	job, err := js.Client.CoreV1().Pods(js.Config.Namespace).Create(context.Background(), &poddesc, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return getStatus(job, js.labels)
+}
+
+// wait registers a job which doesn't start yet. Jobs with an approval annotation wait until someone approves
+// or rejects them, all others until waitUntil.
+func (js *Executor) wait(poddesc corev1.Pod, waitUntil time.Time) (*werftv1.JobStatus, error) {
+	status, err := getStatus(&poddesc, js.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		name             = poddesc.Name
+		awaitingApproval = status.Approval != nil
+	)
+	status.Phase = werftv1.JobPhase_PHASE_WAITING
+	if awaitingApproval {
+		status.Conditions.AwaitingApproval = true
+		status.Details = "awaiting approval"
+	}
+
+	// This job's time hasn't come yet - let's delay its execution until later.
+	startChan, cancelChan, approveChan := make(chan struct{}), make(chan string), make(chan *werftv1.ApprovalDecision)
+	js.mu.Lock()
+	js.waitingJobs[name] = &waitingJob{
+		Cancel:  func(reason string) { cancelChan <- reason },
+		Start:   func() { close(startChan) },
+		Approve: func(decision *werftv1.ApprovalDecision) { approveChan <- decision },
+		Mutex:   poddesc.Labels[js.labels.LabelMutex],
+		Status:  status,
+	}
+	js.mu.Unlock()
+
+	done := func() {
+		if !awaitingApproval {
+			return
+		}
+		err := js.deletePendingApproval(name)
 		if err != nil {
-			return nil, err
+			log.WithError(err).WithField("name", name).Warn("cannot delete job awaiting approval")
 		}
+	}
+	fail := func(reason string) {
+		done()
 
-		// This job's time hasn't come yet - let's delay its execution until later.
-		startChan, cancelChan := make(chan struct{}), make(chan string)
+		status.Phase = werftv1.JobPhase_PHASE_DONE
+		status.Conditions.Success = false
+		status.Conditions.AwaitingApproval = false
+		status.Details = reason
+		status.Metadata.Finished = ptypes.TimestampNow()
+		js.OnUpdate(&poddesc, status)
+	}
+	run := func() {
 		js.mu.Lock()
-		js.waitingJobs[opts.JobName] = &waitingJob{
-			Cancel: func(reason string) { cancelChan <- reason },
-			Start:  func() { close(startChan) },
-			Mutex:  opts.Mutex,
-			Status: status,
-		}
+		delete(js.waitingJobs, name)
 		js.mu.Unlock()
 
-		run := func() {
-			js.mu.Lock()
-			delete(js.waitingJobs, opts.JobName)
-			js.mu.Unlock()
-
-			startJob()
+		_, err := js.createPod(poddesc)
+		if err != nil {
+			log.WithError(err).WithField("name", name).Error("cannot start waiting job")
+			fail(fmt.Sprintf("cannot start job: %v", err))
+			return
 		}
+		done()
+	}
 
-		go func() {
-			d := time.Until(opts.WaitUntil)
-			select {
-			case <-time.After(d):
-				run()
-			case <-startChan:
-				run()
-			case reason := <-cancelChan:
-				log.WithField("name", opts.JobName).Debug("canceled this waiting job")
-				status.Phase = werftv1.JobPhase_PHASE_DONE
-				status.Conditions.Success = false
-				status.Details = reason
-				js.OnUpdate(&poddesc, status)
+	var timeout <-chan time.Time
+	if !awaitingApproval {
+		timeout = time.After(time.Until(waitUntil))
+	}
+
+	go func() {
+		select {
+		case <-timeout:
+			run()
+		case <-startChan:
+			run()
+		case decision := <-approveChan:
+			status.Approval.Decisions = append(status.Approval.Decisions, decision)
+			approval, err := (&jsonpb.Marshaler{}).MarshalToString(status.Approval)
+			if err != nil {
+				fail(fmt.Sprintf("cannot marshal approval: %v", err))
+				return
 			}
-		}()
+			poddesc.Annotations[js.labels.AnnotationApproval] = approval
 
-		status.Phase = werftv1.JobPhase_PHASE_WAITING
+			if !decision.Approved {
+				log.WithField("name", name).WithField("user", decision.User).Debug("rejected this waiting job")
+				fail(fmt.Sprintf("job was rejected by %s", decision.User))
+				return
+			}
+			log.WithField("name", name).WithField("user", decision.User).Debug("approved this waiting job")
+			run()
+		case reason := <-cancelChan:
+			log.WithField("name", name).Debug("canceled this waiting job")
+			fail(reason)
+		}
+	}()
 
-		// normally we'd see a Kubernetes event as the job would start immediately. This Kubernetes event would propagate
-		// throughout the system. However, waiting jobs do not produce Kubernetes events right away, hence we have to
-		// call OnUpdate ourselves.
-		js.OnUpdate(&poddesc, status)
+	return status, nil
+}
 
-		return status, nil
+// storePendingApproval keeps the pod of a job which awaits approval in a secret
+func (js *Executor) storePendingApproval(poddesc corev1.Pod) error {
+	pod, err := json.Marshal(poddesc)
+	if err != nil {
+		return err
 	}
+	_, err = js.Client.CoreV1().Secrets(js.Config.Namespace).Create(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: poddesc.Name,
+			Labels: map[string]string{
+				js.labels.LabelJobName:          poddesc.Name,
+				js.labels.LabelAwaitingApproval: "true",
+			},
+		},
+		Data: map[string][]byte{pendingApprovalPodKey: pod},
+	}, metav1.CreateOptions{})
+	return err
+}
+
+// deletePendingApproval removes the secret of a job which no longer awaits approval
+func (js *Executor) deletePendingApproval(name string) error {
+	err := js.Client.CoreV1().Secrets(js.Config.Namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err, ok := err.(*k8serr.StatusError); ok && err.ErrStatus.Code == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// restorePendingApprovals registers all jobs which were awaiting approval when werft stopped
+func (js *Executor) restorePendingApprovals() error {
+	secrets, err := js.Client.CoreV1().Secrets(js.Config.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=true", js.labels.LabelAwaitingApproval),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets.Items {
+		var poddesc corev1.Pod
+		err := json.Unmarshal(secret.Data[pendingApprovalPodKey], &poddesc)
+		if err != nil {
+			log.WithError(err).WithField("name", secret.Name).Warn("cannot restore job awaiting approval")
+			continue
+		}
+
+		// werft might have stopped after the job was approved but before it cleaned up
+		_, err = js.Client.CoreV1().Pods(js.Config.Namespace).Get(context.Background(), poddesc.Name, metav1.GetOptions{})
+		if err == nil {
+			err = js.deletePendingApproval(secret.Name)
+			if err != nil {
+				log.WithError(err).WithField("name", secret.Name).Warn("cannot delete job awaiting approval")
+			}
+			continue
+		}
 
-	return startJob()
+		_, err = js.wait(poddesc, time.Time{})
+		if err != nil {
+			log.WithError(err).WithField("name", secret.Name).Warn("cannot restore job awaiting approval")
+			continue
+		}
+		log.WithField("name", poddesc.Name).Info("restored job awaiting approval")
+	}
+	return nil
 }
 
 func (js *Executor) monitorJobs() {
@@ -456,6 +617,20 @@ func (js *Executor) Logs(name string) io.Reader {
 	return listenToLogs(js.Client, name, js.Config.Namespace, js.labels)
 }
 
+// GetTemplateSecret retrieves the data of a secret for use in job templates. Only secrets labeled
+// with the template secret label (e.g. werft.dev/templateSecret=true) are available to job templates.
+func (js *Executor) GetTemplateSecret(ctx context.Context, name string) (map[string][]byte, error) {
+	secret, err := js.Client.CoreV1().Secrets(js.Config.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !k8serr.IsNotFound(err) {
+		return nil, err
+	}
+	if err != nil || secret.Labels[js.labels.LabelTemplateSecret] != "true" {
+		// we don't tell if the secret exists, unless it's meant to be used by job templates
+		return nil, xerrors.Errorf("secret %s is not available to job templates", name)
+	}
+	return secret.Data, nil
+}
+
 func (js *Executor) doHousekeeping() {
 	tick := time.NewTicker(js.Config.JobPrepTimeout.Duration / 2)
 	for {
@@ -557,6 +732,25 @@ func (js *Executor) Stop(name, reason string) error {
 	return nil
 }
 
+// ErrNotAwaitingApproval is returned when approving or rejecting a job which does not await approval
+var ErrNotAwaitingApproval = xerrors.Errorf("job is not awaiting approval")
+
+// Approve records the decision of an approver on a job which awaits approval. Approving the job starts it,
+// rejecting it fails the job.
+func (js *Executor) Approve(name string, decision *werftv1.ApprovalDecision) error {
+	js.mu.Lock()
+	wj, ok := js.waitingJobs[name]
+	if !ok || !wj.Status.Conditions.AwaitingApproval {
+		js.mu.Unlock()
+		return ErrNotAwaitingApproval
+	}
+	delete(js.waitingJobs, name)
+	js.mu.Unlock()
+
+	wj.Approve(decision)
+	return nil
+}
+
 // GetKnownJobs returns a list of all jobs the executor knows about
 func (js *Executor) GetKnownJobs() (jobs []werftv1.JobStatus, err error) {
 	js.mu.RLock()
@@ -18,6 +18,12 @@ type labelSet struct {
 	// LabelMutex makes jobs findable via their mutex
 	LabelMutex string
 
+	// LabelTemplateSecret marks secrets which job templates may use
+	LabelTemplateSecret string
+
+	// LabelAwaitingApproval marks the secrets which keep the pods of jobs awaiting approval
+	LabelAwaitingApproval string
+
 	// UserDataAnnotationPrefix is prepended together with the label prefix to all user annotations added to jobs
 	UserDataAnnotationPrefix string
 
@@ -41,6 +47,9 @@ type labelSet struct {
 
 	// AnnotationSidecars lists all container whose lifecycle depends on that of the others
 	AnnotationSidecars string
+
+	// AnnotationApproval stores the JSON encoded approval of a job, including who approved it
+	AnnotationApproval string
 }
 
 // newLabelSetet returns a new label set initialized with a particular prefix
@@ -54,6 +63,8 @@ func newLabelSetet(prefix string) labelSet {
 		LabelWerftMarker:         prefix + "job",
 		LabelJobName:             prefix + "jobName",
 		LabelMutex:               prefix + "mutex",
+		LabelTemplateSecret:      prefix + "templateSecret",
+		LabelAwaitingApproval:    prefix + "awaitingApproval",
 		UserDataAnnotationPrefix: "userdata." + prefix,
 		AnnotationFailureLimit:   prefix + "failureLimit",
 		AnnotationMetadata:       prefix + "metadata",
@@ -62,5 +73,6 @@ func newLabelSetet(prefix string) labelSet {
 		AnnotationCanReplay:      prefix + "canReplay",
 		AnnotationWaitUntil:      prefix + "waitUntil",
 		AnnotationSidecars:       prefix + "sidecars",
+		AnnotationApproval:       prefix + "approval",
 	}
 }
@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"strings"
 	"sync"
 	"time"
 
@@ -100,7 +99,7 @@ func (ll *logListener) Start() {
 			for _, c := range statuses {
 				if c.State.Running != nil {
 					var prefix string
-					if isSidecar := strings.Contains(pod.Annotations[ll.Labels.AnnotationSidecars], c.Name); isSidecar {
+					if isSidecar(pod, ll.Labels, c.Name) {
 						prefix = fmt.Sprintf("[%s] ", c.Name)
 					}
 					go ll.tail(pod.Name, c.Name, prefix)
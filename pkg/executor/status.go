@@ -61,6 +61,15 @@ func getStatus(obj *corev1.Pod, labels labelSet) (status *v1.JobStatus, err erro
 		}
 	}
 
+	var approval *v1.JobApproval
+	if c, ok := obj.Annotations[labels.AnnotationApproval]; ok {
+		approval = &v1.JobApproval{}
+		err = jsonpb.UnmarshalString(c, approval)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot unmarshal approval: %w", err)
+		}
+	}
+
 	status = &v1.JobStatus{
 		Name:     name,
 		Metadata: &md,
@@ -70,7 +79,8 @@ func getStatus(obj *corev1.Pod, labels labelSet) (status *v1.JobStatus, err erro
 			CanReplay: canReplay,
 			WaitUntil: waitUntil,
 		},
-		Results: results,
+		Results:  results,
+		Approval: approval,
 	}
 
 	var (
@@ -87,7 +97,7 @@ func getStatus(obj *corev1.Pod, labels labelSet) (status *v1.JobStatus, err erro
 			return
 		}
 
-		isSidecarContainer := strings.Contains(obj.Annotations[labels.AnnotationSidecars], cs.Name)
+		isSidecarContainer := isSidecar(obj, labels, cs.Name)
 		if cs.State.Terminated != nil {
 			if cs.State.Terminated.ExitCode != 0 {
 				anyFailed = true
@@ -152,3 +162,14 @@ func getJobName(obj *corev1.Pod, labels labelSet) (id string, ok bool) {
 	id, ok = obj.Labels[labels.LabelJobName]
 	return
 }
+
+// isSidecar returns true if the pod lists the container as sidecar. The names must match exactly, because
+// the generated containers of steps and services share the name space with the containers of the job.
+func isSidecar(pod *corev1.Pod, labels labelSet, container string) bool {
+	for _, s := range strings.Fields(pod.Annotations[labels.AnnotationSidecars]) {
+		if s == container {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package executor
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsSidecar(t *testing.T) {
+	labels := newLabelSetet("")
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{labels.AnnotationSidecars: "redis postgres-db"},
+	}}
+	for container, expectation := range map[string]bool{
+		"redis":       true,
+		"postgres-db": true,
+		"db":          false,
+		"postgres":    false,
+		"redis-cli":   false,
+	} {
+		if act := isSidecar(pod, labels, container); act != expectation {
+			t.Errorf("%s: expected %v, got %v", container, expectation, act)
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package filterexpr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"golang.org/x/xerrors"
+)
+
+// maxClauses limits the number of OR-clauses an expression may compile to. Converting an expression to
+// conjunctive normal form can grow exponentially, hence we need an upper bound.
+const maxClauses = 256
+
+// ErrTooComplex is returned when an expression would compile to too many filter expressions
+var ErrTooComplex = fmt.Errorf("expression is too complex")
+
+// ParseExpression parses a single filter expression, e.g.
+//
+//	phase == done and (repo.ref |= refs/tags/ or not success == true) and created > -24h
+//
+// The expression language supports:
+//   - the comparison operators ==, !=, ~=, |=, =| (and their !-negated forms) as well as <, >, <= and >=,
+//   - regular expressions (field =~ "^refs/heads/release-[0-9]+") and globs (field =* refs/heads/release/*),
+//   - and, or, not and parentheses,
+//   - field in (a, b, c) and field not in (a, b, c),
+//   - a bare field name which checks for the existence of that field.
+//
+// Values containing whitespace or operator characters must be quoted using single or double quotes.
+// Values for created and finished can be RFC3339 timestamps, dates (2006-01-02), unix timestamps, now,
+// or a duration relative to now (e.g. -24h or now-7d).
+//
+// The expression is compiled to conjunctive normal form, i.e. the resulting filter expressions must all
+// match, and within a filter expression one term must match. An empty expression returns no filter.
+func ParseExpression(expr string) ([]*v1.FilterExpression, error) {
+	toks, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 0 {
+		return nil, nil
+	}
+
+	p := &parser{toks: toks}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, xerrors.Errorf("unexpected %s at position %d", t.val, t.pos)
+	}
+
+	clauses, err := toCNF(n, false)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*v1.FilterExpression, len(clauses))
+	for i, c := range clauses {
+		res[i] = &v1.FilterExpression{Terms: c}
+	}
+	return res, nil
+}
+
+type tokenType int
+
+const (
+	tokWord tokenType = iota
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+// exprOps lists all comparison operators. Longer operators must come first so that lexing is greedy.
+var exprOps = []string{"!==", "!~=", "!|=", "!=|", "!=~", "!=*", "==", "!=", "~=", "|=", "=|", "=~", "=*", "<=", ">=", "<", ">"}
+
+func lex(expr string) ([]token, error) {
+	var (
+		res []token
+		rs  = []rune(expr)
+	)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			res = append(res, token{typ: tokLParen, val: "(", pos: i})
+			i++
+		case r == ')':
+			res = append(res, token{typ: tokRParen, val: ")", pos: i})
+			i++
+		case r == ',':
+			res = append(res, token{typ: tokComma, val: ",", pos: i})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			var val strings.Builder
+			for ; i < len(rs) && rs[i] != r; i++ {
+				if rs[i] == '\\' && i+1 < len(rs) && (rs[i+1] == r || rs[i+1] == '\\') {
+					i++
+				}
+				val.WriteRune(rs[i])
+			}
+			if i >= len(rs) {
+				return nil, xerrors.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			res = append(res, token{typ: tokString, val: val.String(), pos: start})
+		case strings.ContainsRune("!=~|<>", r):
+			var op string
+			for _, o := range exprOps {
+				if strings.HasPrefix(string(rs[i:]), o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, xerrors.Errorf("invalid operator at position %d", i)
+			}
+			res = append(res, token{typ: tokOp, val: op, pos: i})
+			i += len([]rune(op))
+		default:
+			start := i
+			for ; i < len(rs); i++ {
+				if unicode.IsSpace(rs[i]) || strings.ContainsRune("(),\"'!=~|<>", rs[i]) {
+					break
+				}
+			}
+			res = append(res, token{typ: tokWord, val: string(rs[start:i]), pos: start})
+		}
+	}
+	return res, nil
+}
+
+type nodeKind int
+
+const (
+	nodeTerm nodeKind = iota
+	nodeAnd
+	nodeOr
+	nodeNot
+)
+
+type node struct {
+	kind     nodeKind
+	children []*node
+	term     *v1.FilterTerm
+}
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() *token {
+	if p.pos >= len(p.toks) {
+		return nil
+	}
+	return &p.toks[p.pos]
+}
+
+func (p *parser) next() *token {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) peekKeyword(kw string) bool {
+	t := p.peek()
+	return t != nil && t.typ == tokWord && strings.EqualFold(t.val, kw)
+}
+
+func (p *parser) parseOr() (*node, error) {
+	return p.parseBinary(nodeOr, "or", p.parseAnd)
+}
+
+func (p *parser) parseAnd() (*node, error) {
+	return p.parseBinary(nodeAnd, "and", p.parseUnary)
+}
+
+func (p *parser) parseBinary(kind nodeKind, kw string, operand func() (*node, error)) (*node, error) {
+	n, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	res := &node{kind: kind, children: []*node{n}}
+	for p.peekKeyword(kw) {
+		p.next()
+		n, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		res.children = append(res.children, n)
+	}
+	if len(res.children) == 1 {
+		return res.children[0], nil
+	}
+	return res, nil
+}
+
+func (p *parser) parseUnary() (*node, error) {
+	if p.peekKeyword("not") {
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeNot, children: []*node{n}}, nil
+	}
+
+	t := p.peek()
+	if t == nil {
+		return nil, xerrors.Errorf("unexpected end of expression")
+	}
+	if t.typ == tokLParen {
+		open := p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t == nil || t.typ != tokRParen {
+			return nil, xerrors.Errorf("missing closing parenthesis for position %d", open.pos)
+		}
+		return n, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (*node, error) {
+	ft := p.next()
+	if ft.typ != tokWord {
+		return nil, xerrors.Errorf("expected field name at position %d, found %s", ft.pos, ft.val)
+	}
+	field := ft.val
+
+	var negateIn bool
+	if p.peekKeyword("not") && p.pos+1 < len(p.toks) && strings.EqualFold(p.toks[p.pos+1].val, "in") {
+		p.next()
+		negateIn = true
+	}
+	if p.peekKeyword("in") {
+		p.next()
+		vals, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		res := &node{kind: nodeOr}
+		for _, v := range vals {
+			v, err := normaliseValue(field, v)
+			if err != nil {
+				return nil, err
+			}
+			res.children = append(res.children, &node{kind: nodeTerm, term: &v1.FilterTerm{Field: field, Value: v, Operation: v1.FilterOp_OP_EQUALS}})
+		}
+		if negateIn {
+			return &node{kind: nodeNot, children: []*node{res}}, nil
+		}
+		return res, nil
+	}
+
+	ot := p.peek()
+	if ot == nil || ot.typ != tokOp {
+		return &node{kind: nodeTerm, term: &v1.FilterTerm{Field: field, Operation: v1.FilterOp_OP_EXISTS}}, nil
+	}
+	p.next()
+
+	vt := p.next()
+	if vt == nil || (vt.typ != tokWord && vt.typ != tokString) {
+		return nil, xerrors.Errorf("missing value for %s at position %d", field, ot.pos)
+	}
+	val, err := normaliseValue(field, vt.val)
+	if err != nil {
+		return nil, err
+	}
+
+	term := &v1.FilterTerm{Field: field, Value: val}
+	switch ot.val {
+	case "==":
+		term.Operation = v1.FilterOp_OP_EQUALS
+	case "!=", "!==":
+		term.Operation, term.Negate = v1.FilterOp_OP_EQUALS, true
+	case "~=":
+		term.Operation = v1.FilterOp_OP_CONTAINS
+	case "!~=":
+		term.Operation, term.Negate = v1.FilterOp_OP_CONTAINS, true
+	case "|=":
+		term.Operation = v1.FilterOp_OP_STARTS_WITH
+	case "!|=":
+		term.Operation, term.Negate = v1.FilterOp_OP_STARTS_WITH, true
+	case "=|":
+		term.Operation = v1.FilterOp_OP_ENDS_WITH
+	case "!=|":
+		term.Operation, term.Negate = v1.FilterOp_OP_ENDS_WITH, true
+	case "=~":
+		term.Operation = v1.FilterOp_OP_REGEX
+	case "!=~":
+		term.Operation, term.Negate = v1.FilterOp_OP_REGEX, true
+	case "=*":
+		term.Operation = v1.FilterOp_OP_GLOB
+	case "!=*":
+		term.Operation, term.Negate = v1.FilterOp_OP_GLOB, true
+	case "<":
+		term.Operation = v1.FilterOp_OP_LESS_THAN
+	case ">":
+		term.Operation = v1.FilterOp_OP_GREATER_THAN
+	case "<=":
+		term.Operation, term.Negate = v1.FilterOp_OP_GREATER_THAN, true
+	case ">=":
+		term.Operation, term.Negate = v1.FilterOp_OP_LESS_THAN, true
+	default:
+		return nil, xerrors.Errorf("unsupported operator %s at position %d", ot.val, ot.pos)
+	}
+	if term.Operation == v1.FilterOp_OP_REGEX {
+		if _, err := regexp.Compile(val); err != nil {
+			return nil, xerrors.Errorf("invalid regular expression %s: %w", val, err)
+		}
+	}
+	return &node{kind: nodeTerm, term: term}, nil
+}
+
+func (p *parser) parseList() ([]string, error) {
+	if t := p.next(); t == nil || t.typ != tokLParen {
+		return nil, xerrors.Errorf("expected ( after in")
+	}
+
+	var res []string
+	for {
+		t := p.next()
+		if t == nil {
+			return nil, xerrors.Errorf("unterminated list")
+		}
+		if t.typ != tokWord && t.typ != tokString {
+			return nil, xerrors.Errorf("expected value at position %d, found %s", t.pos, t.val)
+		}
+		res = append(res, t.val)
+
+		t = p.next()
+		if t == nil {
+			return nil, xerrors.Errorf("unterminated list")
+		}
+		if t.typ == tokRParen {
+			return res, nil
+		}
+		if t.typ != tokComma {
+			return nil, xerrors.Errorf("expected , or ) at position %d, found %s", t.pos, t.val)
+		}
+	}
+}
+
+// toCNF converts the expression tree to conjunctive normal form, pushing negations down to the terms.
+func toCNF(n *node, negate bool) ([][]*v1.FilterTerm, error) {
+	switch n.kind {
+	case nodeTerm:
+		t := *n.term
+		t.Negate = t.Negate != negate
+		return [][]*v1.FilterTerm{{&t}}, nil
+	case nodeNot:
+		return toCNF(n.children[0], !negate)
+	}
+
+	conjunction := (n.kind == nodeAnd) != negate
+	if conjunction {
+		var res [][]*v1.FilterTerm
+		for _, c := range n.children {
+			cs, err := toCNF(c, negate)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, cs...)
+			if len(res) > maxClauses {
+				return nil, ErrTooComplex
+			}
+		}
+		return res, nil
+	}
+
+	// disjunction: distribute the OR over the clauses of all children
+	res := [][]*v1.FilterTerm{{}}
+	for _, c := range n.children {
+		cs, err := toCNF(c, negate)
+		if err != nil {
+			return nil, err
+		}
+		if len(res)*len(cs) > maxClauses {
+			return nil, ErrTooComplex
+		}
+
+		var nres [][]*v1.FilterTerm
+		for _, a := range res {
+			for _, b := range cs {
+				clause := make([]*v1.FilterTerm, 0, len(a)+len(b))
+				clause = append(clause, a...)
+				clause = append(clause, b...)
+				nres = append(nres, clause)
+			}
+		}
+		res = nres
+	}
+	return res, nil
+}
@@ -0,0 +1,209 @@
+package filterexpr
+
+import (
+	"strconv"
+	"strings"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fieldAliases are shorthands for field paths
+var fieldAliases = map[string]string{
+	"spec_name": "metadata.job_spec_name",
+	"repo.rev":  "metadata.repository.revision",
+}
+
+// FieldPath addresses a scalar field of a JobStatus by its path, e.g. conditions.did_execute or results.type
+type FieldPath struct {
+	// Name is the canonical path of the field using protobuf field names, e.g. conditions.did_execute
+	Name string
+	// Segments are the protobuf JSON names of the fields along the path, e.g. [conditions didExecute]
+	Segments []string
+	// Repeated marks the segments which are lists
+	Repeated []bool
+
+	fields []protoreflect.FieldDescriptor
+}
+
+// ResolveField resolves the dotted path of a JobStatus field. Path segments can use the protobuf name
+// (did_execute) or the JSON name (didExecute) of a field. Paths which do not start with a JobStatus field
+// are resolved relative to the job metadata, i.e. job_spec_name is the same as metadata.job_spec_name.
+// The path must lead to a scalar field, i.e. a string, bool, number or enum.
+func ResolveField(field string) (*FieldPath, error) {
+	if alias, ok := fieldAliases[field]; ok {
+		field = alias
+	}
+
+	desc := proto.MessageReflect(&v1.JobStatus{}).Descriptor()
+	segs := strings.Split(field, ".")
+	if findField(desc, segs[0]) == nil {
+		if md := findField(desc, "metadata"); md != nil && findField(md.Message(), segs[0]) != nil {
+			segs = append([]string{"metadata"}, segs...)
+		}
+	}
+
+	var (
+		res   FieldPath
+		names []string
+	)
+	for i, seg := range segs {
+		if desc == nil {
+			return nil, xerrors.Errorf("unknown field %s: %s is not a message", field, strings.Join(segs[:i], "."))
+		}
+		fd := findField(desc, seg)
+		if fd == nil {
+			return nil, xerrors.Errorf("unknown field %s", field)
+		}
+		if fd.IsMap() {
+			return nil, xerrors.Errorf("unsupported field %s: maps are not supported", field)
+		}
+
+		res.fields = append(res.fields, fd)
+		res.Segments = append(res.Segments, fd.JSONName())
+		res.Repeated = append(res.Repeated, fd.IsList())
+		names = append(names, string(fd.Name()))
+		desc = fd.Message()
+	}
+	if desc != nil {
+		return nil, xerrors.Errorf("unsupported field %s: only scalar fields can be filtered on", field)
+	}
+	res.Name = strings.Join(names, ".")
+
+	return &res, nil
+}
+
+func findField(desc protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := desc.Fields()
+	if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return fields.ByJSONName(name)
+}
+
+func (p *FieldPath) leaf() protoreflect.FieldDescriptor {
+	return p.fields[len(p.fields)-1]
+}
+
+// IsRepeated returns true if any segment of the path is a list
+func (p *FieldPath) IsRepeated() bool {
+	for _, r := range p.Repeated {
+		if r {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNumeric returns true if the field is a number or enum
+func (p *FieldPath) IsNumeric() bool {
+	switch p.leaf().Kind() {
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.BoolKind:
+		return false
+	default:
+		return true
+	}
+}
+
+// IsJSONString returns true if the JSON representation of the field is a string.
+// Protobuf encodes 64 bit integers as strings in JSON.
+func (p *FieldPath) IsJSONString() bool {
+	switch p.leaf().Kind() {
+	case protoreflect.StringKind, protoreflect.BytesKind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsBool returns true if the field is a bool
+func (p *FieldPath) IsBool() bool {
+	return p.leaf().Kind() == protoreflect.BoolKind
+}
+
+// ZeroValue returns the normalised value of the field if it is not set
+func (p *FieldPath) ZeroValue() string {
+	return p.format(p.leaf().Default())
+}
+
+// NormaliseValue brings a filter value into the form Values produces. Bools become true or false,
+// enum values are accepted by name (case-insensitive, with or without their prefix) and become their number.
+func (p *FieldPath) NormaliseValue(val string) (string, error) {
+	fd := p.leaf()
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		switch strings.ToLower(val) {
+		case "true", "1":
+			return "true", nil
+		case "false", "0":
+			return "false", nil
+		}
+		return "", xerrors.Errorf("invalid value for %s: %s is not a bool", p.Name, val)
+	case protoreflect.EnumKind:
+		if _, err := strconv.ParseInt(val, 10, 32); err == nil {
+			return val, nil
+		}
+		vals := fd.Enum().Values()
+		for i := 0; i < vals.Len(); i++ {
+			ev := vals.Get(i)
+			name := string(ev.Name())
+			if strings.EqualFold(name, val) || strings.HasSuffix(strings.ToLower(name), "_"+strings.ToLower(val)) {
+				return strconv.Itoa(int(ev.Number())), nil
+			}
+		}
+		return "", xerrors.Errorf("invalid value for %s: %s", p.Name, val)
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return val, nil
+	default:
+		if _, err := strconv.ParseFloat(val, 64); err != nil {
+			return "", xerrors.Errorf("invalid value for %s: %s is not a number", p.Name, val)
+		}
+		return val, nil
+	}
+}
+
+// Values returns the normalised values of the field. Unset fields produce their zero value.
+// If the path contains lists, there is one value per list element.
+func (p *FieldPath) Values(js *v1.JobStatus) []string {
+	var res []string
+	p.collect(proto.MessageReflect(js), 0, &res)
+	return res
+}
+
+func (p *FieldPath) collect(msg protoreflect.Message, idx int, res *[]string) {
+	fd := p.fields[idx]
+	val := msg.Get(fd)
+	last := idx == len(p.fields)-1
+
+	if fd.IsList() {
+		lst := val.List()
+		for i := 0; i < lst.Len(); i++ {
+			if last {
+				*res = append(*res, p.format(lst.Get(i)))
+			} else {
+				p.collect(lst.Get(i).Message(), idx+1, res)
+			}
+		}
+		return
+	}
+	if last {
+		*res = append(*res, p.format(val))
+		return
+	}
+	p.collect(val.Message(), idx+1, res)
+}
+
+func (p *FieldPath) format(val protoreflect.Value) string {
+	switch p.leaf().Kind() {
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(val.Bool())
+	case protoreflect.EnumKind:
+		return strconv.Itoa(int(val.Enum()))
+	default:
+		return val.String()
+	}
+}
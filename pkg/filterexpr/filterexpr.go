@@ -2,7 +2,10 @@ package filterexpr
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	v1 "github.com/csweichel/werft/pkg/api/v1"
 	"golang.org/x/xerrors"
@@ -18,6 +21,8 @@ func Parse(exprs []string) ([]*v1.FilterTerm, error) {
 		"~=": v1.FilterOp_OP_CONTAINS,
 		"|=": v1.FilterOp_OP_STARTS_WITH,
 		"=|": v1.FilterOp_OP_ENDS_WITH,
+		"=~": v1.FilterOp_OP_REGEX,
+		"=*": v1.FilterOp_OP_GLOB,
 	}
 
 	res := make([]*v1.FilterTerm, len(exprs))
@@ -26,37 +31,34 @@ func Parse(exprs []string) ([]*v1.FilterTerm, error) {
 			op  v1.FilterOp
 			opn string
 			neg bool
+			pos = -1
 		)
+		// the operator that occurs first wins, and for operators at the same position the longest one.
+		// This way "foo==*bar" is an equality check and not a glob.
 		for k, v := range ops {
-			if strings.Contains(expr, "!"+k) {
-				op = v
-				opn = "!" + k
-				neg = true
-				break
-			}
-			if strings.Contains(expr, k) {
-				op = v
-				opn = k
-				break
+			for _, cand := range []string{"!" + k, k} {
+				idx := strings.Index(expr, cand)
+				if idx < 0 {
+					continue
+				}
+				if pos >= 0 && (idx > pos || (idx == pos && len(cand) <= len(opn))) {
+					continue
+				}
+				op, opn, neg, pos = v, cand, strings.HasPrefix(cand, "!"), idx
 			}
 		}
 		if opn == "" {
 			return nil, ErrMissingOp
 		}
 
-		segs := strings.Split(expr, opn)
-		field, val := strings.TrimSpace(segs[0]), strings.TrimSpace(segs[1])
-		if field == "success" {
-			if val == "true" {
-				val = "1"
-			} else {
-				val = "0"
-			}
+		field, val := strings.TrimSpace(expr[:pos]), strings.TrimSpace(expr[pos+len(opn):])
+		val, err := normaliseValue(field, val)
+		if err != nil {
+			return nil, err
 		}
-		if field == "phase" {
-			phn := strings.ToUpper(fmt.Sprintf("PHASE_%s", val))
-			if _, ok := v1.JobPhase_value[phn]; !ok {
-				return nil, xerrors.Errorf("invalid phase: %s", val)
+		if op == v1.FilterOp_OP_REGEX {
+			if _, err := regexp.Compile(val); err != nil {
+				return nil, xerrors.Errorf("invalid regular expression %s: %w", val, err)
 			}
 		}
 
@@ -71,21 +73,140 @@ func Parse(exprs []string) ([]*v1.FilterTerm, error) {
 	return res, nil
 }
 
-// MatchesFilter returns true if the annotations are matched by the filter
-func MatchesFilter(js *v1.JobStatus, filter []*v1.FilterExpression) (matches bool) {
-	if len(filter) == 0 {
-		return true
+// normaliseValue brings the value of a filter term into the form the job stores and MatchesFilter expect
+func normaliseValue(field, val string) (string, error) {
+	switch field {
+	case "success":
+		if val == "true" || val == "1" {
+			return "1", nil
+		}
+		return "0", nil
+	case "pinned":
+		if val == "true" || val == "1" {
+			return "true", nil
+		}
+		return "false", nil
+	case "phase":
+		phn := strings.ToUpper(fmt.Sprintf("PHASE_%s", val))
+		if _, ok := v1.JobPhase_value[phn]; !ok {
+			return "", xerrors.Errorf("invalid phase: %s", val)
+		}
+	case "created", "finished":
+		// relative times are kept as they are and resolved when the filter is evaluated,
+		// so that long-lived filters such as retention rules don't go stale
+		if _, ok, err := relativeTime(val); ok || err != nil {
+			return val, err
+		}
+		t, err := parseTime(val, time.Now())
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(t.Unix(), 10), nil
 	}
-	if js == nil {
-		return false
+	return val, nil
+}
+
+// ResolveValue brings the value of a filter term into the form the job stores compare against at the time
+// of evaluation, i.e. relative times of created and finished become unix timestamps relative to now.
+// All other values are returned as they are.
+func ResolveValue(field, val string, now time.Time) (string, error) {
+	if field != "created" && field != "finished" {
+		return val, nil
+	}
+	offset, ok, err := relativeTime(val)
+	if err != nil || !ok {
+		return val, err
+	}
+	return strconv.FormatInt(now.Add(offset).Unix(), 10), nil
+}
+
+// ParseTime parses absolute (RFC3339, date or unix timestamp) and relative (now, -24h, now-7d, now+1h) time values
+func ParseTime(val string) (time.Time, error) {
+	return parseTime(val, time.Now())
+}
+
+// parseTime parses absolute (RFC3339, date or unix timestamp) and relative (now, -24h, now-7d, now+1h) time values
+func parseTime(val string, now time.Time) (time.Time, error) {
+	if offset, ok, err := relativeTime(val); err != nil {
+		return time.Time{}, err
+	} else if ok {
+		return now.Add(offset), nil
+	}
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", val); err == nil {
+		return t, nil
+	}
+	if ts, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return time.Unix(ts, 0), nil
+	}
+	return time.Time{}, xerrors.Errorf("invalid time: %s", val)
+}
+
+// relativeTime returns the offset to now of a relative time value, e.g. -24h for now-24h or 1h for +1h.
+// If the value is not a relative time, ok is false.
+func relativeTime(val string) (offset time.Duration, ok bool, err error) {
+	if val == "now" {
+		return 0, true, nil
+	}
+	rel := strings.TrimPrefix(val, "now")
+	if rel == "" || (rel[0] != '-' && rel[0] != '+') {
+		if rel != val {
+			return 0, false, xerrors.Errorf("invalid relative time %s", val)
+		}
+		return 0, false, nil
+	}
+
+	d, err := parseDuration(rel[1:])
+	if err == nil && d < 0 {
+		err = xerrors.Errorf("duration must not have a sign")
+	}
+	if err != nil {
+		return 0, false, xerrors.Errorf("invalid relative time %s: %w", val, err)
+	}
+	if rel[0] == '-' {
+		d = -d
+	}
+	return d, true, nil
+}
+
+// parseDuration extends time.ParseDuration with support for days (d) and weeks (w)
+func parseDuration(val string) (time.Duration, error) {
+	for unit, mult := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if !strings.HasSuffix(val, unit) {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSuffix(val, unit), 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n * float64(mult)), nil
 	}
+	return time.ParseDuration(val)
+}
 
+// Fields returns the values of all fields of a job that can be used in filter and order expressions
+func Fields(js *v1.JobStatus) map[string]string {
 	idx := map[string]string{
-		"name":  js.Name,
-		"phase": strings.ToLower(strings.TrimPrefix(js.Phase.String(), "PHASE_")),
+		"name":   js.Name,
+		"phase":  strings.ToLower(strings.TrimPrefix(js.Phase.String(), "PHASE_")),
+		"pinned": strconv.FormatBool(js.Pinned),
+	}
+	if js.Conditions != nil {
+		idx["success"] = "0"
+		if js.Conditions.Success {
+			idx["success"] = "1"
+		}
 	}
 	if js.Metadata != nil {
 		idx["owner"] = js.Metadata.Owner
+		if js.Metadata.Created != nil {
+			idx["created"] = strconv.FormatInt(js.Metadata.Created.Seconds, 10)
+		}
+		if js.Metadata.Finished != nil {
+			idx["finished"] = strconv.FormatInt(js.Metadata.Finished.Seconds, 10)
+		}
 		idx["trigger"] = strings.ToLower(strings.TrimPrefix(js.Metadata.Trigger.String(), "TRIGGER_"))
 		if js.Metadata.Repository != nil {
 			idx["repo.owner"] = js.Metadata.Repository.Owner
@@ -94,31 +215,61 @@ func MatchesFilter(js *v1.JobStatus, filter []*v1.FilterExpression) (matches boo
 			idx["repo.ref"] = js.Metadata.Repository.Ref
 			idx["repo.rev"] = js.Metadata.Repository.Revision
 		}
+		for _, at := range js.Metadata.Annotations {
+			idx["annotation."+at.Key] = at.Value
+		}
 	}
-	for _, at := range js.Metadata.Annotations {
-		idx["annotation."+at.Key] = at.Value
+	return idx
+}
+
+// columnFields are the fields the SQL job stores keep in columns of their own. Jobs which have no value for
+// such a field, e.g. jobs which have not finished yet, have NULL in that column.
+var columnFields = map[string]struct{}{
+	"name":       {},
+	"owner":      {},
+	"phase":      {},
+	"repo.owner": {},
+	"repo.repo":  {},
+	"repo.host":  {},
+	"repo.ref":   {},
+	"trigger":    {},
+	"success":    {},
+	"created":    {},
+	"finished":   {},
+	"pinned":     {},
+}
+
+// MatchesFilter returns true if the annotations are matched by the filter
+func MatchesFilter(js *v1.JobStatus, filter []*v1.FilterExpression) (matches bool) {
+	if len(filter) == 0 {
+		return true
+	}
+	if js == nil {
+		return false
 	}
 
+	idx := Fields(js)
+	now := time.Now()
+
 	matches = true
 	for _, req := range filter {
 		var tm bool
 		for _, alt := range req.Terms {
 			val, ok := idx[alt.Field]
-			if !ok {
+			if _, column := columnFields[alt.Field]; !ok && column && alt.Operation != v1.FilterOp_OP_EXISTS {
+				// like comparisons with NULL in SQL, comparisons with a value the job doesn't have are false - even when negated
+				tm = false
 				continue
 			}
 
-			switch alt.Operation {
-			case v1.FilterOp_OP_CONTAINS:
-				tm = strings.Contains(val, alt.Value)
-			case v1.FilterOp_OP_ENDS_WITH:
-				tm = strings.HasSuffix(val, alt.Value)
-			case v1.FilterOp_OP_EQUALS:
-				tm = val == alt.Value
-			case v1.FilterOp_OP_STARTS_WITH:
-				tm = strings.HasPrefix(val, alt.Value)
-			case v1.FilterOp_OP_EXISTS:
-				tm = true
+			if ok {
+				value, err := ResolveValue(alt.Field, alt.Value, now)
+				tm = err == nil && matchesTerm(val, alt.Operation, value)
+			} else if fp, err := ResolveField(alt.Field); err == nil {
+				tm = matchesFieldPath(js, fp, alt.Operation, alt.Value)
+			} else {
+				// fields the job doesn't have, e.g. absent annotations, match nothing - unless negated
+				tm = false
 			}
 
 			if alt.Negate {
@@ -137,3 +288,100 @@ func MatchesFilter(js *v1.JobStatus, filter []*v1.FilterExpression) (matches boo
 	}
 	return matches
 }
+
+// matchesFieldPath returns true if any of the values of the field path matches
+func matchesFieldPath(js *v1.JobStatus, fp *FieldPath, op v1.FilterOp, value string) bool {
+	if op != v1.FilterOp_OP_EXISTS {
+		var err error
+		value, err = fp.NormaliseValue(value)
+		if err != nil {
+			return false
+		}
+	}
+	for _, val := range fp.Values(js) {
+		if matchesTerm(val, op, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesTerm(val string, op v1.FilterOp, value string) bool {
+	switch op {
+	case v1.FilterOp_OP_CONTAINS:
+		return strings.Contains(val, value)
+	case v1.FilterOp_OP_ENDS_WITH:
+		return strings.HasSuffix(val, value)
+	case v1.FilterOp_OP_EQUALS:
+		return val == value
+	case v1.FilterOp_OP_STARTS_WITH:
+		return strings.HasPrefix(val, value)
+	case v1.FilterOp_OP_EXISTS:
+		return true
+	case v1.FilterOp_OP_LESS_THAN:
+		return CompareValues(val, value) < 0
+	case v1.FilterOp_OP_GREATER_THAN:
+		return CompareValues(val, value) > 0
+	case v1.FilterOp_OP_REGEX:
+		re, err := regexp.Compile(value)
+		return err == nil && re.MatchString(val)
+	case v1.FilterOp_OP_GLOB:
+		return GlobToRegexp(value).MatchString(val)
+	}
+	return false
+}
+
+// CompareValues compares two values numerically if both are numbers, and lexicographically otherwise
+func CompareValues(a, b string) int {
+	na, erra := strconv.ParseFloat(a, 64)
+	nb, errb := strconv.ParseFloat(b, 64)
+	if erra != nil || errb != nil {
+		return strings.Compare(a, b)
+	}
+	switch {
+	case na < nb:
+		return -1
+	case na > nb:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// GlobToRegexp converts a glob pattern to an anchored regular expression. In a glob pattern * matches any
+// sequence of characters (including /) and ? matches a single character. All other characters match themselves.
+func GlobToRegexp(glob string) *regexp.Regexp {
+	var res strings.Builder
+	res.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			res.WriteString(".*")
+		case '?':
+			res.WriteString(".")
+		default:
+			res.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	res.WriteString("$")
+	return regexp.MustCompile(res.String())
+}
+
+// GlobToLike converts a glob pattern to an SQL LIKE pattern using \ as escape character
+func GlobToLike(glob string) string {
+	var res strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '*':
+			res.WriteRune('%')
+		case '?':
+			res.WriteRune('_')
+		case '%', '_', '\\':
+			res.WriteRune('\\')
+			res.WriteRune(r)
+		default:
+			res.WriteRune(r)
+		}
+	}
+	return res.String()
+}
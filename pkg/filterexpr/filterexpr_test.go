@@ -3,10 +3,12 @@ package filterexpr_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/alecthomas/repr"
 	v1 "github.com/csweichel/werft/pkg/api/v1"
 	"github.com/csweichel/werft/pkg/filterexpr"
+	"github.com/golang/protobuf/ptypes"
 )
 
 func TestValidBasics(t *testing.T) {
@@ -28,6 +30,12 @@ func TestValidBasics(t *testing.T) {
 		{"success!==true", &v1.FilterTerm{Field: "success", Value: "1", Operation: v1.FilterOp_OP_EQUALS, Negate: true}, ""},
 		{"success!==false", &v1.FilterTerm{Field: "success", Value: "0", Operation: v1.FilterOp_OP_EQUALS, Negate: true}, ""},
 		{"trim == whitespace", &v1.FilterTerm{Field: "trim", Value: "whitespace", Operation: v1.FilterOp_OP_EQUALS, Negate: false}, ""},
+		{"foo=~^ba[rz]$", &v1.FilterTerm{Field: "foo", Value: "^ba[rz]$", Operation: v1.FilterOp_OP_REGEX, Negate: false}, ""},
+		{"foo!=~bar", &v1.FilterTerm{Field: "foo", Value: "bar", Operation: v1.FilterOp_OP_REGEX, Negate: true}, ""},
+		{"foo=*refs/heads/*", &v1.FilterTerm{Field: "foo", Value: "refs/heads/*", Operation: v1.FilterOp_OP_GLOB, Negate: false}, ""},
+		{"foo!=*refs/heads/*", &v1.FilterTerm{Field: "foo", Value: "refs/heads/*", Operation: v1.FilterOp_OP_GLOB, Negate: true}, ""},
+		{"foo==*bar", &v1.FilterTerm{Field: "foo", Value: "*bar", Operation: v1.FilterOp_OP_EQUALS, Negate: false}, ""},
+		{"foo=~(", nil, "invalid regular expression (: error parsing regexp: missing closing ): `(`"},
 		{"foo", nil, filterexpr.ErrMissingOp.Error()},
 		{"phase==blabla", nil, "invalid phase: blabla"},
 	}
@@ -78,6 +86,86 @@ func TestMatchesFilter(t *testing.T) {
 			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "trigger", Value: "deleted", Operation: v1.FilterOp_OP_EQUALS, Negate: true}}}},
 			false,
 		},
+		{
+			&v1.JobStatus{Metadata: md, Name: "werft-release-12.3"},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "name", Value: "release-[0-9]+", Operation: v1.FilterOp_OP_REGEX}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: md, Name: "werft-release-abc"},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "name", Value: "release-[0-9]+", Operation: v1.FilterOp_OP_REGEX}}}},
+			false,
+		},
+		{
+			&v1.JobStatus{Metadata: md, Name: "unfinished"},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "finished", Value: "1609459200", Operation: v1.FilterOp_OP_GREATER_THAN, Negate: true}}}},
+			false,
+		},
+		{
+			&v1.JobStatus{Metadata: md, Name: "unfinished"},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "finished", Operation: v1.FilterOp_OP_EXISTS, Negate: true}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: &v1.JobMetadata{Repository: &v1.Repository{Ref: "refs/heads/release/1.0/fix"}}},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "repo.ref", Value: "refs/heads/release/*", Operation: v1.FilterOp_OP_GLOB}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: &v1.JobMetadata{Repository: &v1.Repository{Ref: "refs/heads/main"}}},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "repo.ref", Value: "refs/heads/release/*", Operation: v1.FilterOp_OP_GLOB}}}},
+			false,
+		},
+		{
+			&v1.JobStatus{Metadata: &v1.JobMetadata{Repository: &v1.Repository{Ref: "refs/heads/v1.2"}}},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "repo.ref", Value: "refs/heads/v?.?", Operation: v1.FilterOp_OP_GLOB}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: md, Results: []*v1.JobResult{{Type: "docker"}, {Type: "url"}}},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "results.type", Value: "url", Operation: v1.FilterOp_OP_EQUALS}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: md, Results: []*v1.JobResult{{Type: "docker"}}},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "results.type", Value: "url", Operation: v1.FilterOp_OP_EQUALS, Negate: true}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: md},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "annotation.skip-release", Operation: v1.FilterOp_OP_EXISTS, Negate: true}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: md},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "annotation.skip-release", Value: "true", Operation: v1.FilterOp_OP_EQUALS, Negate: true}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: md},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "annotation.skip-release", Operation: v1.FilterOp_OP_EXISTS}}}},
+			false,
+		},
+		{
+			&v1.JobStatus{Metadata: md},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "conditions.did_execute", Value: "false", Operation: v1.FilterOp_OP_EQUALS}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: md, Conditions: &v1.JobConditions{DidExecute: true}},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "conditions.didExecute", Value: "true", Operation: v1.FilterOp_OP_EQUALS}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: &v1.JobMetadata{JobSpecName: "build"}},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "spec_name", Value: "build", Operation: v1.FilterOp_OP_EQUALS}}}},
+			true,
+		},
+		{
+			&v1.JobStatus{Metadata: md},
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "metadata.trigger", Value: "deleted", Operation: v1.FilterOp_OP_EQUALS}}}},
+			true,
+		},
 	}
 
 	for idx, test := range tests {
@@ -86,3 +174,184 @@ func TestMatchesFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestParseExpression(t *testing.T) {
+	term := func(field, value string, op v1.FilterOp, neg bool) *v1.FilterTerm {
+		return &v1.FilterTerm{Field: field, Value: value, Operation: op, Negate: neg}
+	}
+	tests := []struct {
+		Input  string
+		Result []*v1.FilterExpression
+		Error  string
+	}{
+		{"", nil, ""},
+		{"foo==bar", []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("foo", "bar", v1.FilterOp_OP_EQUALS, false)}}}, ""},
+		{"foo != bar", []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("foo", "bar", v1.FilterOp_OP_EQUALS, true)}}}, ""},
+		{"foo !=| bar", []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("foo", "bar", v1.FilterOp_OP_ENDS_WITH, true)}}}, ""},
+		{`foo == "hello world"`, []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("foo", "hello world", v1.FilterOp_OP_EQUALS, false)}}}, ""},
+		{"annotation.foo", []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("annotation.foo", "", v1.FilterOp_OP_EXISTS, false)}}}, ""},
+		{
+			"phase == done and success == true",
+			[]*v1.FilterExpression{
+				{Terms: []*v1.FilterTerm{term("phase", "done", v1.FilterOp_OP_EQUALS, false)}},
+				{Terms: []*v1.FilterTerm{term("success", "1", v1.FilterOp_OP_EQUALS, false)}},
+			},
+			"",
+		},
+		{
+			"a==1 or b==2",
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("a", "1", v1.FilterOp_OP_EQUALS, false), term("b", "2", v1.FilterOp_OP_EQUALS, false)}}},
+			"",
+		},
+		{
+			"not (a==1 or b==2)",
+			[]*v1.FilterExpression{
+				{Terms: []*v1.FilterTerm{term("a", "1", v1.FilterOp_OP_EQUALS, true)}},
+				{Terms: []*v1.FilterTerm{term("b", "2", v1.FilterOp_OP_EQUALS, true)}},
+			},
+			"",
+		},
+		{
+			"(a==1 and b==2) or c==3",
+			[]*v1.FilterExpression{
+				{Terms: []*v1.FilterTerm{term("a", "1", v1.FilterOp_OP_EQUALS, false), term("c", "3", v1.FilterOp_OP_EQUALS, false)}},
+				{Terms: []*v1.FilterTerm{term("b", "2", v1.FilterOp_OP_EQUALS, false), term("c", "3", v1.FilterOp_OP_EQUALS, false)}},
+			},
+			"",
+		},
+		{
+			"owner in (foo, 'bar')",
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("owner", "foo", v1.FilterOp_OP_EQUALS, false), term("owner", "bar", v1.FilterOp_OP_EQUALS, false)}}},
+			"",
+		},
+		{
+			"owner not in (foo, bar)",
+			[]*v1.FilterExpression{
+				{Terms: []*v1.FilterTerm{term("owner", "foo", v1.FilterOp_OP_EQUALS, true)}},
+				{Terms: []*v1.FilterTerm{term("owner", "bar", v1.FilterOp_OP_EQUALS, true)}},
+			},
+			"",
+		},
+		{"created > 2021-01-01T00:00:00Z", []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("created", "1609459200", v1.FilterOp_OP_GREATER_THAN, false)}}}, ""},
+		{"finished <= 2021-01-01", []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("finished", "1609459200", v1.FilterOp_OP_GREATER_THAN, true)}}}, ""},
+		{`repo.ref =~ "^refs/heads/(main|master)$"`, []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("repo.ref", "^refs/heads/(main|master)$", v1.FilterOp_OP_REGEX, false)}}}, ""},
+		{"repo.ref !=* refs/heads/release/*", []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("repo.ref", "refs/heads/release/*", v1.FilterOp_OP_GLOB, true)}}}, ""},
+		{"repo.ref =~ '['", nil, "invalid regular expression [: error parsing regexp: missing closing ]: `[`"},
+		{"created > yesterday", nil, "invalid time: yesterday"},
+		{"created > -24h", []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("created", "-24h", v1.FilterOp_OP_GREATER_THAN, false)}}}, ""},
+		{"finished < now+1h", []*v1.FilterExpression{{Terms: []*v1.FilterTerm{term("finished", "now+1h", v1.FilterOp_OP_LESS_THAN, false)}}}, ""},
+		{"created > now--1h", nil, "invalid relative time now--1h: duration must not have a sign"},
+		{"created > nowish", nil, "invalid relative time nowish"},
+		{"phase == blabla", nil, "invalid phase: blabla"},
+		{"(a==1", nil, "missing closing parenthesis for position 0"},
+		{"a==", nil, "missing value for a at position 1"},
+		{"a==1 b==2", nil, "unexpected b at position 5"},
+		{`a=="foo`, nil, "unterminated string at position 3"},
+	}
+
+	for _, test := range tests {
+		res, err := filterexpr.ParseExpression(test.Input)
+		if err != nil {
+			if err.Error() != test.Error {
+				t.Errorf("%s: %v != %v", test.Input, err, test.Error)
+			}
+			continue
+		}
+		if test.Error != "" {
+			t.Errorf("%s: expected error %s", test.Input, test.Error)
+			continue
+		}
+		if !reflect.DeepEqual(res, test.Result) {
+			t.Errorf("%s: expected %s but got %s", test.Input, repr.String(test.Result), repr.String(res))
+		}
+	}
+}
+
+func TestParseExpressionRelativeTime(t *testing.T) {
+	res, err := filterexpr.ParseExpression("created > -24h")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recent := &v1.JobStatus{Metadata: &v1.JobMetadata{Created: ptypes.TimestampNow()}}
+	if !filterexpr.MatchesFilter(recent, res) {
+		t.Errorf("job created now should match %s", repr.String(res))
+	}
+
+	old, _ := ptypes.TimestampProto(time.Now().Add(-48 * time.Hour))
+	if filterexpr.MatchesFilter(&v1.JobStatus{Metadata: &v1.JobMetadata{Created: old}}, res) {
+		t.Errorf("job created two days ago should not match %s", repr.String(res))
+	}
+}
+
+func TestMatchesFilterFutureRelativeTime(t *testing.T) {
+	job := &v1.JobStatus{Metadata: &v1.JobMetadata{Created: ptypes.TimestampNow()}}
+	for expr, expectation := range map[string]bool{
+		"created < now+1h": true,
+		"created < +1h":    true,
+		"created > now+1h": false,
+		"created < now-1h": false,
+	} {
+		res, err := filterexpr.ParseExpression(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act := filterexpr.MatchesFilter(job, res); act != expectation {
+			t.Errorf("%s: expected %v, got %v", expr, expectation, act)
+		}
+	}
+}
+
+func TestGlobToLike(t *testing.T) {
+	tests := []struct {
+		Glob string
+		Like string
+	}{
+		{"refs/heads/*", "refs/heads/%"},
+		{"v?.*", "v_.%"},
+		{"100%_done", "100\\%\\_done"},
+	}
+	for _, test := range tests {
+		if act := filterexpr.GlobToLike(test.Glob); act != test.Like {
+			t.Errorf("%s: expected %s but got %s", test.Glob, test.Like, act)
+		}
+	}
+}
+
+func TestResolveField(t *testing.T) {
+	tests := []struct {
+		Field    string
+		Name     string
+		Segments []string
+		Repeated []bool
+		Error    bool
+	}{
+		{Field: "results.type", Name: "results.type", Segments: []string{"results", "type"}, Repeated: []bool{true, false}},
+		{Field: "conditions.did_execute", Name: "conditions.did_execute", Segments: []string{"conditions", "didExecute"}, Repeated: []bool{false, false}},
+		{Field: "conditions.didExecute", Name: "conditions.did_execute", Segments: []string{"conditions", "didExecute"}, Repeated: []bool{false, false}},
+		{Field: "job_spec_name", Name: "metadata.job_spec_name", Segments: []string{"metadata", "jobSpecName"}, Repeated: []bool{false, false}},
+		{Field: "spec_name", Name: "metadata.job_spec_name", Segments: []string{"metadata", "jobSpecName"}, Repeated: []bool{false, false}},
+		{Field: "repo.rev", Name: "metadata.repository.revision", Segments: []string{"metadata", "repository", "revision"}, Repeated: []bool{false, false, false}},
+		{Field: "results.channels", Name: "results.channels", Segments: []string{"results", "channels"}, Repeated: []bool{true, true}},
+		{Field: "conditions", Error: true},
+		{Field: "conditions.did_execute.foo", Error: true},
+		{Field: "doesnotexist", Error: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Field, func(t *testing.T) {
+			fp, err := filterexpr.ResolveField(test.Field)
+			if test.Error {
+				if err == nil {
+					t.Errorf("expected error, got %v", fp)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fp.Name != test.Name || !reflect.DeepEqual(fp.Segments, test.Segments) || !reflect.DeepEqual(fp.Repeated, test.Repeated) {
+				t.Errorf("unexpected field path: %s %v %v", fp.Name, fp.Segments, fp.Repeated)
+			}
+		})
+	}
+}
@@ -509,6 +509,92 @@ func (m *GetRemoteAnnotationsResponse) GetAnnotations() map[string]string {
 	return nil
 }
 
+type ChangedFilesRequest struct {
+	Repository           *v1.Repository `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	BaseRevision         string         `protobuf:"bytes,2,opt,name=base_revision,json=baseRevision,proto3" json:"base_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ChangedFilesRequest) Reset()         { *m = ChangedFilesRequest{} }
+func (m *ChangedFilesRequest) String() string { return proto.CompactTextString(m) }
+func (*ChangedFilesRequest) ProtoMessage()    {}
+func (*ChangedFilesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d8b2585439eaf91, []int{12}
+}
+
+func (m *ChangedFilesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangedFilesRequest.Unmarshal(m, b)
+}
+func (m *ChangedFilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangedFilesRequest.Marshal(b, m, deterministic)
+}
+func (m *ChangedFilesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangedFilesRequest.Merge(m, src)
+}
+func (m *ChangedFilesRequest) XXX_Size() int {
+	return xxx_messageInfo_ChangedFilesRequest.Size(m)
+}
+func (m *ChangedFilesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangedFilesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangedFilesRequest proto.InternalMessageInfo
+
+func (m *ChangedFilesRequest) GetRepository() *v1.Repository {
+	if m != nil {
+		return m.Repository
+	}
+	return nil
+}
+
+func (m *ChangedFilesRequest) GetBaseRevision() string {
+	if m != nil {
+		return m.BaseRevision
+	}
+	return ""
+}
+
+type ChangedFilesResponse struct {
+	Paths                []string `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangedFilesResponse) Reset()         { *m = ChangedFilesResponse{} }
+func (m *ChangedFilesResponse) String() string { return proto.CompactTextString(m) }
+func (*ChangedFilesResponse) ProtoMessage()    {}
+func (*ChangedFilesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d8b2585439eaf91, []int{13}
+}
+
+func (m *ChangedFilesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangedFilesResponse.Unmarshal(m, b)
+}
+func (m *ChangedFilesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangedFilesResponse.Marshal(b, m, deterministic)
+}
+func (m *ChangedFilesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangedFilesResponse.Merge(m, src)
+}
+func (m *ChangedFilesResponse) XXX_Size() int {
+	return xxx_messageInfo_ChangedFilesResponse.Size(m)
+}
+func (m *ChangedFilesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangedFilesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangedFilesResponse proto.InternalMessageInfo
+
+func (m *ChangedFilesResponse) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RepoHostRequest)(nil), "repoplugin.RepoHostRequest")
 	proto.RegisterType((*RepoHostResponse)(nil), "repoplugin.RepoHostResponse")
@@ -523,6 +609,8 @@ func init() {
 	proto.RegisterType((*GetRemoteAnnotationsRequest)(nil), "repoplugin.GetRemoteAnnotationsRequest")
 	proto.RegisterType((*GetRemoteAnnotationsResponse)(nil), "repoplugin.GetRemoteAnnotationsResponse")
 	proto.RegisterMapType((map[string]string)(nil), "repoplugin.GetRemoteAnnotationsResponse.AnnotationsEntry")
+	proto.RegisterType((*ChangedFilesRequest)(nil), "repoplugin.ChangedFilesRequest")
+	proto.RegisterType((*ChangedFilesResponse)(nil), "repoplugin.ChangedFilesResponse")
 }
 
 func init() {
@@ -530,39 +618,42 @@ func init() {
 }
 
 var fileDescriptor_0d8b2585439eaf91 = []byte{
-	// 499 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x8d, 0xd3, 0xd0, 0x26, 0x13, 0xd4, 0xba, 0xab, 0x1e, 0x22, 0x27, 0x87, 0x6a, 0x0f, 0x90,
-	0x03, 0xb8, 0x6a, 0xb8, 0x00, 0x42, 0x88, 0x42, 0x21, 0x54, 0x02, 0x09, 0xad, 0x04, 0x07, 0x38,
-	0x99, 0xb0, 0x50, 0xab, 0xce, 0x8e, 0xf1, 0x4e, 0x12, 0xe5, 0x57, 0xf0, 0x83, 0xf8, 0x73, 0xc8,
-	0xde, 0x8d, 0xbf, 0x64, 0x82, 0x88, 0x7a, 0x9b, 0x7d, 0x33, 0x3b, 0xf3, 0xf6, 0x69, 0xde, 0xc2,
-	0x71, 0x22, 0x63, 0x7c, 0x18, 0x47, 0x8b, 0x1f, 0xa1, 0xf2, 0xe3, 0x04, 0x09, 0x19, 0xa4, 0x90,
-	0x41, 0x3c, 0x16, 0xc4, 0xe1, 0xd9, 0xf2, 0xfc, 0x6c, 0x25, 0x93, 0xef, 0x64, 0xf2, 0xfc, 0x18,
-	0x8e, 0x84, 0x8c, 0xf1, 0x2d, 0x6a, 0x12, 0xf2, 0xe7, 0x42, 0x6a, 0xe2, 0xf7, 0xc0, 0x2d, 0x20,
-	0x1d, 0xa3, 0xd2, 0x92, 0x31, 0xe8, 0x5c, 0xa3, 0xa6, 0x81, 0x73, 0xea, 0x8c, 0x7b, 0x22, 0x8b,
-	0xf9, 0x0b, 0x38, 0x14, 0x52, 0x63, 0xb4, 0x94, 0xf6, 0x26, 0xf3, 0x21, 0x1b, 0xa7, 0x43, 0xc2,
-	0x64, 0x9d, 0xd5, 0xf6, 0x27, 0x87, 0xfe, 0xf2, 0xdc, 0x17, 0x39, 0x2a, 0x4a, 0x15, 0xfc, 0x22,
-	0x1d, 0x6e, 0x3b, 0xd8, 0x41, 0xff, 0xdb, 0x62, 0x06, 0xc3, 0x57, 0xa8, 0x48, 0x2a, 0xba, 0x52,
-	0x21, 0xa5, 0x61, 0x10, 0x2a, 0x99, 0xec, 0xc8, 0x88, 0x9d, 0xc0, 0x9d, 0x38, 0xa0, 0x6b, 0x3d,
-	0x68, 0x9f, 0xee, 0x8d, 0x7b, 0xc2, 0x1c, 0xf8, 0x33, 0x18, 0x35, 0x0f, 0xb1, 0xa4, 0x47, 0xd0,
-	0x9b, 0x6d, 0xc0, 0x6c, 0xc8, 0x5d, 0x51, 0x00, 0xfc, 0x23, 0x1c, 0x5d, 0xe2, 0x4a, 0x45, 0x18,
-	0x7c, 0xdb, 0x95, 0x16, 0x83, 0x4e, 0xca, 0x64, 0xd0, 0x36, 0xf2, 0xa7, 0x31, 0x7f, 0x00, 0x6e,
-	0xd1, 0xd6, 0x12, 0x19, 0xc0, 0xc1, 0xcc, 0x10, 0xb5, 0x34, 0x36, 0x47, 0xfe, 0x09, 0xdc, 0x77,
-	0xa1, 0xa6, 0x37, 0x61, 0x24, 0xf5, 0x6d, 0xb2, 0x18, 0x57, 0xfa, 0x1a, 0x16, 0xb9, 0x88, 0x4e,
-	0x59, 0xc4, 0xf7, 0x30, 0x9c, 0x4a, 0x12, 0x72, 0x8e, 0x24, 0x2f, 0x94, 0x42, 0x0a, 0x28, 0x44,
-	0xb5, 0x2b, 0x19, 0xfe, 0xdb, 0x81, 0x51, 0x73, 0x3f, 0xab, 0xc5, 0x17, 0xe8, 0x07, 0x05, 0x9c,
-	0x71, 0xe9, 0x4f, 0x9e, 0xf8, 0x85, 0x1f, 0xfc, 0x6d, 0xd7, 0xfd, 0x12, 0xf6, 0x5a, 0x51, 0xb2,
-	0x16, 0xe5, 0x6e, 0xde, 0x73, 0x70, 0xeb, 0x05, 0xcc, 0x85, 0xbd, 0x1b, 0xb9, 0xb6, 0x16, 0x49,
-	0xc3, 0x54, 0x88, 0x65, 0x10, 0x2d, 0xa4, 0x55, 0xcc, 0x1c, 0x9e, 0xb6, 0x1f, 0x3b, 0x93, 0x5f,
-	0x1d, 0x63, 0x32, 0xf3, 0x98, 0x0f, 0x19, 0x1f, 0x36, 0x85, 0xee, 0xc6, 0x78, 0x6c, 0x58, 0x26,
-	0x5a, 0x73, 0xa8, 0x37, 0x6a, 0x4e, 0x1a, 0xe6, 0xbc, 0xc5, 0x2e, 0xe1, 0xc0, 0xfa, 0x8a, 0x79,
-	0xd5, 0xd2, 0xb2, 0x5d, 0xbd, 0x61, 0x63, 0x2e, 0xef, 0x72, 0x03, 0x27, 0x4d, 0x5b, 0xcf, 0xee,
-	0x97, 0xaf, 0x6d, 0x31, 0x9f, 0x37, 0xfe, 0x77, 0x61, 0x3e, 0x6c, 0x0a, 0xdd, 0xcd, 0x36, 0x57,
-	0xdf, 0x5e, 0xb3, 0x4e, 0xf5, 0xed, 0x75, 0x03, 0xf0, 0x16, 0xbb, 0x82, 0x5e, 0xbe, 0x90, 0xac,
-	0x52, 0x5c, 0xdf, 0x7f, 0xef, 0x6f, 0xd9, 0x92, 0x00, 0x4d, 0x2b, 0x52, 0x15, 0x60, 0xcb, 0x4e,
-	0x57, 0x05, 0xd8, 0xb6, 0x6d, 0xbc, 0xf5, 0xb2, 0xfb, 0x79, 0x7f, 0x86, 0xf3, 0x39, 0xaa, 0xaf,
-	0xfb, 0xd9, 0xcf, 0xfc, 0xe8, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x48, 0x85, 0x6b, 0x56, 0xce,
-	0x05, 0x00, 0x00,
+	// 554 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xcd, 0x6e, 0x13, 0x3d,
+	0x14, 0xfd, 0x92, 0xe6, 0x6b, 0x93, 0x9b, 0xd0, 0xa4, 0x26, 0x8b, 0xc8, 0x89, 0x44, 0x64, 0x24,
+	0xc8, 0xa2, 0x4c, 0xd5, 0xb0, 0x01, 0x84, 0x10, 0xa5, 0x85, 0x50, 0x09, 0x24, 0x64, 0x04, 0x0b,
+	0x58, 0xa0, 0x69, 0x6a, 0x1a, 0xd3, 0x89, 0x3d, 0x8c, 0x9d, 0xa9, 0xf2, 0x6c, 0xbc, 0x0e, 0x0f,
+	0x82, 0x66, 0xec, 0xf9, 0x0b, 0xd3, 0x54, 0x04, 0x76, 0xf6, 0xb9, 0xd7, 0xf7, 0x9c, 0x9c, 0xf1,
+	0x71, 0x60, 0x2f, 0x60, 0xbe, 0x7c, 0xe0, 0x7b, 0x8b, 0x0b, 0x2e, 0x1c, 0x3f, 0x90, 0x5a, 0x22,
+	0x88, 0x20, 0x83, 0x60, 0xe4, 0xfa, 0xfc, 0x20, 0x3c, 0x3c, 0xb8, 0x62, 0xc1, 0x57, 0x6d, 0xea,
+	0x64, 0x0f, 0xda, 0x94, 0xf9, 0xf2, 0xb5, 0x54, 0x9a, 0xb2, 0xef, 0x0b, 0xa6, 0x34, 0xb9, 0x07,
+	0x9d, 0x0c, 0x52, 0xbe, 0x14, 0x8a, 0x21, 0x04, 0xb5, 0x99, 0x54, 0xba, 0x57, 0x19, 0x56, 0x46,
+	0x0d, 0x1a, 0xaf, 0xc9, 0x73, 0xd8, 0xa5, 0x4c, 0x49, 0x2f, 0x64, 0xf6, 0x24, 0x72, 0x20, 0xa6,
+	0x53, 0x5c, 0xcb, 0x60, 0x19, 0xf7, 0x36, 0xc7, 0xbb, 0x4e, 0x78, 0xe8, 0xd0, 0x14, 0xa5, 0xb9,
+	0x0e, 0x72, 0x04, 0xed, 0x74, 0x82, 0x25, 0xfa, 0xd3, 0x11, 0x53, 0xe8, 0x1f, 0x4b, 0xa1, 0x99,
+	0xd0, 0xa7, 0x82, 0xeb, 0x68, 0xe9, 0x72, 0xc1, 0x82, 0x0d, 0x15, 0xa1, 0x2e, 0xfc, 0xef, 0xbb,
+	0x7a, 0xa6, 0x7a, 0xd5, 0xe1, 0xd6, 0xa8, 0x41, 0xcd, 0x86, 0x3c, 0x85, 0x41, 0x39, 0x89, 0x15,
+	0x3d, 0x80, 0xc6, 0x34, 0x01, 0x63, 0x92, 0x16, 0xcd, 0x00, 0xf2, 0x01, 0xda, 0x27, 0xf2, 0x4a,
+	0x78, 0xd2, 0x3d, 0xdf, 0x54, 0x16, 0x82, 0x5a, 0xa4, 0xa4, 0x57, 0x35, 0xf6, 0x47, 0x6b, 0xb2,
+	0x0f, 0x9d, 0x6c, 0xac, 0x15, 0xd2, 0x83, 0x9d, 0xa9, 0x11, 0x6a, 0x65, 0x24, 0x5b, 0xf2, 0x11,
+	0x3a, 0x6f, 0xb8, 0xd2, 0xaf, 0xb8, 0xc7, 0xd4, 0xbf, 0x54, 0x31, 0x2a, 0xcc, 0x35, 0x2a, 0x52,
+	0x13, 0x2b, 0x79, 0x13, 0xdf, 0x42, 0x7f, 0xc2, 0x34, 0x65, 0x73, 0xa9, 0xd9, 0x91, 0x10, 0x52,
+	0xbb, 0x9a, 0x4b, 0xb1, 0xa9, 0x18, 0xf2, 0xa3, 0x02, 0x83, 0xf2, 0x79, 0xd6, 0x8b, 0xcf, 0xd0,
+	0x74, 0x33, 0x38, 0xd6, 0xd2, 0x1c, 0x3f, 0x76, 0xb2, 0x3c, 0x38, 0xeb, 0x8e, 0x3b, 0x39, 0xec,
+	0xa5, 0xd0, 0xc1, 0x92, 0xe6, 0xa7, 0xe1, 0x67, 0xd0, 0x59, 0x6d, 0x40, 0x1d, 0xd8, 0xba, 0x64,
+	0x4b, 0x1b, 0x91, 0x68, 0x19, 0x19, 0x11, 0xba, 0xde, 0x82, 0x59, 0xc7, 0xcc, 0xe6, 0x49, 0xf5,
+	0x51, 0x85, 0x7c, 0x83, 0xdb, 0xc7, 0x33, 0x57, 0x5c, 0xb0, 0xf3, 0xbf, 0xfa, 0x22, 0x77, 0xe1,
+	0xd6, 0x99, 0xab, 0xd8, 0x97, 0x80, 0x85, 0x5c, 0x71, 0x29, 0x2c, 0x51, 0x2b, 0x02, 0xa9, 0xc5,
+	0xc8, 0x3e, 0x74, 0x8b, 0x5c, 0x6a, 0xcd, 0x67, 0x1a, 0xff, 0xac, 0x99, 0xf8, 0x1b, 0x86, 0x77,
+	0xb1, 0x53, 0x68, 0x02, 0xf5, 0xe4, 0x49, 0x40, 0xfd, 0xbc, 0x85, 0x2b, 0x6f, 0x07, 0x1e, 0x94,
+	0x17, 0x0d, 0x23, 0xf9, 0x0f, 0x9d, 0xc0, 0x8e, 0x4d, 0x3c, 0xc2, 0xc5, 0xd6, 0xfc, 0x43, 0x82,
+	0xfb, 0xa5, 0xb5, 0x74, 0xca, 0x25, 0x74, 0xcb, 0xf2, 0x88, 0xee, 0xe7, 0x8f, 0xad, 0x79, 0x16,
+	0xf0, 0xe8, 0xe6, 0xc6, 0x94, 0x6c, 0x02, 0xf5, 0x24, 0x67, 0xc5, 0xdf, 0xbe, 0x12, 0x6a, 0x3c,
+	0x28, 0x2f, 0xa6, 0x83, 0x4e, 0xa1, 0x91, 0x46, 0x05, 0x15, 0x9a, 0x57, 0x93, 0x89, 0xaf, 0xab,
+	0xe6, 0x0c, 0x28, 0xbb, 0xbc, 0x45, 0x03, 0xd6, 0xa4, 0x0d, 0x8f, 0x6e, 0x6e, 0x4c, 0x75, 0xbf,
+	0x87, 0x56, 0xfe, 0xfe, 0xa0, 0x3b, 0x05, 0xf3, 0x7e, 0xbf, 0xc5, 0x78, 0x78, 0x7d, 0x43, 0x32,
+	0xf4, 0x45, 0xfd, 0xd3, 0xf6, 0x54, 0xce, 0xe7, 0x52, 0x9c, 0x6d, 0xc7, 0x7f, 0x44, 0x0f, 0x7f,
+	0x0d, 0x00, 0x73, 0x1d, 0x93, 0xc1, 0xbd, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -592,6 +683,10 @@ type RepositoryPluginClient interface {
 	// with a particular commit, e.g. the commit message, PRs or merge requests.
 	// Implementors can expect the revision of the repo object to be set.
 	GetRemoteAnnotations(ctx context.Context, in *GetRemoteAnnotationsRequest, opts ...grpc.CallOption) (*GetRemoteAnnotationsResponse, error)
+	// ChangedFiles lists the files which changed between a base revision and the revision of
+	// the repository. If no base revision is given, the revision's (first) parent is used.
+	// Implementors can expect the revision of the repo object to be set.
+	ChangedFiles(ctx context.Context, in *ChangedFilesRequest, opts ...grpc.CallOption) (*ChangedFilesResponse, error)
 }
 
 type repositoryPluginClient struct {
@@ -656,6 +751,15 @@ func (c *repositoryPluginClient) GetRemoteAnnotations(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *repositoryPluginClient) ChangedFiles(ctx context.Context, in *ChangedFilesRequest, opts ...grpc.CallOption) (*ChangedFilesResponse, error) {
+	out := new(ChangedFilesResponse)
+	err := c.cc.Invoke(ctx, "/repoplugin.RepositoryPlugin/ChangedFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RepositoryPluginServer is the server API for RepositoryPlugin service.
 type RepositoryPluginServer interface {
 	// RepoHost returns the host which this plugins integrates with
@@ -673,6 +777,10 @@ type RepositoryPluginServer interface {
 	// with a particular commit, e.g. the commit message, PRs or merge requests.
 	// Implementors can expect the revision of the repo object to be set.
 	GetRemoteAnnotations(context.Context, *GetRemoteAnnotationsRequest) (*GetRemoteAnnotationsResponse, error)
+	// ChangedFiles lists the files which changed between a base revision and the revision of
+	// the repository. If no base revision is given, the revision's (first) parent is used.
+	// Implementors can expect the revision of the repo object to be set.
+	ChangedFiles(context.Context, *ChangedFilesRequest) (*ChangedFilesResponse, error)
 }
 
 // UnimplementedRepositoryPluginServer can be embedded to have forward compatible implementations.
@@ -697,6 +805,9 @@ func (*UnimplementedRepositoryPluginServer) ListFiles(ctx context.Context, req *
 func (*UnimplementedRepositoryPluginServer) GetRemoteAnnotations(ctx context.Context, req *GetRemoteAnnotationsRequest) (*GetRemoteAnnotationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRemoteAnnotations not implemented")
 }
+func (*UnimplementedRepositoryPluginServer) ChangedFiles(ctx context.Context, req *ChangedFilesRequest) (*ChangedFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangedFiles not implemented")
+}
 
 func RegisterRepositoryPluginServer(s *grpc.Server, srv RepositoryPluginServer) {
 	s.RegisterService(&_RepositoryPlugin_serviceDesc, srv)
@@ -810,6 +921,24 @@ func _RepositoryPlugin_GetRemoteAnnotations_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RepositoryPlugin_ChangedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangedFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoryPluginServer).ChangedFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repoplugin.RepositoryPlugin/ChangedFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoryPluginServer).ChangedFiles(ctx, req.(*ChangedFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _RepositoryPlugin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "repoplugin.RepositoryPlugin",
 	HandlerType: (*RepositoryPluginServer)(nil),
@@ -838,6 +967,10 @@ var _RepositoryPlugin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetRemoteAnnotations",
 			Handler:    _RepositoryPlugin_GetRemoteAnnotations_Handler,
 		},
+		{
+			MethodName: "ChangedFiles",
+			Handler:    _RepositoryPlugin_ChangedFiles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "repo-plugin.proto",
@@ -67,6 +67,16 @@ func (c *compoundRepositoryProvider) RemoteAnnotations(ctx context.Context, repo
 	return prov.RemoteAnnotations(ctx, repo)
 }
 
+// ChangedFiles lists the files which changed between the base revision and the repo's revision.
+// If base is empty, the revision's parent is used.
+func (c *compoundRepositoryProvider) ChangedFiles(ctx context.Context, repo *v1.Repository, base string) (paths []string, err error) {
+	prov, err := c.getProvider(repo.Host)
+	if err != nil {
+		return nil, err
+	}
+	return prov.ChangedFiles(ctx, repo, base)
+}
+
 // ContentProvider produces a content provider for a particular repo
 func (c *compoundRepositoryProvider) ContentProvider(ctx context.Context, repo *v1.Repository, paths ...string) (werft.ContentProvider, error) {
 	prov, err := c.getProvider(repo.Host)
@@ -122,6 +132,20 @@ func (p *pluginHostProvider) RemoteAnnotations(ctx context.Context, repo *v1.Rep
 	return resp.Annotations, nil
 }
 
+// ChangedFiles lists the files which changed between the base revision and the repo's revision.
+// If base is empty, the revision's parent is used.
+func (p *pluginHostProvider) ChangedFiles(ctx context.Context, repo *v1.Repository, base string) (paths []string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := p.C.ChangedFiles(ctx, &common.ChangedFilesRequest{Repository: repo, BaseRevision: base})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Paths, nil
+}
+
 // ContentProvider produces a content provider for a particular repo
 func (p *pluginHostProvider) ContentProvider(ctx context.Context, repo *v1.Repository, paths ...string) (werft.ContentProvider, error) {
 	return &pluginContentProvider{
@@ -1,6 +1,7 @@
 package prettyprint
 
 import (
+	"fmt"
 	"text/tabwriter"
 	"text/template"
 	"time"
@@ -23,6 +24,12 @@ func formatTemplate(pp *Content) error {
 				}
 				return ts.Format(time.RFC3339)
 			},
+			"toDuration": func(seconds float64) string {
+				return time.Duration(seconds * float64(time.Second)).Round(time.Second).String()
+			},
+			"toPercent": func(ratio float64) string {
+				return fmt.Sprintf("%.1f%%", ratio*100)
+			},
 		}).
 		Parse(pp.Template)
 	if err != nil {
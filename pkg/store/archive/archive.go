@@ -0,0 +1,428 @@
+// Package archive exports the content of werft's stores to a tar archive and imports it again.
+// Because archives only use the store interfaces, they can also be used to migrate between store implementations.
+//
+// An archive contains the following entries:
+//
+//	werft-archive.json      archive manifest
+//	jobs/<name>.json        job status in protobuf JSON encoding
+//	specs/<name>.json       job spec and the YAML it was produced from
+//	logs/<name>.log         job log
+//	numbergroups.json       latest number of each number group
+//	schedules.json          all schedules in protobuf JSON encoding
+//
+// Schedules keep their next run and whether they're paused, but not the outcome of their last run.
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/golang/protobuf/jsonpb"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// Version is the version of the archive format
+	Version = 1
+
+	manifestName     = "werft-archive.json"
+	numberGroupsName = "numbergroups.json"
+	schedulesName    = "schedules.json"
+	jobsDir          = "jobs/"
+	specsDir         = "specs/"
+	logsDir          = "logs/"
+
+	pageSize = 100
+)
+
+// Stores are the stores which are exported from or imported into
+type Stores struct {
+	Jobs   store.Jobs
+	Logs   store.Logs
+	Groups store.NumberGroup
+	// Schedules is optional - if it's nil, schedules are neither exported nor imported
+	Schedules store.Schedules
+}
+
+type manifest struct {
+	Version  int       `json:"version"`
+	Exported time.Time `json:"exported"`
+}
+
+type jobSpec struct {
+	Spec json.RawMessage `json:"spec,omitempty"`
+	Data []byte          `json:"data,omitempty"`
+}
+
+// Export writes all jobs, job specs, logs, number groups and schedules to w as tar archive.
+// Reading logs which are still written to blocks until they are closed, hence export should run while werft is stopped.
+func Export(ctx context.Context, w io.Writer, src Stores) error {
+	tw := tar.NewWriter(w)
+	now := time.Now()
+	writeEntry := func(name string, content []byte) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: now,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(content)
+		return err
+	}
+
+	mf, err := json.Marshal(manifest{Version: Version, Exported: now})
+	if err != nil {
+		return err
+	}
+	err = writeEntry(manifestName, mf)
+	if err != nil {
+		return err
+	}
+
+	var (
+		marshaler = &jsonpb.Marshaler{}
+		groups    = make(map[string]int)
+		order     = []*v1.OrderExpression{{Field: "created", Ascending: true}}
+		count     int
+	)
+	for start := 0; ; start += pageSize {
+		jobs, total, err := src.Jobs.Find(ctx, nil, order, start, pageSize)
+		if err != nil {
+			return xerrors.Errorf("cannot list jobs: %w", err)
+		}
+
+		for i := range jobs {
+			job := &jobs[i]
+			data, err := marshaler.MarshalToString(job)
+			if err != nil {
+				return xerrors.Errorf("cannot marshal job %s: %w", job.Name, err)
+			}
+			err = writeEntry(jobsDir+job.Name+".json", []byte(data))
+			if err != nil {
+				return err
+			}
+
+			err = exportJobSpec(writeEntry, src.Jobs, job.Name)
+			if err != nil {
+				return xerrors.Errorf("cannot export job spec of %s: %w", job.Name, err)
+			}
+
+			err = exportLog(writeEntry, src.Logs, job.Name)
+			if err != nil {
+				return xerrors.Errorf("cannot export log of %s: %w", job.Name, err)
+			}
+
+			if grp, ok := numberGroup(job.Name); ok {
+				groups[grp] = 0
+			}
+			count++
+		}
+		log.WithField("exported", count).WithField("total", total).Debug("exporting jobs")
+
+		if len(jobs) == 0 || start+len(jobs) >= total {
+			break
+		}
+	}
+
+	for grp := range groups {
+		nr, err := src.Groups.Latest(grp)
+		if err == store.ErrNotFound {
+			delete(groups, grp)
+			continue
+		}
+		if err != nil {
+			return xerrors.Errorf("cannot export number group %s: %w", grp, err)
+		}
+		groups[grp] = nr
+	}
+	grps, err := json.Marshal(groups)
+	if err != nil {
+		return err
+	}
+	err = writeEntry(numberGroupsName, grps)
+	if err != nil {
+		return err
+	}
+
+	var schedules []json.RawMessage
+	if src.Schedules != nil {
+		res, err := src.Schedules.List(ctx)
+		if err != nil {
+			return xerrors.Errorf("cannot list schedules: %w", err)
+		}
+		for _, sched := range res {
+			data, err := marshaler.MarshalToString(sched)
+			if err != nil {
+				return xerrors.Errorf("cannot marshal schedule %s: %w", sched.Name, err)
+			}
+			schedules = append(schedules, json.RawMessage(data))
+		}
+		scheds, err := json.Marshal(schedules)
+		if err != nil {
+			return err
+		}
+		err = writeEntry(schedulesName, scheds)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.WithField("jobs", count).WithField("numberGroups", len(groups)).WithField("schedules", len(schedules)).Info("export complete")
+	return tw.Close()
+}
+
+func exportJobSpec(writeEntry func(string, []byte) error, jobs store.Jobs, name string) error {
+	spec, data, err := jobs.GetJobSpec(name)
+	if err == store.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	res := jobSpec{Data: data}
+	if spec != nil {
+		s, err := (&jsonpb.Marshaler{}).MarshalToString(spec)
+		if err != nil {
+			return err
+		}
+		res.Spec = json.RawMessage(s)
+	}
+	content, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return writeEntry(specsDir+name+".json", content)
+}
+
+func exportLog(writeEntry func(string, []byte) error, logs store.Logs, name string) error {
+	rd, err := logs.Read(name)
+	if err == store.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+
+	// tar needs to know the size of an entry upfront, hence we have to buffer the log
+	content, err := ioutil.ReadAll(rd)
+	if err != nil {
+		return err
+	}
+	return writeEntry(logsDir+name+".log", content)
+}
+
+// numberGroup returns the number group a job name was produced from, e.g. werft-build-main for werft-build-main.42
+func numberGroup(name string) (group string, ok bool) {
+	idx := strings.LastIndex(name, ".")
+	if idx <= 0 {
+		return "", false
+	}
+	if _, err := strconv.ParseUint(name[idx+1:], 10, 64); err != nil {
+		return "", false
+	}
+	return name[:idx], true
+}
+
+// Import restores the content of an archive produced by Export into the stores. Jobs, job specs and schedules which exist
+// already are overwritten, existing logs are kept. Number groups are only ever advanced so that no job name is handed out twice.
+func Import(ctx context.Context, r io.Reader, dst Stores) error {
+	tr := tar.NewReader(r)
+
+	var (
+		seenManifest bool
+		count        int
+	)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return xerrors.Errorf("cannot read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if !seenManifest {
+			if hdr.Name != manifestName {
+				return xerrors.Errorf("not a werft archive: %s must be the first entry", manifestName)
+			}
+			var mf manifest
+			err = json.NewDecoder(tr).Decode(&mf)
+			if err != nil {
+				return xerrors.Errorf("cannot read archive manifest: %w", err)
+			}
+			if mf.Version != Version {
+				return xerrors.Errorf("unsupported archive version %d", mf.Version)
+			}
+			seenManifest = true
+			continue
+		}
+
+		dir, fn := path.Split(hdr.Name)
+		switch {
+		case dir == jobsDir && strings.HasSuffix(fn, ".json"):
+			var job v1.JobStatus
+			err = jsonpb.Unmarshal(tr, &job)
+			if err != nil {
+				return xerrors.Errorf("cannot read %s: %w", hdr.Name, err)
+			}
+			err = dst.Jobs.Store(ctx, job)
+			if err != nil {
+				return xerrors.Errorf("cannot import job %s: %w", job.Name, err)
+			}
+			if job.Pinned {
+				err = dst.Jobs.Pin(ctx, job.Name, true)
+				if err != nil {
+					return xerrors.Errorf("cannot pin job %s: %w", job.Name, err)
+				}
+				err = dst.Logs.Pin(job.Name, true)
+				if err != nil {
+					return xerrors.Errorf("cannot pin log of %s: %w", job.Name, err)
+				}
+			}
+			count++
+			if count%pageSize == 0 {
+				log.WithField("imported", count).Debug("importing jobs")
+			}
+		case dir == specsDir && strings.HasSuffix(fn, ".json"):
+			name := strings.TrimSuffix(fn, ".json")
+			err = importJobSpec(tr, dst.Jobs, name)
+			if err != nil {
+				return xerrors.Errorf("cannot import job spec of %s: %w", name, err)
+			}
+		case dir == logsDir && strings.HasSuffix(fn, ".log"):
+			name := strings.TrimSuffix(fn, ".log")
+			err = importLog(tr, dst.Logs, name)
+			if err != nil {
+				return xerrors.Errorf("cannot import log of %s: %w", name, err)
+			}
+		case hdr.Name == numberGroupsName:
+			var groups map[string]int
+			err = json.NewDecoder(tr).Decode(&groups)
+			if err != nil {
+				return xerrors.Errorf("cannot read %s: %w", hdr.Name, err)
+			}
+			for grp, nr := range groups {
+				err = advanceNumberGroup(dst.Groups, grp, nr)
+				if err != nil {
+					return xerrors.Errorf("cannot import number group %s: %w", grp, err)
+				}
+			}
+		case hdr.Name == schedulesName && dst.Schedules != nil:
+			err = importSchedules(ctx, tr, dst.Schedules)
+			if err != nil {
+				return err
+			}
+		default:
+			log.WithField("name", hdr.Name).Warn("ignoring unknown archive entry")
+		}
+	}
+	if !seenManifest {
+		return xerrors.Errorf("not a werft archive: archive is empty")
+	}
+
+	log.WithField("jobs", count).Info("import complete")
+	return nil
+}
+
+func importJobSpec(r io.Reader, jobs store.Jobs, name string) error {
+	var s jobSpec
+	err := json.NewDecoder(r).Decode(&s)
+	if err != nil {
+		return err
+	}
+
+	var spec v1.JobSpec
+	if len(s.Spec) > 0 {
+		err = jsonpb.Unmarshal(bytes.NewReader(s.Spec), &spec)
+		if err != nil {
+			return err
+		}
+	}
+	return jobs.StoreJobSpec(name, spec, s.Data)
+}
+
+func importSchedules(ctx context.Context, r io.Reader, schedules store.Schedules) error {
+	var raw []json.RawMessage
+	err := json.NewDecoder(r).Decode(&raw)
+	if err != nil {
+		return xerrors.Errorf("cannot read %s: %w", schedulesName, err)
+	}
+	for _, data := range raw {
+		var sched v1.Schedule
+		err = jsonpb.Unmarshal(bytes.NewReader(data), &sched)
+		if err != nil {
+			return xerrors.Errorf("cannot read %s: %w", schedulesName, err)
+		}
+
+		err = schedules.Create(ctx, sched)
+		if err == store.ErrAlreadyExists {
+			err = schedules.Delete(ctx, sched.Name)
+			if err == nil {
+				err = schedules.Create(ctx, sched)
+			}
+		}
+		if err != nil {
+			return xerrors.Errorf("cannot import schedule %s: %w", sched.Name, err)
+		}
+	}
+	return nil
+}
+
+func importLog(r io.Reader, logs store.Logs, name string) error {
+	// logs are opened for appending, hence importing a log twice would duplicate its content
+	if rd, err := logs.Read(name); err == nil {
+		rd.Close()
+		log.WithField("name", name).Debug("log exists already - not importing")
+		return nil
+	} else if err != store.ErrNotFound {
+		return err
+	}
+
+	w, err := logs.Open(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	if err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// advanceNumberGroup advances a number group until its latest number is at least nr.
+// NumberGroup offers no way to set a number, hence we have to draw numbers until we get there.
+func advanceNumberGroup(groups store.NumberGroup, group string, nr int) error {
+	cur, err := groups.Latest(group)
+	if err == store.ErrNotFound {
+		cur = -1
+	} else if err != nil {
+		return err
+	}
+	for cur < nr {
+		cur, err = groups.Next(group)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package archive_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/csweichel/werft/pkg/store/archive"
+	"github.com/csweichel/werft/pkg/store/storetest"
+	"github.com/golang/protobuf/proto"
+)
+
+type numberGroup struct {
+	groups map[string]int
+	mu     sync.Mutex
+}
+
+func (g *numberGroup) Latest(group string) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	nr, ok := g.groups[group]
+	if !ok {
+		return 0, store.ErrNotFound
+	}
+	return nr, nil
+}
+
+func (g *numberGroup) Next(group string) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	nr, ok := g.groups[group]
+	if ok {
+		nr++
+	}
+	g.groups[group] = nr
+	return nr, nil
+}
+
+func newStores(t *testing.T) archive.Stores {
+	logs, err := store.NewFileLogStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return archive.Stores{
+		Jobs:      store.NewInMemoryJobStore(),
+		Logs:      logs,
+		Groups:    &numberGroup{groups: make(map[string]int)},
+		Schedules: store.NewInMemoryScheduleStore(),
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	var (
+		ctx = context.Background()
+		src = newStores(t)
+		now = time.Now()
+	)
+
+	jobs := []v1.JobStatus{
+		storetest.NewJob("werft-build-main.0", now.Add(-2*time.Hour)),
+		storetest.NewJob("werft-build-main.1", now.Add(-1*time.Hour)),
+		storetest.NewJob("local-job", now),
+	}
+	for _, job := range jobs {
+		err := src.Jobs.Store(ctx, job)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w, err := src.Logs.Open(job.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = io.WriteString(w, "log of "+job.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+	}
+	for i := 0; i < 5; i++ {
+		_, _ = src.Groups.Next("werft-build-main")
+	}
+	err := src.Jobs.Pin(ctx, "werft-build-main.1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/build.yaml"}, NameSuffix: "foo"}
+	err = src.Jobs.StoreJobSpec("werft-build-main.1", spec, []byte("pod: {}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schedules := []v1.Schedule{
+		storetest.NewSchedule("nightly", now.Add(time.Hour).Truncate(time.Second)),
+		storetest.NewSchedule("weekly", now.Add(time.Hour).Truncate(time.Second), func(s *v1.Schedule) { s.Paused = true }),
+	}
+	for _, sched := range schedules {
+		err = src.Schedules.Create(ctx, sched)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	err = archive.Export(ctx, &buf, src)
+	if err != nil {
+		t.Fatalf("cannot export: %v", err)
+	}
+
+	dst := newStores(t)
+	_, _ = dst.Groups.Next("werft-build-main")
+	err = dst.Schedules.Create(ctx, storetest.NewSchedule("nightly", now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = archive.Import(ctx, bytes.NewReader(buf.Bytes()), dst)
+	if err != nil {
+		t.Fatalf("cannot import: %v", err)
+	}
+
+	for _, job := range jobs {
+		act, err := dst.Jobs.Get(ctx, job.Name)
+		if err != nil {
+			t.Errorf("cannot get job %s: %v", job.Name, err)
+			continue
+		}
+		exp, _ := src.Jobs.Get(ctx, job.Name)
+		if !proto.Equal(exp, act) {
+			t.Errorf("job %s: expected %v, got %v", job.Name, exp, act)
+		}
+
+		rd, err := dst.Logs.Read(job.Name)
+		if err != nil {
+			t.Errorf("cannot read log of %s: %v", job.Name, err)
+			continue
+		}
+		lg, _ := ioutil.ReadAll(rd)
+		rd.Close()
+		if exp := "log of " + job.Name; string(lg) != exp {
+			t.Errorf("log of %s: expected %q, got %q", job.Name, exp, string(lg))
+		}
+	}
+
+	actSpec, actData, err := dst.Jobs.GetJobSpec("werft-build-main.1")
+	if err != nil {
+		t.Fatalf("cannot get job spec: %v", err)
+	}
+	if !proto.Equal(&spec, actSpec) || string(actData) != "pod: {}" {
+		t.Errorf("unexpected job spec: %v, %q", actSpec, string(actData))
+	}
+	if _, _, err = dst.Jobs.GetJobSpec("werft-build-main.0"); err != store.ErrNotFound {
+		t.Errorf("expected no job spec for werft-build-main.0, got %v", err)
+	}
+
+	for _, sched := range schedules {
+		act, err := dst.Schedules.Get(ctx, sched.Name)
+		if err != nil {
+			t.Errorf("cannot get schedule %s: %v", sched.Name, err)
+			continue
+		}
+		if !proto.Equal(&sched, act) {
+			t.Errorf("schedule %s: expected %v, got %v", sched.Name, &sched, act)
+		}
+	}
+
+	nr, err := dst.Groups.Latest("werft-build-main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nr != 4 {
+		t.Errorf("expected number group to be at 4, got %d", nr)
+	}
+}
+
+func TestImportInvalid(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Archive func(t *testing.T) []byte
+	}{
+		{"empty", func(t *testing.T) []byte { return nil }},
+		{"not a tar", func(t *testing.T) []byte { return []byte("hello world") }},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := archive.Import(context.Background(), bytes.NewReader(test.Archive(t)), newStores(t))
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
 )
 
 // FileLogStore is a file backed log store
@@ -40,7 +42,7 @@ func NewFileLogStore(base string) (*FileLogStore, error) {
 	return f, nil
 }
 
-// GarbageCollect removes all log files older than the given duration.
+// GarbageCollect removes all log files older than the given duration, except for pinned ones.
 func (fs *FileLogStore) GarbageCollect(olderThan time.Duration) error {
 	// clean known files first - this way we can skip those which are currently
 	// open for writing.
@@ -63,24 +65,85 @@ func (fs *FileLogStore) GarbageCollect(olderThan time.Duration) error {
 	if err != nil {
 		return err
 	}
+	var (
+		pinned  = make(map[string]struct{})
+		removed = make(map[string]struct{})
+	)
 	for _, f := range fss {
-		if !f.Mode().IsRegular() {
+		if strings.HasSuffix(f.Name(), pinnedSuffix) {
+			pinned[strings.TrimSuffix(f.Name(), pinnedSuffix)+".log"] = struct{}{}
+		}
+	}
+	for _, f := range fss {
+		if !f.Mode().IsRegular() || strings.HasSuffix(f.Name(), pinnedSuffix) {
 			continue
 		}
 		if _, ok := openForWriting[f.Name()]; ok {
 			continue
 		}
+		if _, ok := pinned[f.Name()]; ok {
+			continue
+		}
 		if time.Since(fileAge(f)) <= olderThan {
 			continue
 		}
 
 		// we don't want a single file to block the GC of others
-		_ = os.Remove(filepath.Join(fs.Base, f.Name()))
+		if err := os.Remove(filepath.Join(fs.Base, f.Name())); err == nil {
+			removed[f.Name()] = struct{}{}
+		}
+	}
+
+	// forget about the files we've removed so that reading them results in ErrNotFound
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	for id, f := range fs.files {
+		if _, ok := removed[f.fn]; ok && f.closed {
+			delete(fs.files, id)
+		}
 	}
 
 	return nil
 }
 
+// Delete removes the log file of a single job. Log files which are open for writing cannot be deleted.
+func (fs *FileLogStore) Delete(id string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if f, exists := fs.files[id]; exists {
+		if !f.Closed() {
+			return xerrors.Errorf("log %s is open for writing", id)
+		}
+		delete(fs.files, id)
+	}
+
+	for _, fn := range []string{fmt.Sprintf("%s.log", id), id + pinnedSuffix} {
+		err := os.Remove(filepath.Join(fs.Base, fn))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// pinnedSuffix is the suffix of the marker files which protect logs from garbage collection
+const pinnedSuffix = ".pinned"
+
+// Pin protects a log file from garbage collection using a marker file next to the log file
+func (fs *FileLogStore) Pin(id string, pinned bool) error {
+	fn := filepath.Join(fs.Base, id+pinnedSuffix)
+	if !pinned {
+		err := os.Remove(fn)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	return ioutil.WriteFile(fn, nil, 0644)
+}
+
 // fileAge returns the age of a file
 func fileAge(stat fs.FileInfo) time.Time {
 	if lstat, ok := stat.Sys().(*unix.Stat_t); ok {
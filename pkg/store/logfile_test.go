@@ -94,3 +94,75 @@ func TestContinuousWriteReading(t *testing.T) {
 		t.Errorf("did not read message back, but: %s", string(actual))
 	}
 }
+
+func TestFileLogStoreDelete(t *testing.T) {
+	s, err := store.NewFileLogStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create test store: %v", err)
+	}
+
+	w, err := s.Open("foo")
+	if err != nil {
+		t.Fatalf("cannot place log: %v", err)
+	}
+	err = s.Delete("foo")
+	if err == nil {
+		t.Errorf("expected error when deleting a log open for writing")
+	}
+
+	w.Close()
+	err = s.Delete("foo")
+	if err != nil {
+		t.Fatalf("cannot delete log: %v", err)
+	}
+	_, err = s.Read("foo")
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for deleted log, got %v", err)
+	}
+
+	err = s.Delete("does-not-exist")
+	if err != nil {
+		t.Errorf("deleting an unknown log should not fail: %v", err)
+	}
+}
+
+func TestFileLogStorePin(t *testing.T) {
+	s, err := store.NewFileLogStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create test store: %v", err)
+	}
+	for _, id := range []string{"pinned", "unpinned"} {
+		w, err := s.Open(id)
+		if err != nil {
+			t.Fatalf("cannot place log: %v", err)
+		}
+		w.Close()
+	}
+
+	err = s.Pin("pinned", true)
+	if err != nil {
+		t.Fatalf("cannot pin log: %v", err)
+	}
+	err = s.GarbageCollect(0)
+	if err != nil {
+		t.Fatalf("cannot garbage collect: %v", err)
+	}
+	if _, err := s.Read("pinned"); err != nil {
+		t.Errorf("pinned log was garbage collected: %v", err)
+	}
+	if _, err := s.Read("unpinned"); err != store.ErrNotFound {
+		t.Errorf("expected unpinned log to be garbage collected, got %v", err)
+	}
+
+	err = s.Pin("pinned", false)
+	if err != nil {
+		t.Fatalf("cannot unpin log: %v", err)
+	}
+	err = s.GarbageCollect(0)
+	if err != nil {
+		t.Fatalf("cannot garbage collect: %v", err)
+	}
+	if _, err := s.Read("pinned"); err != store.ErrNotFound {
+		t.Errorf("expected log to be garbage collected after unpinning, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"sort"
 	"sync"
 	"time"
 
@@ -17,14 +18,16 @@ import (
 // NewInMemoryLogStore provides a new log store which stores its logs in memory
 func NewInMemoryLogStore() Logs {
 	return &inMemoryLogStore{
-		logs: make(map[string]*logSession),
+		logs:   make(map[string]*logSession),
+		pinned: make(map[string]struct{}),
 	}
 }
 
 // inMemoryLogStore implements a log store in memory
 type inMemoryLogStore struct {
-	logs map[string]*logSession
-	mu   sync.RWMutex
+	logs   map[string]*logSession
+	pinned map[string]struct{}
+	mu     sync.RWMutex
 }
 
 type logSession struct {
@@ -148,13 +151,16 @@ func (s *inMemoryLogStore) Read(id string) (io.ReadCloser, error) {
 }
 
 func (s *inMemoryLogStore) GarbageCollect(olderThan time.Duration) error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	for id, sess := range s.logs {
 		if time.Since(sess.StartedAt) <= olderThan {
 			continue
 		}
+		if _, pinned := s.pinned[id]; pinned {
+			continue
+		}
 		delete(s.logs, id)
 
 		sess.Close()
@@ -163,6 +169,35 @@ func (s *inMemoryLogStore) GarbageCollect(olderThan time.Duration) error {
 	return nil
 }
 
+// Delete removes the log of a single job
+func (s *inMemoryLogStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.pinned, id)
+	sess, ok := s.logs[id]
+	if !ok {
+		return nil
+	}
+	delete(s.logs, id)
+	sess.Close()
+
+	return nil
+}
+
+// Pin protects a log from garbage collection
+func (s *inMemoryLogStore) Pin(id string, pinned bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pinned {
+		s.pinned[id] = struct{}{}
+	} else {
+		delete(s.pinned, id)
+	}
+	return nil
+}
+
 type jobspec struct {
 	YAML []byte
 	Spec v1.JobSpec
@@ -189,6 +224,7 @@ func (s *inMemoryJobStore) Store(ctx context.Context, job v1.JobStatus) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	job.Pinned = s.jobs[job.Name].Pinned
 	s.jobs[job.Name] = job
 	return nil
 }
@@ -209,17 +245,82 @@ func (s *inMemoryJobStore) Get(ctx context.Context, name string) (*v1.JobStatus,
 
 // Searches for jobs based on their annotations
 func (s *inMemoryJobStore) Find(ctx context.Context, filter []*v1.FilterExpression, order []*v1.OrderExpression, start, limit int) (slice []v1.JobStatus, total int, err error) {
+	for _, o := range order {
+		if _, ok := indexedFields[o.Field]; !ok {
+			return nil, 0, xerrors.Errorf("unknown field %s", o.Field)
+		}
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var res []v1.JobStatus
+	var (
+		res    []v1.JobStatus
+		fields []map[string]string
+	)
 	for _, js := range s.jobs {
 		if !filterexpr.MatchesFilter(&js, filter) {
 			continue
 		}
 		res = append(res, js)
+		fields = append(fields, filterexpr.Fields(&js))
+	}
+
+	// we always order by name last to produce a stable order for paging
+	order = append(order[:len(order):len(order)], &v1.OrderExpression{Field: "name", Ascending: true})
+	idx := make([]int, len(res))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		a, b := fields[idx[i]], fields[idx[j]]
+		for _, o := range order {
+			c := filterexpr.CompareValues(a[o.Field], b[o.Field])
+			if c == 0 {
+				continue
+			}
+			if o.Ascending {
+				return c < 0
+			}
+			return c > 0
+		}
+		return false
+	})
+
+	total = len(res)
+	if start >= total {
+		return nil, total, nil
+	}
+	end := total
+	if limit > 0 && start+limit < end {
+		end = start + limit
 	}
-	return res, len(res), nil
+	slice = make([]v1.JobStatus, 0, end-start)
+	for _, i := range idx[start:end] {
+		slice = append(slice, res[i])
+	}
+	return slice, total, nil
+}
+
+// Statistics computes job statistics from the jobs in this store
+func (s *inMemoryJobStore) Statistics(ctx context.Context, filter []*v1.FilterExpression, from, to time.Time) ([]*v1.JobStatistics, error) {
+	return FindStatistics(ctx, s, filter, from, to)
+}
+
+// indexedFields are the fields jobs can be ordered by. These are the same fields the SQL stores index.
+var indexedFields = map[string]struct{}{
+	"name":       {},
+	"owner":      {},
+	"phase":      {},
+	"repo.owner": {},
+	"repo.repo":  {},
+	"repo.host":  {},
+	"repo.ref":   {},
+	"trigger":    {},
+	"success":    {},
+	"created":    {},
+	"finished":   {},
+	"pinned":     {},
 }
 
 func (s *inMemoryJobStore) StoreJobSpec(name string, spec v1.JobSpec, data []byte) error {
@@ -245,15 +346,15 @@ func (s *inMemoryJobStore) GetJobSpec(name string) (spec *v1.JobSpec, data []byt
 }
 
 func (s *inMemoryJobStore) GarbageCollect(olderThan time.Duration) error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	for id, job := range s.jobs {
 		t, err := ptypes.Timestamp(job.Metadata.Created)
 		if err != nil {
 			continue
 		}
-		if job.Phase != v1.JobPhase_PHASE_DONE {
+		if job.Phase != v1.JobPhase_PHASE_DONE || job.Pinned {
 			continue
 		}
 		if time.Since(t) <= olderThan {
@@ -264,3 +365,147 @@ func (s *inMemoryJobStore) GarbageCollect(olderThan time.Duration) error {
 
 	return nil
 }
+
+// Delete removes a single job
+func (s *inMemoryJobStore) Delete(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.jobs, name)
+	return nil
+}
+
+// Pin sets the pinned flag of a job
+func (s *inMemoryJobStore) Pin(ctx context.Context, name string, pinned bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return ErrNotFound
+	}
+	job.Pinned = pinned
+	s.jobs[name] = job
+	return nil
+}
+
+// NewInMemoryScheduleStore creates a new in-memory schedule store
+func NewInMemoryScheduleStore() Schedules {
+	return &inMemoryScheduleStore{
+		schedules: make(map[string]v1.Schedule),
+	}
+}
+
+type inMemoryScheduleStore struct {
+	schedules map[string]v1.Schedule
+	mu        sync.RWMutex
+}
+
+// Create stores a new schedule
+func (s *inMemoryScheduleStore) Create(ctx context.Context, schedule v1.Schedule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.schedules[schedule.Name]; exists {
+		return ErrAlreadyExists
+	}
+	s.schedules[schedule.Name] = schedule
+	return nil
+}
+
+// Get retrieves a schedule
+func (s *inMemoryScheduleStore) Get(ctx context.Context, name string) (*v1.Schedule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	schedule, ok := s.schedules[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &schedule, nil
+}
+
+// List returns all schedules ordered by their name
+func (s *inMemoryScheduleStore) List(ctx context.Context) ([]*v1.Schedule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]*v1.Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedule := schedule
+		res = append(res, &schedule)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res, nil
+}
+
+// Delete removes a schedule
+func (s *inMemoryScheduleStore) Delete(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.schedules[name]; !exists {
+		return ErrNotFound
+	}
+	delete(s.schedules, name)
+	return nil
+}
+
+// Pause sets the paused flag and the next run of a schedule
+func (s *inMemoryScheduleStore) Pause(ctx context.Context, name string, paused bool, nextRun time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, ok := s.schedules[name]
+	if !ok {
+		return ErrNotFound
+	}
+	next, err := ptypes.TimestampProto(nextRun.Truncate(time.Second))
+	if err != nil {
+		return err
+	}
+	schedule.Paused = paused
+	schedule.NextRun = next
+	s.schedules[name] = schedule
+	return nil
+}
+
+// Claim takes the run of a schedule which is due at the given time
+func (s *inMemoryScheduleStore) Claim(ctx context.Context, name string, due, nextRun time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, ok := s.schedules[name]
+	if !ok || schedule.Paused || schedule.NextRun == nil || schedule.NextRun.Seconds != due.Unix() {
+		return false, nil
+	}
+	next, err := ptypes.TimestampProto(nextRun.Truncate(time.Second))
+	if err != nil {
+		return false, err
+	}
+	last, err := ptypes.TimestampProto(due.Truncate(time.Second))
+	if err != nil {
+		return false, err
+	}
+	schedule.NextRun = next
+	schedule.LastRun = last
+	schedule.LastJob = ""
+	schedule.LastError = ""
+	s.schedules[name] = schedule
+	return true, nil
+}
+
+// RecordRun records the job the last run started
+func (s *inMemoryScheduleStore) RecordRun(ctx context.Context, name, job, errMsg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, ok := s.schedules[name]
+	if !ok {
+		return ErrNotFound
+	}
+	schedule.LastJob = job
+	schedule.LastError = errMsg
+	s.schedules[name] = schedule
+	return nil
+}
@@ -0,0 +1,20 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/csweichel/werft/pkg/store/storetest"
+)
+
+func TestInMemoryJobStore(t *testing.T) {
+	storetest.RunJobsTests(t, func(t *testing.T) store.Jobs {
+		return store.NewInMemoryJobStore()
+	})
+}
+
+func TestInMemoryScheduleStore(t *testing.T) {
+	storetest.RunSchedulesTests(t, func(t *testing.T) store.Schedules {
+		return store.NewInMemoryScheduleStore()
+	})
+}
@@ -9,13 +9,20 @@ import (
 
 	v1 "github.com/csweichel/werft/pkg/api/v1"
 	"github.com/csweichel/werft/pkg/store"
+	"github.com/csweichel/werft/pkg/store/sqlstore"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
-	"golang.org/x/xerrors"
 )
 
+// dialect describes the SQL of Postgres to the shared SQL stores
+var dialect = sqlstore.Dialect{
+	Placeholders:  sqlstore.NumberPlaceholders,
+	RegexOperator: "~",
+	JSONTerm:      jsonTerm,
+}
+
 // JobStore stores jobs in a Postgres database
 type JobStore struct {
 	DB *sql.DB
@@ -63,20 +70,61 @@ func (s *JobStore) RegisterPrometheusMetrics(reg prometheus.Registerer) {
 
 // GarbageCollect removes all job entries older than the specified duration
 func (s *JobStore) GarbageCollect(olderThan time.Duration) error {
-	_, err := s.DB.Query(`
+	_, err := s.DB.Exec(`
 		DELETE FROM job_status 
 		WHERE created <= $1
 		  AND phase = 'done'
+		  AND NOT pinned
 	`, time.Now().Add(-olderThan).Unix())
 	return err
 }
 
+// Delete removes a single job and its annotations
+func (s *JobStore) Delete(ctx context.Context, name string) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		DELETE FROM annotations
+		WHERE job_id IN (SELECT id FROM job_status WHERE name = $1)
+	`, name)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM job_status WHERE name = $1", name)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Pin sets the pinned flag of a job
+func (s *JobStore) Pin(ctx context.Context, name string, pinned bool) error {
+	res, err := s.DB.ExecContext(ctx, "UPDATE job_status SET pinned = $2 WHERE name = $1", name, pinned)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
 // Store stores job information in the store.
 func (s *JobStore) Store(ctx context.Context, job v1.JobStatus) error {
 	defer func(start time.Time) {
 		s.metrics.PostgresStoreJobDurationSecond.Observe(time.Since(start).Seconds())
 	}(time.Now())
 
+	// the pinned flag is maintained using Pin and must not be part of the data
+	job.Pinned = false
 	marshaler := &jsonpb.Marshaler{
 		EnumsAsInts: true,
 	}
@@ -94,13 +142,18 @@ func (s *JobStore) Store(ctx context.Context, job v1.JobStatus) error {
 	if err != nil {
 		return err
 	}
+	var finished sql.NullInt64
+	if job.Metadata.Finished != nil {
+		finished = sql.NullInt64{Int64: job.Metadata.Finished.Seconds, Valid: true}
+	}
+
 	var jobID int
 	err = tx.QueryRow(`
 		INSERT
-		INTO   job_status (name, data, owner, phase, repo_owner, repo_repo, repo_host, repo_ref, trigger_src, success, created)
-		VALUES            ($1  , $2  , $3   , $4   , $5        , $6       , $7       , $8      , $9         , $10,     $11    ) 
+		INTO   job_status (name, data, owner, phase, repo_owner, repo_repo, repo_host, repo_ref, trigger_src, success, created, finished, job_spec_name)
+		VALUES            ($1  , $2  , $3   , $4   , $5        , $6       , $7       , $8      , $9         , $10,     $11    , $12     , $13          ) 
 		ON CONFLICT (name) DO UPDATE 
-			SET data = $2, owner = $3, phase = $4, repo_owner = $5, repo_repo = $6, repo_host = $7, repo_ref = $8, trigger_src = $9, success = $10, created = $11
+			SET data = $2, owner = $3, phase = $4, repo_owner = $5, repo_repo = $6, repo_host = $7, repo_ref = $8, trigger_src = $9, success = $10, created = $11, finished = $12, job_spec_name = $13
 		RETURNING id`,
 		job.Name,
 		serializedJob,
@@ -110,9 +163,11 @@ func (s *JobStore) Store(ctx context.Context, job v1.JobStatus) error {
 		job.Metadata.Repository.Repo,
 		job.Metadata.Repository.Host,
 		job.Metadata.Repository.Ref,
-		strings.ToLower(strings.TrimPrefix("TRIGGER_", job.Metadata.Trigger.String())),
+		strings.ToLower(strings.TrimPrefix(job.Metadata.Trigger.String(), "TRIGGER_")),
 		success,
 		job.Metadata.Created.Seconds,
+		finished,
+		job.Metadata.JobSpecName,
 	).Scan(&jobID)
 	if err != nil {
 		tx.Rollback()
@@ -142,8 +197,11 @@ func (s *JobStore) Store(ctx context.Context, job v1.JobStatus) error {
 
 // Get retrieves a particular job bassd on its name.
 func (s *JobStore) Get(ctx context.Context, name string) (*v1.JobStatus, error) {
-	var data string
-	err := s.DB.QueryRow("SELECT data FROM job_status WHERE name = $1", name).Scan(&data)
+	var (
+		data   string
+		pinned bool
+	)
+	err := s.DB.QueryRow("SELECT data, pinned FROM job_status WHERE name = $1", name).Scan(&data, &pinned)
 	if err == sql.ErrNoRows {
 		return nil, store.ErrNotFound
 	}
@@ -157,94 +215,20 @@ func (s *JobStore) Get(ctx context.Context, name string) (*v1.JobStatus, error)
 		return nil, err
 	}
 
+	res.Pinned = pinned
+
 	return &res, nil
 }
 
 // Find searches for jobs based on their annotations. If filter is empty no filter is applied.
 func (s *JobStore) Find(ctx context.Context, filter []*v1.FilterExpression, order []*v1.OrderExpression, start, limit int) (slice []v1.JobStatus, total int, err error) {
-	fieldMap := map[string]string{
-		"name":       "name",
-		"owner":      "owner",
-		"phase":      "phase",
-		"repo.owner": "repo_owner",
-		"repo.repo":  "repo_repo",
-		"repo.host":  "repo_host",
-		"repo.ref":   "repo_ref",
-		"trigger":    "trigger",
-		"success":    "success",
-		"created":    "created",
-	}
-
-	var (
-		whereExps []string
-		args      []interface{}
-	)
-	for _, f := range filter {
-		if len(f.Terms) == 0 {
-			continue
-		}
-
-		var terms []string
-		for _, t := range f.Terms {
-			var not string
-			if t.Negate {
-				not = "NOT"
-			}
-
-			field, ok := fieldMap[t.Field]
-			if !ok {
-				return nil, 0, xerrors.Errorf("unknown field %s", t.Field)
-			}
-
-			var op string
-			switch t.Operation {
-			case v1.FilterOp_OP_CONTAINS:
-				op = "LIKE '%' || ? || '%'"
-			case v1.FilterOp_OP_ENDS_WITH:
-				op = "LIKE '%' || ?"
-			case v1.FilterOp_OP_EQUALS:
-				op = "= ?"
-			case v1.FilterOp_OP_STARTS_WITH:
-				op = "LIKE ? || '%'"
-			case v1.FilterOp_OP_EXISTS:
-				op = "IS NOT NULL"
-			default:
-				return nil, 0, xerrors.Errorf("unknown operation %v", t.Operation)
-			}
-			expr := fmt.Sprintf("%s %s %s", not, field, op)
-			terms = append(terms, expr)
-			args = append(args, t.Value)
-		}
-
-		expr := fmt.Sprintf("(%s)", strings.Join(terms, " OR "))
-		whereExps = append(whereExps, expr)
-	}
-	whereExp := strings.Join(whereExps, " AND ")
-	if whereExp != "" {
-		whereExp = "WHERE " + whereExp
-		prev := ""
-		for i := 1; prev != whereExp; i++ {
-			prev = whereExp
-			whereExp = strings.Replace(whereExp, "?", fmt.Sprintf("$%d", i), 1)
-		}
-	}
-
-	var orderExps []string
-	for _, o := range order {
-		field, ok := fieldMap[o.Field]
-		if !ok {
-			return nil, 0, xerrors.Errorf("unknown field %s", o.Field)
-		}
-
-		dir := "DESC"
-		if o.Ascending {
-			dir = "ASC"
-		}
-		orderExps = append(orderExps, fmt.Sprintf("%s %s", field, dir))
+	whereExp, args, err := dialect.WhereExpression(filter)
+	if err != nil {
+		return nil, 0, err
 	}
-	var orderExp string
-	if len(orderExps) > 0 {
-		orderExp = fmt.Sprintf("ORDER BY %s", strings.Join(orderExps, ", "))
+	orderExp, err := sqlstore.OrderExpression(order)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	limitExp := "ALL"
@@ -259,7 +243,7 @@ func (s *JobStore) Find(ctx context.Context, filter []*v1.FilterExpression, orde
 		return nil, 0, err
 	}
 
-	query := fmt.Sprintf("SELECT data FROM job_status %s %s LIMIT %s OFFSET %d", whereExp, orderExp, limitExp, start)
+	query := fmt.Sprintf("SELECT data, pinned FROM job_status %s %s LIMIT %s OFFSET %d", whereExp, orderExp, limitExp, start)
 	log.WithField("query", query).Debug("running query")
 	rows, err := s.DB.Query(query, args...)
 	if err != nil {
@@ -269,8 +253,11 @@ func (s *JobStore) Find(ctx context.Context, filter []*v1.FilterExpression, orde
 
 	var result []v1.JobStatus
 	for rows.Next() {
-		var data string
-		err = rows.Scan(&data)
+		var (
+			data   string
+			pinned bool
+		)
+		err = rows.Scan(&data, &pinned)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -280,16 +267,85 @@ func (s *JobStore) Find(ctx context.Context, filter []*v1.FilterExpression, orde
 		if err != nil {
 			return nil, 0, err
 		}
+		res.Pinned = pinned
 
 		result = append(result, res)
 	}
-	if rows.Err() != nil {
+	if err := rows.Err(); err != nil {
 		return nil, 0, err
 	}
 
 	return result, total, nil
 }
 
+// Statistics computes job statistics using Postgres' aggregate functions
+func (s *JobStore) Statistics(ctx context.Context, filter []*v1.FilterExpression, from, to time.Time) ([]*v1.JobStatistics, error) {
+	whereExp, args, err := dialect.WhereExpression(store.StatisticsFilter(filter, from, to))
+	if err != nil {
+		return nil, err
+	}
+
+	// recoveries are the time between the first failure in a sequence of failures, and the next successful job.
+	query := fmt.Sprintf(`
+		WITH jobs AS (
+			SELECT repo_host, repo_owner, repo_repo, repo_ref, COALESCE(job_spec_name, '') AS job_spec_name, success, created, finished,
+			       LAG(success) OVER (PARTITION BY repo_host, repo_owner, repo_repo, repo_ref, COALESCE(job_spec_name, '') ORDER BY created) AS prev_success
+			FROM   job_status
+			%s
+		), recoveries AS (
+			SELECT f.repo_host, f.repo_owner, f.repo_repo, f.repo_ref, f.job_spec_name,
+			       (SELECT MIN(s.finished)
+			        FROM   jobs s
+			        WHERE  s.repo_host = f.repo_host AND s.repo_owner = f.repo_owner AND s.repo_repo = f.repo_repo AND s.repo_ref = f.repo_ref AND s.job_spec_name = f.job_spec_name
+			          AND  s.success = 1
+			          AND  s.created > f.created
+			       ) - f.finished AS recovery
+			FROM   jobs f
+			WHERE  f.success = 0 AND (f.prev_success IS NULL OR f.prev_success = 1)
+		)
+		SELECT j.repo_host, j.repo_owner, j.repo_repo, j.repo_ref, j.job_spec_name,
+		       COUNT(1),
+		       SUM(j.success),
+		       COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY j.finished - j.created) FILTER (WHERE j.finished IS NOT NULL), 0),
+		       COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY j.finished - j.created) FILTER (WHERE j.finished IS NOT NULL), 0),
+		       (SELECT COALESCE(AVG(r.recovery), 0) FROM recoveries r WHERE r.repo_host = j.repo_host AND r.repo_owner = j.repo_owner AND r.repo_repo = j.repo_repo AND r.repo_ref = j.repo_ref AND r.job_spec_name = j.job_spec_name AND r.recovery IS NOT NULL),
+		       (SELECT COUNT(r.recovery)           FROM recoveries r WHERE r.repo_host = j.repo_host AND r.repo_owner = j.repo_owner AND r.repo_repo = j.repo_repo AND r.repo_ref = j.repo_ref AND r.job_spec_name = j.job_spec_name)
+		FROM   jobs j
+		GROUP BY j.repo_host, j.repo_owner, j.repo_repo, j.repo_ref, j.job_spec_name
+		ORDER BY j.repo_host, j.repo_owner, j.repo_repo, j.repo_ref, j.job_spec_name
+	`, whereExp)
+	log.WithField("query", query).Debug("running query")
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*v1.JobStatistics
+	for rows.Next() {
+		stats := &v1.JobStatistics{Repository: &v1.Repository{}}
+		err = rows.Scan(
+			&stats.Repository.Host, &stats.Repository.Owner, &stats.Repository.Repo, &stats.Repository.Ref, &stats.JobSpecName,
+			&stats.RunCount,
+			&stats.SuccessCount,
+			&stats.DurationP50Seconds,
+			&stats.DurationP95Seconds,
+			&stats.MttrSeconds,
+			&stats.RecoveryCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		stats.SuccessRate = float64(stats.SuccessCount) / float64(stats.RunCount)
+		res = append(res, stats)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // StoreJobSpec stores job information in the store.
 func (s *JobStore) StoreJobSpec(name string, spec v1.JobSpec, data []byte) error {
 	rawSpec, err := proto.Marshal(&spec)
@@ -0,0 +1,59 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/csweichel/werft/pkg/store/postgres"
+	"github.com/csweichel/werft/pkg/store/storetest"
+
+	_ "github.com/lib/pq"
+)
+
+// testDB connects to the Postgres database configured using WERFT_TEST_POSTGRES,
+// e.g. WERFT_TEST_POSTGRES="host=localhost user=postgres password=postgres dbname=werft_test sslmode=disable".
+// All tables in that database are truncated prior to each test.
+func testDB(t *testing.T) *sql.DB {
+	dsn := os.Getenv("WERFT_TEST_POSTGRES")
+	if dsn == "" {
+		t.Skip("WERFT_TEST_POSTGRES is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("cannot connect to test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	err = postgres.Migrate(db)
+	if err != nil {
+		t.Fatalf("cannot migrate test database: %v", err)
+	}
+	_, err = db.Exec("TRUNCATE job_status, annotations, job_spec, number_group, schedule")
+	if err != nil {
+		t.Fatalf("cannot clean test database: %v", err)
+	}
+	return db
+}
+
+func TestJobStore(t *testing.T) {
+	storetest.RunJobsTests(t, func(t *testing.T) store.Jobs {
+		s, err := postgres.NewJobStore(testDB(t))
+		if err != nil {
+			t.Fatalf("cannot create job store: %v", err)
+		}
+		return s
+	})
+}
+
+func TestScheduleStore(t *testing.T) {
+	storetest.RunSchedulesTests(t, func(t *testing.T) store.Schedules {
+		s, err := postgres.NewScheduleStore(testDB(t))
+		if err != nil {
+			t.Fatalf("cannot create schedule store: %v", err)
+		}
+		return s
+	})
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/filterexpr"
+	"github.com/csweichel/werft/pkg/store/sqlstore"
+	"golang.org/x/xerrors"
+)
+
+const annotationPrefix = "annotation."
+
+// jsonTerm translates a filter term on a field which is not promoted to a column into a query on the data column.
+// Equality checks use JSONB containment and other operations use JSON path queries - both are served by the GIN
+// index on data. The returned expression uses ? placeholders and does not honour t.Negate.
+func jsonTerm(d sqlstore.Dialect, t *v1.FilterTerm) (expr string, args []interface{}, err error) {
+	if strings.HasPrefix(t.Field, annotationPrefix) {
+		return annotationTerm(strings.TrimPrefix(t.Field, annotationPrefix), t)
+	}
+
+	fp, err := filterexpr.ResolveField(t.Field)
+	if err != nil {
+		return "", nil, err
+	}
+
+	val := t.Value
+	if t.Operation != v1.FilterOp_OP_EXISTS {
+		val, err = fp.NormaliseValue(val)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	if !fp.IsRepeated() {
+		return scalarTerm(d, fp, t.Operation, val)
+	}
+
+	if t.Operation == v1.FilterOp_OP_EQUALS && val != fp.ZeroValue() {
+		doc, err := containmentDoc(fp.Segments, fp.Repeated, jsonValue(fp, val))
+		if err != nil {
+			return "", nil, err
+		}
+		return "data @> ?::jsonb", []interface{}{doc}, nil
+	}
+
+	var path strings.Builder
+	path.WriteString("$")
+	for i, seg := range fp.Segments {
+		fmt.Fprintf(&path, ".%s", jsonPathString(seg))
+		if fp.Repeated[i] {
+			path.WriteString("[*]")
+		}
+	}
+	if t.Operation != v1.FilterOp_OP_EXISTS {
+		cond, err := jsonPathCondition(t.Operation, val, fp.IsJSONString())
+		if err != nil {
+			return "", nil, err
+		}
+		fmt.Fprintf(&path, " ? (%s)", cond)
+	}
+	return "data @? ?::jsonpath", []interface{}{path.String()}, nil
+}
+
+// scalarTerm produces an expression on a field which is not part of a list. Protobuf's JSON encoding omits
+// fields with their zero value, hence missing fields are treated as having their zero value.
+func scalarTerm(d sqlstore.Dialect, fp *filterexpr.FieldPath, op v1.FilterOp, val string) (expr string, args []interface{}, err error) {
+	if op == v1.FilterOp_OP_EXISTS {
+		return "TRUE", nil, nil
+	}
+	if op == v1.FilterOp_OP_EQUALS && val != fp.ZeroValue() {
+		doc, err := containmentDoc(fp.Segments, fp.Repeated, jsonValue(fp, val))
+		if err != nil {
+			return "", nil, err
+		}
+		return "data @> ?::jsonb", []interface{}{doc}, nil
+	}
+
+	path := fmt.Sprintf("data #>> '{%s}'", strings.Join(fp.Segments, ","))
+	if fp.IsNumeric() && (op == v1.FilterOp_OP_LESS_THAN || op == v1.FilterOp_OP_GREATER_THAN) {
+		cmp := "<"
+		if op == v1.FilterOp_OP_GREATER_THAN {
+			cmp = ">"
+		}
+		return fmt.Sprintf("COALESCE((%s)::numeric, 0) %s ?::numeric", path, cmp), []interface{}{val}, nil
+	}
+
+	op2sql, val, err := d.Operation(op, val)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("COALESCE(%s, ?) %s", path, op2sql), []interface{}{fp.ZeroValue(), val}, nil
+}
+
+// annotationTerm produces an expression on the value of the annotation with the given key
+func annotationTerm(key string, t *v1.FilterTerm) (expr string, args []interface{}, err error) {
+	if t.Operation == v1.FilterOp_OP_EQUALS {
+		doc, err := containmentDoc([]string{"metadata", "annotations"}, []bool{false, true}, map[string]string{"key": key, "value": t.Value})
+		if err != nil {
+			return "", nil, err
+		}
+		return "data @> ?::jsonb", []interface{}{doc}, nil
+	}
+
+	path := fmt.Sprintf("$.metadata.annotations[*] ? (@.key == %s)", jsonPathString(key))
+	if t.Operation != v1.FilterOp_OP_EXISTS {
+		cond, err := jsonPathCondition(t.Operation, t.Value, true)
+		if err != nil {
+			return "", nil, err
+		}
+		path += fmt.Sprintf(".value ? (%s)", cond)
+	}
+	return "data @? ?::jsonpath", []interface{}{path}, nil
+}
+
+// containmentDoc produces a JSON document for use with the @> operator, e.g. {"results":[{"type":"url"}]}
+func containmentDoc(segments []string, repeated []bool, value interface{}) (string, error) {
+	for i := len(segments) - 1; i >= 0; i-- {
+		if repeated[i] {
+			value = []interface{}{value}
+		}
+		value = map[string]interface{}{segments[i]: value}
+	}
+	res, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// jsonValue converts a normalised value to the type protobuf's JSON encoding uses for the field
+func jsonValue(fp *filterexpr.FieldPath, val string) interface{} {
+	switch {
+	case fp.IsJSONString():
+		return val
+	case fp.IsBool():
+		return val == "true"
+	default:
+		return json.Number(val)
+	}
+}
+
+// jsonPathString quotes a string for use in a JSON path expression
+func jsonPathString(val string) string {
+	res, _ := json.Marshal(val)
+	return string(res)
+}
+
+// jsonPathCondition produces a JSON path filter condition on the current item (@). If quote is true,
+// val is compared as string, otherwise val must be a valid JSON path literal, e.g. a number or bool.
+func jsonPathCondition(op v1.FilterOp, val string, quote bool) (string, error) {
+	literal := val
+	if quote {
+		literal = jsonPathString(val)
+	}
+	switch op {
+	case v1.FilterOp_OP_EQUALS:
+		return fmt.Sprintf("@ == %s", literal), nil
+	case v1.FilterOp_OP_LESS_THAN:
+		return fmt.Sprintf("@ < %s", literal), nil
+	case v1.FilterOp_OP_GREATER_THAN:
+		return fmt.Sprintf("@ > %s", literal), nil
+	case v1.FilterOp_OP_STARTS_WITH:
+		return fmt.Sprintf("@ starts with %s", literal), nil
+	}
+
+	// like_regex only accepts string literals as pattern, hence all other operations are translated to regular expressions
+	var pattern string
+	switch op {
+	case v1.FilterOp_OP_CONTAINS:
+		pattern = regexp.QuoteMeta(val)
+	case v1.FilterOp_OP_ENDS_WITH:
+		pattern = regexp.QuoteMeta(val) + "$"
+	case v1.FilterOp_OP_REGEX:
+		pattern = val
+	case v1.FilterOp_OP_GLOB:
+		pattern = filterexpr.GlobToRegexp(val).String()
+	default:
+		return "", xerrors.Errorf("unknown operation %v", op)
+	}
+	return fmt.Sprintf("@ like_regex %s", jsonPathString(pattern)), nil
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+)
+
+func TestWhereExpression(t *testing.T) {
+	term := func(field, value string, op v1.FilterOp, negate bool) []*v1.FilterExpression {
+		return []*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: field, Value: value, Operation: op, Negate: negate}}}}
+	}
+	tests := []struct {
+		Name   string
+		Filter []*v1.FilterExpression
+		Where  string
+		Args   []interface{}
+		Error  bool
+	}{
+		{
+			Name:   "column",
+			Filter: term("phase", "done", v1.FilterOp_OP_EQUALS, false),
+			Where:  "WHERE ( (phase = $1))",
+			Args:   []interface{}{"done"},
+		},
+		{
+			Name:   "list containment",
+			Filter: term("results.type", "url", v1.FilterOp_OP_EQUALS, false),
+			Where:  "WHERE ( (data @> $1::jsonb))",
+			Args:   []interface{}{`{"results":[{"type":"url"}]}`},
+		},
+		{
+			Name:   "list path",
+			Filter: term("results.type", "do", v1.FilterOp_OP_STARTS_WITH, true),
+			Where:  "WHERE (NOT (data @? $1::jsonpath))",
+			Args:   []interface{}{`$."results"[*]."type" ? (@ starts with "do")`},
+		},
+		{
+			Name:   "list glob",
+			Filter: term("results.channels", "git*", v1.FilterOp_OP_GLOB, false),
+			Where:  "WHERE ( (data @? $1::jsonpath))",
+			Args:   []interface{}{`$."results"[*]."channels"[*] ? (@ like_regex "^git.*$")`},
+		},
+		{
+			Name:   "scalar containment",
+			Filter: term("conditions.did_execute", "true", v1.FilterOp_OP_EQUALS, false),
+			Where:  "WHERE ( (data @> $1::jsonb))",
+			Args:   []interface{}{`{"conditions":{"didExecute":true}}`},
+		},
+		{
+			Name:   "scalar zero value",
+			Filter: term("conditions.did_execute", "false", v1.FilterOp_OP_EQUALS, false),
+			Where:  "WHERE ( (COALESCE(data #>> '{conditions,didExecute}', $1) = $2))",
+			Args:   []interface{}{"false", "false"},
+		},
+		{
+			Name:   "scalar numeric",
+			Filter: term("conditions.failure_count", "2", v1.FilterOp_OP_GREATER_THAN, false),
+			Where:  "WHERE ( (COALESCE((data #>> '{conditions,failureCount}')::numeric, 0) > $1::numeric))",
+			Args:   []interface{}{"2"},
+		},
+		{
+			Name:   "enum by name",
+			Filter: term("metadata.trigger", "deleted", v1.FilterOp_OP_EQUALS, false),
+			Where:  "WHERE ( (data @> $1::jsonb))",
+			Args:   []interface{}{fmt.Sprintf(`{"metadata":{"trigger":%d}}`, v1.JobTrigger_TRIGGER_DELETED)},
+		},
+		{
+			Name:   "annotation",
+			Filter: term("annotation.foo", "bar", v1.FilterOp_OP_CONTAINS, false),
+			Where:  "WHERE ( (data @? $1::jsonpath))",
+			Args:   []interface{}{`$.metadata.annotations[*] ? (@.key == "foo").value ? (@ like_regex "bar")`},
+		},
+		{
+			Name:   "unknown field",
+			Filter: term("doesnotexist", "foo", v1.FilterOp_OP_EQUALS, false),
+			Error:  true,
+		},
+		{
+			Name:   "invalid value",
+			Filter: term("conditions.failure_count", "foo", v1.FilterOp_OP_EQUALS, false),
+			Error:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			where, args, err := dialect.WhereExpression(test.Filter)
+			if test.Error {
+				if err == nil {
+					t.Errorf("expected error, got %s", where)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if where != test.Where {
+				t.Errorf("unexpected where clause:\n\texpected %s\n\tgot      %s", test.Where, where)
+			}
+			if fmt.Sprint(args) != fmt.Sprint(test.Args) {
+				t.Errorf("unexpected args:\n\texpected %v\n\tgot      %v", test.Args, args)
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+-- before the trigger was stored by name, every job was stored with this value
+UPDATE job_status SET trigger_src = 'trigger_';
@@ -0,0 +1,2 @@
+ALTER TABLE job_status DROP COLUMN finished;
+ALTER TABLE job_status DROP COLUMN job_spec_name;
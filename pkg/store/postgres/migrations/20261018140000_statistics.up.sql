@@ -0,0 +1,9 @@
+ALTER TABLE job_status ADD COLUMN finished int NULL;
+ALTER TABLE job_status ADD COLUMN job_spec_name varchar(255) NULL;
+
+UPDATE job_status SET
+    finished = EXTRACT(EPOCH FROM (data::jsonb->'metadata'->>'finished')::timestamptz)::int,
+    job_spec_name = data::jsonb->'metadata'->>'jobSpecName';
+
+CREATE INDEX idx_job_status_finished ON job_status(finished);
+CREATE INDEX idx_job_status_job_spec_name ON job_status(job_spec_name);
@@ -0,0 +1,3 @@
+DROP INDEX idx_job_status_data;
+
+ALTER TABLE job_status ALTER COLUMN data TYPE text USING data::text;
@@ -0,0 +1,3 @@
+ALTER TABLE job_status ALTER COLUMN data TYPE jsonb USING data::jsonb;
+
+CREATE INDEX idx_job_status_data ON job_status USING GIN (data jsonb_path_ops);
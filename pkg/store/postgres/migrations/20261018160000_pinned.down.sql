@@ -0,0 +1 @@
+ALTER TABLE job_status DROP COLUMN pinned;
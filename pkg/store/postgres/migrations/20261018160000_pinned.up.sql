@@ -0,0 +1 @@
+ALTER TABLE job_status ADD COLUMN pinned boolean NOT NULL DEFAULT false;
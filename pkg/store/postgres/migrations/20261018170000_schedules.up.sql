@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS schedule (
+	name varchar(255) NOT NULL PRIMARY KEY,
+	data jsonb NOT NULL,
+	paused boolean NOT NULL DEFAULT false,
+	next_run bigint NOT NULL,
+	last_run bigint NULL,
+	last_job varchar(255) NOT NULL DEFAULT '',
+	last_error text NOT NULL DEFAULT ''
+);
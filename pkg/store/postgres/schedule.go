@@ -0,0 +1,12 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/csweichel/werft/pkg/store/sqlstore"
+)
+
+// NewScheduleStore creates a new Postgres schedule store
+func NewScheduleStore(db *sql.DB) (*sqlstore.ScheduleStore, error) {
+	return &sqlstore.ScheduleStore{DB: db, Dialect: dialect}, nil
+}
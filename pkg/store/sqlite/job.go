@@ -0,0 +1,361 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/csweichel/werft/pkg/store/sqlstore"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"modernc.org/sqlite"
+)
+
+func init() {
+	// SQLite supports the REGEXP operator, but does not ship an implementation for it.
+	sqlite.MustRegisterDeterministicScalarFunction("regexp", 2, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		pattern, _ := args[0].(string)
+		value, _ := args[1].(string)
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString(value), nil
+	})
+}
+
+// Open opens an SQLite database file with the settings the job store requires
+func Open(fn string) (*sql.DB, error) {
+	q := url.Values{}
+	q.Add("_pragma", "busy_timeout(10000)")
+	q.Add("_pragma", "journal_mode(WAL)")
+	// LIKE is case-sensitive in Postgres and our in-memory filter - SQLite must behave the same
+	q.Add("_pragma", "case_sensitive_like(1)")
+
+	db, err := sql.Open("sqlite", fmt.Sprintf("%s?%s", fn, q.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// dialect describes the SQL of SQLite to the shared SQL stores
+var dialect = sqlstore.Dialect{
+	Placeholders:  sqlstore.NoPlaceholders,
+	RegexOperator: "REGEXP",
+	JSONTerm:      jsonTerm,
+}
+
+// JobStore stores jobs in an SQLite database
+type JobStore struct {
+	DB *sql.DB
+
+	metrics struct {
+		SQLiteStoreJobDurationSecond prometheus.Histogram
+	}
+}
+
+// NewJobStore creates a new SQLite job store
+func NewJobStore(db *sql.DB) (*JobStore, error) {
+	res := &JobStore{DB: db}
+	res.metrics.SQLiteStoreJobDurationSecond = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "job_store_store_duration_second",
+		Help:    "Time it takes to store a job status",
+		Buckets: prometheus.ExponentialBuckets(0.001, 10, 4),
+	})
+	return res, nil
+}
+
+var _ store.Jobs = &JobStore{}
+
+// RegisterPrometheusMetrics registers metrics on the registerer with MustRegister
+func (s *JobStore) RegisterPrometheusMetrics(reg prometheus.Registerer) {
+	reg.MustRegister(
+		s.metrics.SQLiteStoreJobDurationSecond,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "job_store_db_open_connections_total",
+			Help: "Open database connections of the job store.",
+		}, func() float64 { return float64(s.DB.Stats().OpenConnections) }),
+	)
+}
+
+// GarbageCollect removes all job entries older than the specified duration
+func (s *JobStore) GarbageCollect(olderThan time.Duration) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		DELETE FROM annotations
+		WHERE job_id IN (SELECT id FROM job_status WHERE created <= ? AND phase = 'done' AND NOT pinned)
+	`, time.Now().Add(-olderThan).Unix())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Exec(`
+		DELETE FROM job_status
+		WHERE created <= ?
+		  AND phase = 'done'
+		  AND NOT pinned
+	`, time.Now().Add(-olderThan).Unix())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Delete removes a single job and its annotations
+func (s *JobStore) Delete(ctx context.Context, name string) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		DELETE FROM annotations
+		WHERE job_id IN (SELECT id FROM job_status WHERE name = ?)
+	`, name)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM job_status WHERE name = ?", name)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Pin sets the pinned flag of a job
+func (s *JobStore) Pin(ctx context.Context, name string, pinned bool) error {
+	res, err := s.DB.ExecContext(ctx, "UPDATE job_status SET pinned = ? WHERE name = ?", pinned, name)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// Store stores job information in the store.
+func (s *JobStore) Store(ctx context.Context, job v1.JobStatus) error {
+	defer func(start time.Time) {
+		s.metrics.SQLiteStoreJobDurationSecond.Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	// the pinned flag is maintained using Pin and must not be part of the data
+	job.Pinned = false
+	marshaler := &jsonpb.Marshaler{
+		EnumsAsInts: true,
+	}
+	serializedJob, err := marshaler.MarshalToString(&job)
+	if err != nil {
+		return err
+	}
+
+	success := 0
+	if job.Conditions.Success {
+		success = 1
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	var finished sql.NullInt64
+	if job.Metadata.Finished != nil {
+		finished = sql.NullInt64{Int64: job.Metadata.Finished.Seconds, Valid: true}
+	}
+
+	var jobID int
+	err = tx.QueryRow(`
+		INSERT
+		INTO   job_status (name, data, owner, phase, repo_owner, repo_repo, repo_host, repo_ref, trigger_src, success, created, finished, job_spec_name)
+		VALUES            ($1  , $2  , $3   , $4   , $5        , $6       , $7       , $8      , $9         , $10,     $11    , $12     , $13          )
+		ON CONFLICT (name) DO UPDATE
+			SET data = $2, owner = $3, phase = $4, repo_owner = $5, repo_repo = $6, repo_host = $7, repo_ref = $8, trigger_src = $9, success = $10, created = $11, finished = $12, job_spec_name = $13
+		RETURNING id`,
+		job.Name,
+		serializedJob,
+		job.Metadata.Owner,
+		strings.ToLower(strings.TrimPrefix(job.Phase.String(), "PHASE_")),
+		job.Metadata.Repository.Owner,
+		job.Metadata.Repository.Repo,
+		job.Metadata.Repository.Host,
+		job.Metadata.Repository.Ref,
+		strings.ToLower(strings.TrimPrefix(job.Metadata.Trigger.String(), "TRIGGER_")),
+		success,
+		job.Metadata.Created.Seconds,
+		finished,
+		job.Metadata.JobSpecName,
+	).Scan(&jobID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, annotation := range job.Metadata.Annotations {
+		_, err := tx.Exec(`
+		INSERT
+		INTO   annotations (job_id, name, value)
+		VALUES             ($1    , $2  , $3   )
+		ON CONFLICT (job_id, name) DO UPDATE
+			SET value = $3
+		`, jobID, annotation.Key, annotation.Value)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Get retrieves a particular job bassd on its name.
+func (s *JobStore) Get(ctx context.Context, name string) (*v1.JobStatus, error) {
+	var (
+		data   string
+		pinned bool
+	)
+	err := s.DB.QueryRowContext(ctx, "SELECT data, pinned FROM job_status WHERE name = ?", name).Scan(&data, &pinned)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var res v1.JobStatus
+	err = jsonpb.UnmarshalString(data, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Pinned = pinned
+
+	return &res, nil
+}
+
+// Find searches for jobs based on their annotations. If filter is empty no filter is applied.
+func (s *JobStore) Find(ctx context.Context, filter []*v1.FilterExpression, order []*v1.OrderExpression, start, limit int) (slice []v1.JobStatus, total int, err error) {
+	whereExp, args, err := dialect.WhereExpression(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	orderExp, err := sqlstore.OrderExpression(order)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limitExp := "-1"
+	if limit > 0 {
+		limitExp = fmt.Sprintf("%d", limit)
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(1) FROM job_status %s", whereExp)
+	log.WithField("query", countQuery).Debug("running query")
+	err = s.DB.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf("SELECT data, pinned FROM job_status %s %s LIMIT %s OFFSET %d", whereExp, orderExp, limitExp, start)
+	log.WithField("query", query).Debug("running query")
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var result []v1.JobStatus
+	for rows.Next() {
+		var (
+			data   string
+			pinned bool
+		)
+		err = rows.Scan(&data, &pinned)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var res v1.JobStatus
+		err = jsonpb.UnmarshalString(data, &res)
+		if err != nil {
+			return nil, 0, err
+		}
+		res.Pinned = pinned
+
+		result = append(result, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return result, total, nil
+}
+
+// Statistics computes job statistics. SQLite lacks percentile functions, hence we compute them outside the database.
+func (s *JobStore) Statistics(ctx context.Context, filter []*v1.FilterExpression, from, to time.Time) ([]*v1.JobStatistics, error) {
+	return store.FindStatistics(ctx, s, filter, from, to)
+}
+
+// StoreJobSpec stores job information in the store.
+func (s *JobStore) StoreJobSpec(name string, spec v1.JobSpec, data []byte) error {
+	rawSpec, err := proto.Marshal(&spec)
+	if err != nil {
+		return err
+	}
+	_, err = s.DB.Exec(`
+		INSERT
+		INTO   job_spec (name, data, spec)
+		VALUES          ($1  , $2  , $3  )
+		ON CONFLICT (name) DO UPDATE
+			SET data = $2, spec = $3
+		`,
+		name,
+		data,
+		rawSpec,
+	)
+	return err
+}
+
+// GetJobSpec retrieves a particular job bassd on its name.
+func (s *JobStore) GetJobSpec(name string) (*v1.JobSpec, []byte, error) {
+	var (
+		rawSpec []byte
+		data    []byte
+	)
+	err := s.DB.QueryRow("SELECT spec, data FROM job_spec WHERE name = ?", name).Scan(&rawSpec, &data)
+	if err == sql.ErrNoRows {
+		return nil, nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var spec *v1.JobSpec
+	if len(rawSpec) != 0 {
+		spec = &v1.JobSpec{}
+		err = proto.Unmarshal(rawSpec, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return spec, data, nil
+}
@@ -0,0 +1,83 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/csweichel/werft/pkg/store/sqlite"
+	"github.com/csweichel/werft/pkg/store/storetest"
+)
+
+func testDB(t *testing.T) *sql.DB {
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "werft.db"))
+	if err != nil {
+		t.Fatalf("cannot open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	err = sqlite.Migrate(db)
+	if err != nil {
+		t.Fatalf("cannot migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestJobStore(t *testing.T) {
+	storetest.RunJobsTests(t, func(t *testing.T) store.Jobs {
+		s, err := sqlite.NewJobStore(testDB(t))
+		if err != nil {
+			t.Fatalf("cannot create job store: %v", err)
+		}
+		return s
+	})
+}
+
+func TestMigrateTwice(t *testing.T) {
+	db := testDB(t)
+	err := sqlite.Migrate(db)
+	if err != nil {
+		t.Errorf("cannot migrate an already migrated database: %v", err)
+	}
+}
+
+func TestNumberGroup(t *testing.T) {
+	ngrp, err := sqlite.NewNumberGroup(testDB(t))
+	if err != nil {
+		t.Fatalf("cannot create number group: %v", err)
+	}
+
+	_, err = ngrp.Latest("foo")
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for unknown group, got %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		nr, err := ngrp.Next("foo")
+		if err != nil {
+			t.Fatalf("cannot get next number: %v", err)
+		}
+		if nr != i {
+			t.Errorf("expected %d, got %d", i, nr)
+		}
+	}
+
+	nr, err := ngrp.Latest("foo")
+	if err != nil {
+		t.Fatalf("cannot get latest number: %v", err)
+	}
+	if nr != 2 {
+		t.Errorf("expected latest number to be 2, got %d", nr)
+	}
+}
+
+func TestScheduleStore(t *testing.T) {
+	storetest.RunSchedulesTests(t, func(t *testing.T) store.Schedules {
+		s, err := sqlite.NewScheduleStore(testDB(t))
+		if err != nil {
+			t.Fatalf("cannot create schedule store: %v", err)
+		}
+		return s
+	})
+}
@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/filterexpr"
+	"github.com/csweichel/werft/pkg/store/sqlstore"
+)
+
+const annotationPrefix = "annotation."
+
+// jsonTerm translates a filter term on a field which is not a column of job_status into a query on the data column
+// using SQLite's JSON functions. Every list along the path of the field becomes a json_each table, so that the term
+// matches if any element matches. The returned expression uses ? placeholders and does not honour t.Negate.
+func jsonTerm(d sqlstore.Dialect, t *v1.FilterTerm) (expr string, args []interface{}, err error) {
+	if strings.HasPrefix(t.Field, annotationPrefix) {
+		return annotationTerm(d, strings.TrimPrefix(t.Field, annotationPrefix), t)
+	}
+
+	fp, err := filterexpr.ResolveField(t.Field)
+	if err != nil {
+		return "", nil, err
+	}
+
+	val := t.Value
+	if t.Operation != v1.FilterOp_OP_EXISTS {
+		val, err = fp.NormaliseValue(val)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	var (
+		tables []string
+		src    = "data"
+		path   = "$"
+	)
+	for i, seg := range fp.Segments {
+		path += fmt.Sprintf(".%q", seg)
+		if !fp.Repeated[i] {
+			continue
+		}
+		alias := fmt.Sprintf("j%d", len(tables))
+		tables = append(tables, fmt.Sprintf("json_each(%s, '%s') %s", src, path, alias))
+		src, path = alias+".value", "$"
+	}
+	field := src
+	if path != "$" {
+		field = fmt.Sprintf("json_extract(%s, '%s')", src, path)
+	}
+
+	var cond string
+	if t.Operation != v1.FilterOp_OP_EXISTS {
+		cond, args, err = valueCondition(d, fp, field, t.Operation, val)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	if len(tables) == 0 {
+		if cond == "" {
+			return "TRUE", nil, nil
+		}
+		return cond, args, nil
+	}
+
+	expr = fmt.Sprintf("EXISTS (SELECT 1 FROM %s", strings.Join(tables, ", "))
+	if cond != "" {
+		expr += " WHERE " + cond
+	}
+	return expr + ")", args, nil
+}
+
+// valueCondition produces a condition on a JSON value. Missing fields are treated as having their zero value,
+// and values are compared in the normalised form filterexpr uses, e.g. bools become true or false.
+func valueCondition(d sqlstore.Dialect, fp *filterexpr.FieldPath, field string, op v1.FilterOp, val string) (cond string, args []interface{}, err error) {
+	if fp.IsNumeric() && (op == v1.FilterOp_OP_LESS_THAN || op == v1.FilterOp_OP_GREATER_THAN) {
+		cmp := "<"
+		if op == v1.FilterOp_OP_GREATER_THAN {
+			cmp = ">"
+		}
+		return fmt.Sprintf("CAST(COALESCE(%s, 0) AS NUMERIC) %s CAST(? AS NUMERIC)", field, cmp), []interface{}{val}, nil
+	}
+
+	op2sql, val, err := d.Operation(op, val)
+	if err != nil {
+		return "", nil, err
+	}
+	if fp.IsBool() {
+		return fmt.Sprintf("(CASE WHEN COALESCE(%s, 0) THEN 'true' ELSE 'false' END) %s", field, op2sql), []interface{}{val}, nil
+	}
+	return fmt.Sprintf("CAST(COALESCE(%s, ?) AS TEXT) %s", field, op2sql), []interface{}{fp.ZeroValue(), val}, nil
+}
+
+// annotationTerm produces an expression on the value of the annotation with the given key
+func annotationTerm(d sqlstore.Dialect, key string, t *v1.FilterTerm) (expr string, args []interface{}, err error) {
+	expr = "EXISTS (SELECT 1 FROM json_each(data, '$.metadata.annotations') a WHERE json_extract(a.value, '$.key') = ?"
+	args = []interface{}{key}
+	if t.Operation != v1.FilterOp_OP_EXISTS {
+		op, val, err := d.Operation(t.Operation, t.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		expr += fmt.Sprintf(" AND COALESCE(json_extract(a.value, '$.value'), '') %s", op)
+		args = append(args, val)
+	}
+	return expr + ")", args, nil
+}
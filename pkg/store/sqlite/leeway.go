@@ -0,0 +1,3 @@
+//go:generate sh -c "[ -f ../../../_deps/pkg-store-sqlite--rice/rice-box.go ] && cp ../../../_deps/pkg-store-sqlite--rice/rice-box.go ."
+
+package sqlite
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"database/sql"
+	"os"
+	"sort"
+	"strings"
+
+	rice "github.com/GeertJohan/go.rice"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// Migrate ensures that the database has the current schema required for using any of the SQLite storage.
+// Migrations are applied in order of their name and recorded in the schema_migrations table.
+func Migrate(db *sql.DB) error {
+	migs, err := getMigrations()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version TEXT NOT NULL PRIMARY KEY)")
+	if err != nil {
+		return xerrors.Errorf("cannot create schema_migrations table: %w", err)
+	}
+
+	for _, mig := range migs {
+		var exists int
+		err = db.QueryRow("SELECT COUNT(1) FROM schema_migrations WHERE version = ?", mig.Version).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists > 0 {
+			continue
+		}
+
+		log.WithField("migration", mig.Version).Debug("applying migration")
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(mig.Up)
+		if err != nil {
+			tx.Rollback()
+			return xerrors.Errorf("error during migration %s: %w", mig.Version, err)
+		}
+		_, err = tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", mig.Version)
+		if err != nil {
+			tx.Rollback()
+			return xerrors.Errorf("error during migration %s: %w", mig.Version, err)
+		}
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type migration struct {
+	Version string
+	Up      string
+}
+
+func getMigrations() ([]migration, error) {
+	box, err := rice.FindBox("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var migs []migration
+	err = box.Walk("", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".up.sql") {
+			return nil
+		}
+
+		up, err := box.String(path)
+		if err != nil {
+			return xerrors.Errorf("cannot read from migration box: %w", err)
+		}
+		migs = append(migs, migration{
+			Version: strings.TrimSuffix(info.Name(), ".up.sql"),
+			Up:      up,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list migrations: %w", err)
+	}
+	sort.Slice(migs, func(i, j int) bool { return migs[i].Version < migs[j].Version })
+
+	return migs, nil
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS job_spec;
+DROP TABLE IF EXISTS number_group;
+DROP TABLE IF EXISTS annotations;
+DROP TABLE IF EXISTS job_status;
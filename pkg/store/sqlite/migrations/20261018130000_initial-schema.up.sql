@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS job_status (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL UNIQUE,
+	data TEXT NOT NULL,
+	owner TEXT NULL,
+	phase TEXT NOT NULL,
+	repo_owner TEXT NULL,
+	repo_repo TEXT NULL,
+	repo_host TEXT NULL,
+	repo_ref TEXT NULL,
+	trigger_src TEXT NULL,
+	success INTEGER NOT NULL,
+	created INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS annotations (
+	job_id INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	value TEXT NULL,
+	CONSTRAINT job_annotation UNIQUE(job_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS number_group (
+	name TEXT NOT NULL PRIMARY KEY,
+	val INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS job_spec (
+	name TEXT NOT NULL PRIMARY KEY,
+	data BLOB NOT NULL,
+	spec BLOB NULL
+);
+
+CREATE INDEX idx_job_status_owner ON job_status(owner);
+CREATE INDEX idx_job_status_phase ON job_status(phase);
+CREATE INDEX idx_job_status_repo_owner ON job_status(repo_owner);
+CREATE INDEX idx_job_status_repo_repo ON job_status(repo_repo);
+CREATE INDEX idx_job_status_repo_host ON job_status(repo_host);
+CREATE INDEX idx_job_status_repo_ref ON job_status(repo_ref);
+CREATE INDEX idx_job_status_trigger_src ON job_status(trigger_src);
+CREATE INDEX idx_job_status_success ON job_status(success);
+CREATE INDEX idx_job_status_created ON job_status(created);
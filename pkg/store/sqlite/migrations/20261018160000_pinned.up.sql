@@ -0,0 +1 @@
+ALTER TABLE job_status ADD COLUMN pinned INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS schedule (
+	name TEXT NOT NULL PRIMARY KEY,
+	data TEXT NOT NULL,
+	paused INTEGER NOT NULL DEFAULT 0,
+	next_run INTEGER NOT NULL,
+	last_run INTEGER NULL,
+	last_job TEXT NOT NULL DEFAULT '',
+	last_error TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_job_status_job_spec_name;
+DROP INDEX IF EXISTS idx_job_status_finished;
+ALTER TABLE job_status DROP COLUMN job_spec_name;
+ALTER TABLE job_status DROP COLUMN finished;
@@ -0,0 +1,9 @@
+ALTER TABLE job_status ADD COLUMN finished INTEGER NULL;
+ALTER TABLE job_status ADD COLUMN job_spec_name TEXT NULL;
+
+UPDATE job_status SET
+	finished = CAST(strftime('%s', json_extract(data, '$.metadata.finished')) AS INTEGER),
+	job_spec_name = json_extract(data, '$.metadata.jobSpecName');
+
+CREATE INDEX idx_job_status_finished ON job_status(finished);
+CREATE INDEX idx_job_status_job_spec_name ON job_status(job_spec_name);
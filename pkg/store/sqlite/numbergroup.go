@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/csweichel/werft/pkg/store"
+)
+
+// NumberGroup provides SQLite backed number groups
+type NumberGroup struct {
+	DB *sql.DB
+}
+
+var _ store.NumberGroup = &NumberGroup{}
+
+// NewNumberGroup creates a new SQLite number group store
+func NewNumberGroup(db *sql.DB) (*NumberGroup, error) {
+	return &NumberGroup{DB: db}, nil
+}
+
+// Latest returns the latest number of a particular number group.
+func (ngrp *NumberGroup) Latest(group string) (nr int, err error) {
+	err = ngrp.DB.QueryRow(`
+		SELECT val
+		FROM   number_group
+		WHERE  name = ?`,
+		group,
+	).Scan(&nr)
+	if err == sql.ErrNoRows {
+		return 0, store.ErrNotFound
+	}
+	return
+}
+
+// Next returns the next number in the group.
+func (ngrp *NumberGroup) Next(group string) (nr int, err error) {
+	err = ngrp.DB.QueryRow(`
+		INSERT
+		INTO   number_group (name, val)
+		VALUES              (?   , 0  )
+		ON CONFLICT (name) DO UPDATE
+			SET val = number_group.val + 1
+		RETURNING val`,
+		group,
+	).Scan(&nr)
+	return
+}
@@ -0,0 +1,142 @@
+package sqlstore
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/filterexpr"
+	"golang.org/x/xerrors"
+)
+
+// jobFields maps filter and order fields to job_status columns
+var jobFields = map[string]string{
+	"name":       "name",
+	"owner":      "owner",
+	"phase":      "phase",
+	"repo.owner": "repo_owner",
+	"repo.repo":  "repo_repo",
+	"repo.host":  "repo_host",
+	"repo.ref":   "repo_ref",
+	"trigger":    "trigger_src",
+	"success":    "success",
+	"created":    "created",
+	"finished":   "finished",
+	"pinned":     "pinned",
+}
+
+// WhereExpression translates the filter to an SQL WHERE clause on job_status using the placeholders of the dialect.
+// Fields which are not a column of job_status are translated by the JSONTerm function of the dialect.
+// Relative times are resolved to the time the expression is built.
+// If the filter is empty, the WHERE clause is empty.
+func (d Dialect) WhereExpression(filter []*v1.FilterExpression) (whereExp string, args []interface{}, err error) {
+	var (
+		whereExps []string
+		now       = time.Now()
+	)
+	for _, f := range filter {
+		if len(f.Terms) == 0 {
+			continue
+		}
+
+		var terms []string
+		for _, t := range f.Terms {
+			var not string
+			if t.Negate {
+				not = "NOT"
+			}
+
+			var (
+				expr  string
+				targs []interface{}
+			)
+			if field, ok := jobFields[t.Field]; ok {
+				val, err := filterexpr.ResolveValue(t.Field, t.Value, now)
+				if err != nil {
+					return "", nil, err
+				}
+				op, val, err := d.Operation(t.Operation, val)
+				if err != nil {
+					return "", nil, err
+				}
+				expr = fmt.Sprintf("%s %s", field, op)
+				if strings.Contains(op, "?") {
+					targs = []interface{}{columnValue(t.Field, val)}
+				}
+			} else if d.JSONTerm != nil {
+				expr, targs, err = d.JSONTerm(d, t)
+				if err != nil {
+					return "", nil, err
+				}
+			} else {
+				return "", nil, xerrors.Errorf("unknown field %s", t.Field)
+			}
+			terms = append(terms, fmt.Sprintf("%s (%s)", not, expr))
+			args = append(args, targs...)
+		}
+
+		expr := fmt.Sprintf("(%s)", strings.Join(terms, " OR "))
+		whereExps = append(whereExps, expr)
+	}
+	whereExp = strings.Join(whereExps, " AND ")
+	if whereExp != "" {
+		whereExp = "WHERE " + d.Placeholders(whereExp)
+	}
+	return whereExp, args, nil
+}
+
+// columnValue converts a filter value to the type of its column. The pinned column is a boolean,
+// which SQLite stores as integer and hence would not match a string.
+func columnValue(field, val string) interface{} {
+	if field == "pinned" {
+		return val == "true" || val == "1"
+	}
+	return val
+}
+
+// Operation translates a filter operation on a value to SQL using a ? placeholder for the value
+func (d Dialect) Operation(op v1.FilterOp, val string) (sql string, arg string, err error) {
+	switch op {
+	case v1.FilterOp_OP_CONTAINS:
+		return "LIKE '%' || ? || '%'", val, nil
+	case v1.FilterOp_OP_ENDS_WITH:
+		return "LIKE '%' || ?", val, nil
+	case v1.FilterOp_OP_EQUALS:
+		return "= ?", val, nil
+	case v1.FilterOp_OP_STARTS_WITH:
+		return "LIKE ? || '%'", val, nil
+	case v1.FilterOp_OP_EXISTS:
+		return "IS NOT NULL", val, nil
+	case v1.FilterOp_OP_LESS_THAN:
+		return "< ?", val, nil
+	case v1.FilterOp_OP_GREATER_THAN:
+		return "> ?", val, nil
+	case v1.FilterOp_OP_REGEX:
+		return d.RegexOperator + " ?", val, nil
+	case v1.FilterOp_OP_GLOB:
+		return "LIKE ? ESCAPE '\\'", filterexpr.GlobToLike(val), nil
+	default:
+		return "", "", xerrors.Errorf("unknown operation %v", op)
+	}
+}
+
+// OrderExpression translates the order to an SQL ORDER BY clause on job_status.
+// The jobs are always ordered by name last to produce a stable order for paging.
+func OrderExpression(order []*v1.OrderExpression) (string, error) {
+	order = append(order[:len(order):len(order)], &v1.OrderExpression{Field: "name", Ascending: true})
+	var orderExps []string
+	for _, o := range order {
+		field, ok := jobFields[o.Field]
+		if !ok {
+			return "", xerrors.Errorf("unknown field %s", o.Field)
+		}
+
+		dir := "DESC"
+		if o.Ascending {
+			dir = "ASC"
+		}
+		orderExps = append(orderExps, fmt.Sprintf("%s %s", field, dir))
+	}
+	return fmt.Sprintf("ORDER BY %s", strings.Join(orderExps, ", ")), nil
+}
@@ -0,0 +1,75 @@
+package sqlstore
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+)
+
+func TestWhereExpression(t *testing.T) {
+	term := func(field, value string, op v1.FilterOp, negate bool) []*v1.FilterExpression {
+		return []*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: field, Value: value, Operation: op, Negate: negate}}}}
+	}
+	dialect := Dialect{Placeholders: NumberPlaceholders, RegexOperator: "REGEXP"}
+	tests := []struct {
+		Name   string
+		Filter []*v1.FilterExpression
+		Where  string
+		Args   []interface{}
+		Error  bool
+	}{
+		{
+			Name: "empty",
+		},
+		{
+			Name:   "column",
+			Filter: term("phase", "done", v1.FilterOp_OP_EQUALS, true),
+			Where:  "WHERE (NOT (phase = $1))",
+			Args:   []interface{}{"done"},
+		},
+		{
+			Name:   "regex",
+			Filter: term("repo.ref", "^refs/tags/", v1.FilterOp_OP_REGEX, false),
+			Where:  "WHERE ( (repo_ref REGEXP $1))",
+			Args:   []interface{}{"^refs/tags/"},
+		},
+		{
+			Name:   "glob",
+			Filter: term("repo.ref", "refs/*_x", v1.FilterOp_OP_GLOB, false),
+			Where:  `WHERE ( (repo_ref LIKE $1 ESCAPE '\'))`,
+			Args:   []interface{}{`refs/%\_x`},
+		},
+		{
+			Name:   "pinned",
+			Filter: term("pinned", "true", v1.FilterOp_OP_EQUALS, false),
+			Where:  "WHERE ( (pinned = $1))",
+			Args:   []interface{}{true},
+		},
+		{
+			Name:   "no JSON support",
+			Filter: term("results.type", "url", v1.FilterOp_OP_EQUALS, false),
+			Error:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			where, args, err := dialect.WhereExpression(test.Filter)
+			if test.Error {
+				if err == nil {
+					t.Errorf("expected error, got %s", where)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if where != test.Where {
+				t.Errorf("unexpected where clause:\n\texpected %s\n\tgot      %s", test.Where, where)
+			}
+			if fmt.Sprint(args) != fmt.Sprint(test.Args) {
+				t.Errorf("unexpected args:\n\texpected %v\n\tgot      %v", test.Args, args)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// ScheduleStore stores schedules in an SQL database
+type ScheduleStore struct {
+	DB      *sql.DB
+	Dialect Dialect
+}
+
+var _ store.Schedules = &ScheduleStore{}
+
+func (s *ScheduleStore) exec(ctx context.Context, stmt string, args ...interface{}) (sql.Result, error) {
+	return s.DB.ExecContext(ctx, s.Dialect.Placeholders(stmt), args...)
+}
+
+// Create stores a new schedule
+func (s *ScheduleStore) Create(ctx context.Context, schedule v1.Schedule) error {
+	var (
+		paused  = schedule.Paused
+		nextRun = schedule.NextRun.GetSeconds()
+	)
+	// the state of a schedule is maintained in columns of its own and must not be part of the data
+	schedule.Paused = false
+	schedule.NextRun = nil
+	schedule.LastRun = nil
+	schedule.LastJob = ""
+	schedule.LastError = ""
+	data, err := (&jsonpb.Marshaler{EnumsAsInts: true}).MarshalToString(&schedule)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.exec(ctx, `
+		INSERT
+		INTO   schedule (name, data, paused, next_run)
+		VALUES          (?   , ?   , ?     , ?       )
+		ON CONFLICT (name) DO NOTHING`,
+		schedule.Name,
+		data,
+		paused,
+		nextRun,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrAlreadyExists
+	}
+	return nil
+}
+
+const scheduleColumns = "data, paused, next_run, last_run, last_job, last_error"
+
+// scanSchedule reads a schedule selected using scheduleColumns
+func scanSchedule(row interface{ Scan(...interface{}) error }) (*v1.Schedule, error) {
+	var (
+		data      string
+		paused    bool
+		nextRun   int64
+		lastRun   sql.NullInt64
+		lastJob   string
+		lastError string
+	)
+	err := row.Scan(&data, &paused, &nextRun, &lastRun, &lastJob, &lastError)
+	if err != nil {
+		return nil, err
+	}
+
+	var res v1.Schedule
+	err = jsonpb.UnmarshalString(data, &res)
+	if err != nil {
+		return nil, err
+	}
+	res.Paused = paused
+	res.LastJob = lastJob
+	res.LastError = lastError
+
+	res.NextRun, err = ptypes.TimestampProto(time.Unix(nextRun, 0))
+	if err != nil {
+		return nil, err
+	}
+	if lastRun.Valid {
+		res.LastRun, err = ptypes.TimestampProto(time.Unix(lastRun.Int64, 0))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &res, nil
+}
+
+// Get retrieves a schedule
+func (s *ScheduleStore) Get(ctx context.Context, name string) (*v1.Schedule, error) {
+	res, err := scanSchedule(s.DB.QueryRowContext(ctx, s.Dialect.Placeholders("SELECT "+scheduleColumns+" FROM schedule WHERE name = ?"), name))
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// List returns all schedules ordered by their name
+func (s *ScheduleStore) List(ctx context.Context) ([]*v1.Schedule, error) {
+	rows, err := s.DB.QueryContext(ctx, "SELECT "+scheduleColumns+" FROM schedule ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*v1.Schedule
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, schedule)
+	}
+	return res, rows.Err()
+}
+
+// Delete removes a schedule
+func (s *ScheduleStore) Delete(ctx context.Context, name string) error {
+	return expectRowsAffected(s.exec(ctx, "DELETE FROM schedule WHERE name = ?", name))
+}
+
+// Pause sets the paused flag and the next run of a schedule
+func (s *ScheduleStore) Pause(ctx context.Context, name string, paused bool, nextRun time.Time) error {
+	return expectRowsAffected(s.exec(ctx, "UPDATE schedule SET paused = ?, next_run = ? WHERE name = ?", paused, nextRun.Unix(), name))
+}
+
+// Claim takes the run of a schedule which is due at the given time. The update only succeeds if the
+// run has not been claimed yet, which makes claiming a run atomic.
+func (s *ScheduleStore) Claim(ctx context.Context, name string, due, nextRun time.Time) (bool, error) {
+	res, err := s.exec(ctx, `
+		UPDATE schedule
+		SET    next_run = ?, last_run = ?, last_job = '', last_error = ''
+		WHERE  name = ?
+		  AND  next_run = ?
+		  AND  NOT paused`,
+		nextRun.Unix(),
+		due.Unix(),
+		name,
+		due.Unix(),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// RecordRun records the job the last run started
+func (s *ScheduleStore) RecordRun(ctx context.Context, name, job, errMsg string) error {
+	return expectRowsAffected(s.exec(ctx, "UPDATE schedule SET last_job = ?, last_error = ? WHERE name = ?", job, errMsg, name))
+}
@@ -0,0 +1,60 @@
+// Package sqlstore contains the parts of the SQL stores which Postgres and SQLite share.
+// Statements are written using ? placeholders, which the dialect of a database rewrites if need be.
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/store"
+)
+
+// Dialect describes how a database differs from the SQL the shared stores produce
+type Dialect struct {
+	// Placeholders rewrites the ? placeholders of a statement to those of the database
+	Placeholders func(stmt string) string
+	// RegexOperator matches a value against a regular expression, e.g. ~ in Postgres
+	RegexOperator string
+	// JSONTerm translates a filter term on a field which is not a column of job_status into a query on
+	// the data column. The returned expression uses ? placeholders and does not honour t.Negate.
+	JSONTerm func(d Dialect, t *v1.FilterTerm) (expr string, args []interface{}, err error)
+}
+
+// NoPlaceholders leaves the ? placeholders of a statement untouched
+func NoPlaceholders(stmt string) string {
+	return stmt
+}
+
+// NumberPlaceholders replaces all ? placeholders with $n. The JSONB operator @? is left untouched.
+func NumberPlaceholders(stmt string) string {
+	var (
+		res strings.Builder
+		n   int
+	)
+	for i, r := range stmt {
+		if r != '?' || (i > 0 && stmt[i-1] == '@') {
+			res.WriteRune(r)
+			continue
+		}
+		n++
+		fmt.Fprintf(&res, "$%d", n)
+	}
+	return res.String()
+}
+
+// expectRowsAffected returns store.ErrNotFound if a statement did not affect any row
+func expectRowsAffected(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
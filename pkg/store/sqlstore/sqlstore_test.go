@@ -0,0 +1,22 @@
+package sqlstore
+
+import "testing"
+
+func TestNumberPlaceholders(t *testing.T) {
+	tests := []struct {
+		Stmt        string
+		Expectation string
+	}{
+		{"SELECT 1", "SELECT 1"},
+		{"UPDATE schedule SET paused = ? WHERE name = ?", "UPDATE schedule SET paused = $1 WHERE name = $2"},
+		{"data @? ? AND name = ?", "data @? $1 AND name = $2"},
+	}
+	for _, test := range tests {
+		t.Run(test.Stmt, func(t *testing.T) {
+			act := NumberPlaceholders(test.Stmt)
+			if act != test.Expectation {
+				t.Errorf("expected %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
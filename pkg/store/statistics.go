@@ -0,0 +1,178 @@
+package store
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+)
+
+// StatisticsFilter restricts a filter to finished jobs created within [from, to).
+func StatisticsFilter(filter []*v1.FilterExpression, from, to time.Time) []*v1.FilterExpression {
+	res := make([]*v1.FilterExpression, 0, len(filter)+3)
+	res = append(res, filter...)
+	res = append(res,
+		&v1.FilterExpression{Terms: []*v1.FilterTerm{{Field: "phase", Value: "done", Operation: v1.FilterOp_OP_EQUALS}}},
+		&v1.FilterExpression{Terms: []*v1.FilterTerm{{Field: "created", Value: strconv.FormatInt(from.Unix(), 10), Operation: v1.FilterOp_OP_LESS_THAN, Negate: true}}},
+		&v1.FilterExpression{Terms: []*v1.FilterTerm{{Field: "created", Value: strconv.FormatInt(to.Unix(), 10), Operation: v1.FilterOp_OP_LESS_THAN}}},
+	)
+	return res
+}
+
+// FindStatistics computes job statistics using Find and ComputeStatistics. Stores which cannot
+// compute statistics themselves can use this function as fallback.
+func FindStatistics(ctx context.Context, jobs Jobs, filter []*v1.FilterExpression, from, to time.Time) ([]*v1.JobStatistics, error) {
+	res, _, err := jobs.Find(ctx, StatisticsFilter(filter, from, to), nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeStatistics(res), nil
+}
+
+type statisticsKey struct {
+	Host, Owner, Repo, Ref, JobSpecName string
+}
+
+// ComputeStatistics computes statistics of finished jobs, grouped by repository, branch and job spec name.
+// Jobs which have not finished are ignored. The result is ordered by repository, branch and job spec name.
+func ComputeStatistics(jobs []v1.JobStatus) []*v1.JobStatistics {
+	groups := make(map[statisticsKey][]v1.JobStatus)
+	for _, j := range jobs {
+		if j.Phase != v1.JobPhase_PHASE_DONE || j.Metadata == nil {
+			continue
+		}
+
+		var key statisticsKey
+		if r := j.Metadata.Repository; r != nil {
+			key = statisticsKey{Host: r.Host, Owner: r.Owner, Repo: r.Repo, Ref: r.Ref}
+		}
+		key.JobSpecName = j.Metadata.JobSpecName
+		groups[key] = append(groups[key], j)
+	}
+
+	res := make([]*v1.JobStatistics, 0, len(groups))
+	for key, grp := range groups {
+		sort.SliceStable(grp, func(i, j int) bool { return createdAt(grp[i]).Before(createdAt(grp[j])) })
+
+		stats := &v1.JobStatistics{
+			Repository: &v1.Repository{
+				Host:  key.Host,
+				Owner: key.Owner,
+				Repo:  key.Repo,
+				Ref:   key.Ref,
+			},
+			JobSpecName: key.JobSpecName,
+			RunCount:    int32(len(grp)),
+		}
+
+		var durations []float64
+		for _, j := range grp {
+			if j.Conditions != nil && j.Conditions.Success {
+				stats.SuccessCount++
+			}
+			if d, ok := duration(j); ok {
+				durations = append(durations, d.Seconds())
+			}
+		}
+		stats.SuccessRate = float64(stats.SuccessCount) / float64(stats.RunCount)
+		sort.Float64s(durations)
+		stats.DurationP50Seconds = percentile(durations, 0.5)
+		stats.DurationP95Seconds = percentile(durations, 0.95)
+
+		var recovery time.Duration
+		for i, j := range grp {
+			// we're looking for the beginning of a sequence of failures
+			if isSuccess(j) || (i > 0 && !isSuccess(grp[i-1])) {
+				continue
+			}
+			if j.Metadata.Finished == nil {
+				continue
+			}
+
+			var recoveredAt time.Time
+			for _, s := range grp[i+1:] {
+				if !isSuccess(s) || s.Metadata.Finished == nil {
+					continue
+				}
+				if !createdAt(s).After(createdAt(j)) {
+					continue
+				}
+				if f := finishedAt(s); recoveredAt.IsZero() || f.Before(recoveredAt) {
+					recoveredAt = f
+				}
+			}
+			if recoveredAt.IsZero() {
+				continue
+			}
+
+			recovery += recoveredAt.Sub(finishedAt(j))
+			stats.RecoveryCount++
+		}
+		if stats.RecoveryCount > 0 {
+			stats.MttrSeconds = recovery.Seconds() / float64(stats.RecoveryCount)
+		}
+
+		res = append(res, stats)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		a, b := res[i], res[j]
+		for _, c := range [][2]string{
+			{a.Repository.Host, b.Repository.Host},
+			{a.Repository.Owner, b.Repository.Owner},
+			{a.Repository.Repo, b.Repository.Repo},
+			{a.Repository.Ref, b.Repository.Ref},
+			{a.JobSpecName, b.JobSpecName},
+		} {
+			if c[0] != c[1] {
+				return c[0] < c[1]
+			}
+		}
+		return false
+	})
+	return res
+}
+
+func isSuccess(j v1.JobStatus) bool {
+	return j.Conditions != nil && j.Conditions.Success
+}
+
+func createdAt(j v1.JobStatus) time.Time {
+	if j.Metadata.Created == nil {
+		return time.Time{}
+	}
+	return time.Unix(j.Metadata.Created.Seconds, int64(j.Metadata.Created.Nanos))
+}
+
+func finishedAt(j v1.JobStatus) time.Time {
+	if j.Metadata.Finished == nil {
+		return time.Time{}
+	}
+	return time.Unix(j.Metadata.Finished.Seconds, int64(j.Metadata.Finished.Nanos))
+}
+
+func duration(j v1.JobStatus) (time.Duration, bool) {
+	if j.Metadata.Created == nil || j.Metadata.Finished == nil {
+		return 0, false
+	}
+	return finishedAt(j).Sub(createdAt(j)), true
+}
+
+// percentile computes the p-th percentile of the sorted values using linear interpolation.
+// This matches the behaviour of Postgres' percentile_cont.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
@@ -0,0 +1,88 @@
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/csweichel/werft/pkg/store/storetest"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func TestComputeStatistics(t *testing.T) {
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(name string, created time.Duration, success bool) v1.JobStatus {
+		return storetest.NewJob(name, t0.Add(created), func(j *v1.JobStatus) {
+			j.Metadata.Finished, _ = ptypes.TimestampProto(t0.Add(created + time.Minute))
+			j.Conditions.Success = success
+		})
+	}
+
+	tests := []struct {
+		Name          string
+		Jobs          []v1.JobStatus
+		RunCount      int32
+		RecoveryCount int32
+		MTTR          float64
+		P95           float64
+	}{
+		{
+			Name:     "single job",
+			Jobs:     []v1.JobStatus{job("a", 0, true)},
+			RunCount: 1,
+			P95:      60,
+		},
+		{
+			Name:     "unrecovered failure",
+			Jobs:     []v1.JobStatus{job("a", 0, true), job("b", time.Hour, false)},
+			RunCount: 2,
+			P95:      60,
+		},
+		{
+			Name: "two failure streaks",
+			Jobs: []v1.JobStatus{
+				// out of order on purpose - statistics must not depend on the order of the input
+				job("d", 3*time.Hour, true),
+				job("a", 0, false),
+				job("b", time.Hour, true),
+				job("c", 2*time.Hour, false),
+				job("e", 4*time.Hour, false),
+			},
+			RunCount:      5,
+			RecoveryCount: 2,
+			MTTR:          time.Hour.Seconds(),
+			P95:           60,
+		},
+		{
+			Name: "ignores unfinished jobs",
+			Jobs: []v1.JobStatus{
+				job("a", 0, true),
+				storetest.NewJob("b", t0, func(j *v1.JobStatus) { j.Phase = v1.JobPhase_PHASE_RUNNING }),
+			},
+			RunCount: 1,
+			P95:      60,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			res := store.ComputeStatistics(test.Jobs)
+			if len(res) != 1 {
+				t.Fatalf("expected a single group, got %d", len(res))
+			}
+			act := res[0]
+			if act.RunCount != test.RunCount {
+				t.Errorf("run count: expected %d, got %d", test.RunCount, act.RunCount)
+			}
+			if act.RecoveryCount != test.RecoveryCount {
+				t.Errorf("recovery count: expected %d, got %d", test.RecoveryCount, act.RecoveryCount)
+			}
+			if act.MttrSeconds != test.MTTR {
+				t.Errorf("MTTR: expected %v, got %v", test.MTTR, act.MttrSeconds)
+			}
+			if act.DurationP95Seconds != test.P95 {
+				t.Errorf("p95: expected %v, got %v", test.P95, act.DurationP95Seconds)
+			}
+		})
+	}
+}
@@ -34,8 +34,15 @@ type Logs interface {
 	// Reading from logs currently being written is supported.
 	Read(id string) (io.ReadCloser, error)
 
-	// GarbageCollect removes all logs older than the given duration.
+	// GarbageCollect removes all logs older than the given duration, except for pinned ones.
 	GarbageCollect(olderThan time.Duration) error
+
+	// Delete removes the log of a single job. Deleting a log which does not exist is not an error.
+	// Logs which are currently open for writing cannot be deleted.
+	Delete(id string) error
+
+	// Pin protects a log from garbage collection (pinned == true), or removes that protection.
+	Pin(id string, pinned bool) error
 }
 
 // Jobs provides access to past jobs
@@ -59,8 +66,19 @@ type Jobs interface {
 	// If limit is 0, no limit is applied.
 	Find(ctx context.Context, filter []*v1.FilterExpression, order []*v1.OrderExpression, start, limit int) (slice []v1.JobStatus, total int, err error)
 
-	// GarbageCollect removes all logs older than the given duration.
+	// Statistics computes statistics of finished jobs created within [from, to), grouped by repository,
+	// branch and job spec name. If filter is empty no filter is applied.
+	Statistics(ctx context.Context, filter []*v1.FilterExpression, from, to time.Time) ([]*v1.JobStatistics, error)
+
+	// GarbageCollect removes all jobs older than the given duration, except for pinned ones.
 	GarbageCollect(olderThan time.Duration) error
+
+	// Delete removes a single job. Deleting a job which does not exist is not an error.
+	Delete(ctx context.Context, name string) error
+
+	// Pin sets the pinned flag of a job. Pinned jobs are not garbage collected.
+	// Storing a job does not change its pinned flag. If the job is unknown we'll return ErrNotFound.
+	Pin(ctx context.Context, name string, pinned bool) error
 }
 
 // NumberGroup enables to atomic generation and storage of numbers.
@@ -75,3 +93,29 @@ type NumberGroup interface {
 	// to this call it is created. This function is thread-safe and atomic.
 	Next(group string) (nr int, err error)
 }
+
+// Schedules stores schedules which start jobs regularly
+type Schedules interface {
+	// Create stores a new schedule. Returns ErrAlreadyExists if a schedule with the same name exists.
+	Create(ctx context.Context, schedule v1.Schedule) error
+
+	// Get retrieves a schedule. If the schedule is unknown we'll return ErrNotFound.
+	Get(ctx context.Context, name string) (*v1.Schedule, error)
+
+	// List returns all schedules ordered by their name.
+	List(ctx context.Context) ([]*v1.Schedule, error)
+
+	// Delete removes a schedule. If the schedule is unknown we'll return ErrNotFound.
+	Delete(ctx context.Context, name string) error
+
+	// Pause sets the paused flag and the next run of a schedule. If the schedule is unknown we'll return ErrNotFound.
+	Pause(ctx context.Context, name string, paused bool, nextRun time.Time) error
+
+	// Claim takes the run of a schedule which is due at the given time, and moves the schedule's next run to nextRun.
+	// Claim returns false if the schedule is unknown or paused, or if the run was claimed already. Each run can be
+	// claimed only once, even if several werft instances share the store.
+	Claim(ctx context.Context, name string, due, nextRun time.Time) (bool, error)
+
+	// RecordRun records the job the last run started, or the error which kept it from starting a job.
+	RecordRun(ctx context.Context, name, job, errMsg string) error
+}
@@ -0,0 +1,547 @@
+// Package storetest provides a conformance test suite for store implementations.
+// All implementations of store.Jobs and store.Schedules are expected to pass this suite, so that tests and setups using
+// one implementation behave the same as with any other.
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/filterexpr"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// JobsFactory produces a new, empty job store for a single test
+type JobsFactory func(t *testing.T) store.Jobs
+
+// RunJobsTests runs the job store conformance test suite against the store produced by the factory
+func RunJobsTests(t *testing.T, factory JobsFactory) {
+	tests := []struct {
+		Name string
+		Test func(t *testing.T, s store.Jobs)
+	}{
+		{"StoreGet", testStoreGet},
+		{"StoreOverride", testStoreOverride},
+		{"Find", testFind},
+		{"FindFields", testFindFields},
+		{"FindOrder", testFindOrder},
+		{"FindPaging", testFindPaging},
+		{"JobSpec", testJobSpec},
+		{"GarbageCollect", testGarbageCollect},
+		{"Delete", testDelete},
+		{"Pin", testPin},
+		{"Statistics", testStatistics},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Test(t, factory(t))
+		})
+	}
+}
+
+// NewJob produces a job status for use in tests
+func NewJob(name string, created time.Time, mod ...func(*v1.JobStatus)) v1.JobStatus {
+	ts, _ := ptypes.TimestampProto(created)
+	res := v1.JobStatus{
+		Name: name,
+		Metadata: &v1.JobMetadata{
+			Owner: "owner",
+			Repository: &v1.Repository{
+				Host:  "github.com",
+				Owner: "csweichel",
+				Repo:  "werft",
+				Ref:   "refs/heads/main",
+			},
+			Trigger:     v1.JobTrigger_TRIGGER_PUSH,
+			Created:     ts,
+			Annotations: []*v1.Annotation{},
+		},
+		Phase:      v1.JobPhase_PHASE_DONE,
+		Conditions: &v1.JobConditions{Success: true},
+		Results:    []*v1.JobResult{},
+	}
+	for _, m := range mod {
+		m(&res)
+	}
+	return res
+}
+
+func storeJobs(t *testing.T, s store.Jobs, jobs ...v1.JobStatus) {
+	for _, j := range jobs {
+		err := s.Store(context.Background(), j)
+		if err != nil {
+			t.Fatalf("cannot store job %s: %v", j.Name, err)
+		}
+	}
+}
+
+func names(jobs []v1.JobStatus) []string {
+	res := make([]string, len(jobs))
+	for i, j := range jobs {
+		res[i] = j.Name
+	}
+	return res
+}
+
+func testStoreGet(t *testing.T, s store.Jobs) {
+	job := NewJob("foo", time.Unix(1000, 0), func(j *v1.JobStatus) {
+		j.Metadata.Annotations = []*v1.Annotation{{Key: "hello", Value: "world"}}
+		j.Results = []*v1.JobResult{{Type: "url", Payload: "https://werft.dev", Channels: []string{"github"}}}
+	})
+	storeJobs(t, s, job)
+
+	act, err := s.Get(context.Background(), "foo")
+	if err != nil {
+		t.Fatalf("cannot get job: %v", err)
+	}
+	if !proto.Equal(act, &job) {
+		t.Errorf("stored job differs from retrieved one: expected %v, got %v", &job, act)
+	}
+
+	_, err = s.Get(context.Background(), "does-not-exist")
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for unknown job, got %v", err)
+	}
+}
+
+func testStoreOverride(t *testing.T, s store.Jobs) {
+	storeJobs(t, s,
+		NewJob("foo", time.Unix(1000, 0), func(j *v1.JobStatus) { j.Phase = v1.JobPhase_PHASE_RUNNING }),
+		NewJob("foo", time.Unix(1000, 0)),
+	)
+
+	act, err := s.Get(context.Background(), "foo")
+	if err != nil {
+		t.Fatalf("cannot get job: %v", err)
+	}
+	if act.Phase != v1.JobPhase_PHASE_DONE {
+		t.Errorf("expected the second store to override the job, but phase is %v", act.Phase)
+	}
+
+	_, total, err := s.Find(context.Background(), nil, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("cannot find jobs: %v", err)
+	}
+	if total != 1 {
+		t.Errorf("expected exactly one job, got %d", total)
+	}
+}
+
+func testFind(t *testing.T, s store.Jobs) {
+	storeJobs(t, s,
+		NewJob("a", time.Unix(1000, 0)),
+		NewJob("b", time.Unix(2000, 0), func(j *v1.JobStatus) {
+			j.Phase = v1.JobPhase_PHASE_RUNNING
+			j.Conditions.Success = false
+		}),
+		NewJob("c", time.Unix(3000, 0), func(j *v1.JobStatus) {
+			j.Metadata.Owner = "someone-else"
+			j.Metadata.Trigger = v1.JobTrigger_TRIGGER_MANUAL
+			j.Metadata.Repository.Ref = "refs/tags/v1.0"
+		}),
+	)
+
+	term := func(field, value string, op v1.FilterOp, neg bool) *v1.FilterExpression {
+		return &v1.FilterExpression{Terms: []*v1.FilterTerm{{Field: field, Value: value, Operation: op, Negate: neg}}}
+	}
+	tests := []struct {
+		Name        string
+		Filter      []*v1.FilterExpression
+		Expectation []string
+	}{
+		{"no filter", nil, []string{"a", "b", "c"}},
+		{"equals", []*v1.FilterExpression{term("phase", "running", v1.FilterOp_OP_EQUALS, false)}, []string{"b"}},
+		{"negated equals", []*v1.FilterExpression{term("phase", "running", v1.FilterOp_OP_EQUALS, true)}, []string{"a", "c"}},
+		{"starts with", []*v1.FilterExpression{term("repo.ref", "refs/tags/", v1.FilterOp_OP_STARTS_WITH, false)}, []string{"c"}},
+		{"ends with", []*v1.FilterExpression{term("owner", "-else", v1.FilterOp_OP_ENDS_WITH, false)}, []string{"c"}},
+		{"contains", []*v1.FilterExpression{term("repo.ref", "heads", v1.FilterOp_OP_CONTAINS, false)}, []string{"a", "b"}},
+		{"trigger", []*v1.FilterExpression{term("trigger", "manual", v1.FilterOp_OP_EQUALS, false)}, []string{"c"}},
+		{"success", []*v1.FilterExpression{term("success", "0", v1.FilterOp_OP_EQUALS, false)}, []string{"b"}},
+		{"created", []*v1.FilterExpression{term("created", "1500", v1.FilterOp_OP_GREATER_THAN, false)}, []string{"b", "c"}},
+		{"regex", []*v1.FilterExpression{term("repo.ref", "^refs/tags/v[0-9]", v1.FilterOp_OP_REGEX, false)}, []string{"c"}},
+		{"glob", []*v1.FilterExpression{term("repo.ref", "refs/*/main", v1.FilterOp_OP_GLOB, false)}, []string{"a", "b"}},
+		{
+			"and",
+			[]*v1.FilterExpression{
+				term("phase", "done", v1.FilterOp_OP_EQUALS, false),
+				term("owner", "owner", v1.FilterOp_OP_EQUALS, false),
+			},
+			[]string{"a"},
+		},
+		{
+			"or",
+			[]*v1.FilterExpression{{Terms: []*v1.FilterTerm{
+				{Field: "name", Value: "a", Operation: v1.FilterOp_OP_EQUALS},
+				{Field: "name", Value: "c", Operation: v1.FilterOp_OP_EQUALS},
+			}}},
+			[]string{"a", "c"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			res, total, err := s.Find(context.Background(), test.Filter, []*v1.OrderExpression{{Field: "name", Ascending: true}}, 0, 0)
+			if err != nil {
+				t.Fatalf("cannot find jobs: %v", err)
+			}
+			if act := names(res); fmt.Sprint(act) != fmt.Sprint(test.Expectation) {
+				t.Errorf("expected %v, got %v", test.Expectation, act)
+			}
+			if total != len(test.Expectation) {
+				t.Errorf("expected total of %d, got %d", len(test.Expectation), total)
+			}
+		})
+	}
+}
+
+// testFindFields makes sure that all stores support the same fields in filters,
+// including annotations and fields which are addressed by their path
+func testFindFields(t *testing.T, s store.Jobs) {
+	storeJobs(t, s,
+		NewJob("with-url", time.Unix(1000, 0), func(j *v1.JobStatus) {
+			j.Results = []*v1.JobResult{{Type: "url", Payload: "https://werft.dev", Channels: []string{"github"}}}
+			j.Conditions.DidExecute = true
+			j.Metadata.JobSpecName = "build"
+			j.Metadata.Annotations = []*v1.Annotation{{Key: "foo", Value: "bar"}}
+			j.Metadata.Repository.Revision = "abc"
+			j.Metadata.Finished, _ = ptypes.TimestampProto(time.Unix(2000, 0))
+		}),
+		NewJob("without-results", time.Unix(1000, 0), func(j *v1.JobStatus) {
+			j.Metadata.JobSpecName = "test"
+		}),
+	)
+	err := s.Pin(context.Background(), "with-url", true)
+	if err != nil {
+		t.Fatalf("cannot pin job: %v", err)
+	}
+
+	tests := []struct {
+		Expr        string
+		Expectation []string
+	}{
+		{"results.type == url", []string{"with-url"}},
+		{"results.type != url", []string{"without-results"}},
+		{"results.payload =* https://*", []string{"with-url"}},
+		{"results.channels == github", []string{"with-url"}},
+		{"conditions.did_execute == true", []string{"with-url"}},
+		{"conditions.did_execute == false", []string{"without-results"}},
+		{"metadata.trigger == push", []string{"with-url", "without-results"}},
+		{"spec_name in (build, test)", []string{"with-url", "without-results"}},
+		{"repo.rev == abc", []string{"with-url"}},
+		{"annotation.foo ~= a", []string{"with-url"}},
+		{"annotation.foo", []string{"with-url"}},
+		{"not annotation.foo", []string{"without-results"}},
+		{"annotation.foo != bar", []string{"without-results"}},
+		{"finished > 1500", []string{"with-url"}},
+		{"finished >= 1500", []string{"with-url"}},
+		{"finished <= 2500", []string{"with-url"}},
+		{"finished <= 1500", nil},
+		{"pinned == true", []string{"with-url"}},
+		{"pinned == false", []string{"without-results"}},
+		{"pinned == 1", []string{"with-url"}},
+	}
+	for _, test := range tests {
+		t.Run(test.Expr, func(t *testing.T) {
+			filter, err := filterexpr.ParseExpression(test.Expr)
+			if err != nil {
+				t.Fatalf("cannot parse expression: %v", err)
+			}
+			res, _, err := s.Find(context.Background(), filter, []*v1.OrderExpression{{Field: "name", Ascending: true}}, 0, 0)
+			if err != nil {
+				t.Fatalf("cannot find jobs: %v", err)
+			}
+			if act := names(res); fmt.Sprint(act) != fmt.Sprint(test.Expectation) {
+				t.Errorf("expected %v, got %v", test.Expectation, act)
+			}
+		})
+	}
+
+	// all stores can order by the fields they can filter by
+	res, _, err := s.Find(context.Background(), nil, []*v1.OrderExpression{{Field: "pinned"}, {Field: "finished"}}, 0, 0)
+	if err != nil {
+		t.Fatalf("cannot order jobs: %v", err)
+	}
+	if act, exp := names(res), []string{"with-url", "without-results"}; fmt.Sprint(act) != fmt.Sprint(exp) {
+		t.Errorf("expected %v, got %v", exp, act)
+	}
+}
+
+func testFindOrder(t *testing.T, s store.Jobs) {
+	storeJobs(t, s,
+		NewJob("a", time.Unix(3000, 0), func(j *v1.JobStatus) { j.Metadata.Owner = "y" }),
+		NewJob("b", time.Unix(1000, 0), func(j *v1.JobStatus) { j.Metadata.Owner = "x" }),
+		NewJob("c", time.Unix(2000, 0), func(j *v1.JobStatus) { j.Metadata.Owner = "y" }),
+		NewJob("d", time.Unix(10000, 0), func(j *v1.JobStatus) { j.Metadata.Owner = "x" }),
+	)
+
+	tests := []struct {
+		Name        string
+		Order       []*v1.OrderExpression
+		Expectation []string
+	}{
+		{"name ascending", []*v1.OrderExpression{{Field: "name", Ascending: true}}, []string{"a", "b", "c", "d"}},
+		{"name descending", []*v1.OrderExpression{{Field: "name"}}, []string{"d", "c", "b", "a"}},
+		{"created ascending", []*v1.OrderExpression{{Field: "created", Ascending: true}}, []string{"b", "c", "a", "d"}},
+		{"created descending", []*v1.OrderExpression{{Field: "created"}}, []string{"d", "a", "c", "b"}},
+		{"owner then name", []*v1.OrderExpression{{Field: "owner", Ascending: true}}, []string{"b", "d", "a", "c"}},
+		{
+			"owner then created",
+			[]*v1.OrderExpression{{Field: "owner", Ascending: true}, {Field: "created"}},
+			[]string{"d", "b", "a", "c"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			res, _, err := s.Find(context.Background(), nil, test.Order, 0, 0)
+			if err != nil {
+				t.Fatalf("cannot find jobs: %v", err)
+			}
+			if act := names(res); fmt.Sprint(act) != fmt.Sprint(test.Expectation) {
+				t.Errorf("expected %v, got %v", test.Expectation, act)
+			}
+		})
+	}
+
+	_, _, err := s.Find(context.Background(), nil, []*v1.OrderExpression{{Field: "does-not-exist"}}, 0, 0)
+	if err == nil {
+		t.Errorf("expected an error when ordering by an unknown field")
+	}
+}
+
+func testFindPaging(t *testing.T, s store.Jobs) {
+	for i := 0; i < 10; i++ {
+		storeJobs(t, s, NewJob(fmt.Sprintf("job-%d", i), time.Unix(int64(1000+i), 0)))
+	}
+
+	order := []*v1.OrderExpression{{Field: "created", Ascending: true}}
+	tests := []struct {
+		Start       int
+		Limit       int
+		Expectation []string
+	}{
+		{0, 3, []string{"job-0", "job-1", "job-2"}},
+		{3, 3, []string{"job-3", "job-4", "job-5"}},
+		{8, 3, []string{"job-8", "job-9"}},
+		{7, 0, []string{"job-7", "job-8", "job-9"}},
+		{10, 3, []string{}},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("start %d limit %d", test.Start, test.Limit), func(t *testing.T) {
+			res, total, err := s.Find(context.Background(), nil, order, test.Start, test.Limit)
+			if err != nil {
+				t.Fatalf("cannot find jobs: %v", err)
+			}
+			if act := names(res); fmt.Sprint(act) != fmt.Sprint(test.Expectation) {
+				t.Errorf("expected %v, got %v", test.Expectation, act)
+			}
+			if total != 10 {
+				t.Errorf("expected total of 10, got %d", total)
+			}
+		})
+	}
+}
+
+func testJobSpec(t *testing.T, s store.Jobs) {
+	spec := v1.JobSpec{
+		Source:     &v1.JobSpec_JobPath{JobPath: ".werft/build.yaml"},
+		NameSuffix: "suffix",
+	}
+	data := []byte("pod: {}")
+	err := s.StoreJobSpec("foo", spec, data)
+	if err != nil {
+		t.Fatalf("cannot store job spec: %v", err)
+	}
+
+	act, actData, err := s.GetJobSpec("foo")
+	if err != nil {
+		t.Fatalf("cannot get job spec: %v", err)
+	}
+	if !proto.Equal(act, &spec) {
+		t.Errorf("stored job spec differs from retrieved one: expected %v, got %v", &spec, act)
+	}
+	if string(actData) != string(data) {
+		t.Errorf("stored job spec data differs from retrieved one: expected %s, got %s", data, actData)
+	}
+
+	_, _, err = s.GetJobSpec("does-not-exist")
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for unknown job spec, got %v", err)
+	}
+}
+
+func testGarbageCollect(t *testing.T, s store.Jobs) {
+	storeJobs(t, s,
+		NewJob("old-done", time.Now().Add(-2*time.Hour)),
+		NewJob("old-running", time.Now().Add(-2*time.Hour), func(j *v1.JobStatus) { j.Phase = v1.JobPhase_PHASE_RUNNING }),
+		NewJob("new-done", time.Now()),
+	)
+
+	err := s.GarbageCollect(time.Hour)
+	if err != nil {
+		t.Fatalf("cannot garbage collect: %v", err)
+	}
+
+	res, _, err := s.Find(context.Background(), nil, []*v1.OrderExpression{{Field: "name", Ascending: true}}, 0, 0)
+	if err != nil {
+		t.Fatalf("cannot find jobs: %v", err)
+	}
+	if act, exp := names(res), []string{"new-done", "old-running"}; fmt.Sprint(act) != fmt.Sprint(exp) {
+		t.Errorf("expected %v after garbage collection, got %v", exp, act)
+	}
+}
+
+func testDelete(t *testing.T, s store.Jobs) {
+	storeJobs(t, s,
+		NewJob("a", time.Now(), func(j *v1.JobStatus) { j.Metadata.Annotations = []*v1.Annotation{{Key: "foo", Value: "bar"}} }),
+		NewJob("b", time.Now()),
+	)
+
+	err := s.Delete(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("cannot delete job: %v", err)
+	}
+	_, err = s.Get(context.Background(), "a")
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for deleted job, got %v", err)
+	}
+	_, err = s.Get(context.Background(), "b")
+	if err != nil {
+		t.Errorf("cannot get job which was not deleted: %v", err)
+	}
+
+	err = s.Delete(context.Background(), "does-not-exist")
+	if err != nil {
+		t.Errorf("deleting an unknown job should not fail: %v", err)
+	}
+
+	// storing a job again after it was deleted must work, i.e. no annotations may be left behind
+	storeJobs(t, s, NewJob("a", time.Now(), func(j *v1.JobStatus) { j.Metadata.Annotations = []*v1.Annotation{{Key: "foo", Value: "baz"}} }))
+}
+
+func testPin(t *testing.T, s store.Jobs) {
+	ctx := context.Background()
+	storeJobs(t, s, NewJob("old", time.Now().Add(-2*time.Hour)))
+
+	err := s.Pin(ctx, "old", true)
+	if err != nil {
+		t.Fatalf("cannot pin job: %v", err)
+	}
+	// storing a job update must not change the pinned flag
+	storeJobs(t, s, NewJob("old", time.Now().Add(-2*time.Hour)))
+
+	res, err := s.Get(ctx, "old")
+	if err != nil {
+		t.Fatalf("cannot get job: %v", err)
+	}
+	if !res.Pinned {
+		t.Errorf("expected job to be pinned")
+	}
+	found, _, err := s.Find(ctx, nil, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("cannot find jobs: %v", err)
+	}
+	if len(found) != 1 || !found[0].Pinned {
+		t.Errorf("expected Find to return the pinned job, got %v", found)
+	}
+
+	err = s.GarbageCollect(time.Hour)
+	if err != nil {
+		t.Fatalf("cannot garbage collect: %v", err)
+	}
+	_, err = s.Get(ctx, "old")
+	if err != nil {
+		t.Errorf("pinned job was garbage collected: %v", err)
+	}
+
+	err = s.Pin(ctx, "old", false)
+	if err != nil {
+		t.Fatalf("cannot unpin job: %v", err)
+	}
+	err = s.GarbageCollect(time.Hour)
+	if err != nil {
+		t.Fatalf("cannot garbage collect: %v", err)
+	}
+	_, err = s.Get(ctx, "old")
+	if err != store.ErrNotFound {
+		t.Errorf("expected unpinned job to be garbage collected, got %v", err)
+	}
+
+	err = s.Pin(ctx, "does-not-exist", true)
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound when pinning an unknown job, got %v", err)
+	}
+}
+
+func testStatistics(t *testing.T, s store.Jobs) {
+	t0 := time.Now().Add(-time.Hour).Truncate(time.Second)
+	job := func(name, spec string, created time.Duration, duration time.Duration, success bool) v1.JobStatus {
+		return NewJob(name, t0.Add(created), func(j *v1.JobStatus) {
+			j.Metadata.JobSpecName = spec
+			j.Metadata.Finished, _ = ptypes.TimestampProto(t0.Add(created + duration))
+			j.Conditions.Success = success
+		})
+	}
+	storeJobs(t, s,
+		job("too-old", "build", -2*time.Hour, time.Minute, false),
+		job("build-a", "build", 0, 60*time.Second, true),
+		job("build-b", "build", 10*time.Minute, 120*time.Second, false),
+		job("build-c", "build", 20*time.Minute, 60*time.Second, false),
+		job("build-d", "build", 30*time.Minute, 180*time.Second, true),
+		NewJob("build-e", t0.Add(40*time.Minute), func(j *v1.JobStatus) {
+			j.Metadata.JobSpecName = "build"
+			j.Phase = v1.JobPhase_PHASE_RUNNING
+		}),
+		job("test-a", "test", 0, 30*time.Second, true),
+	)
+
+	res, err := s.Statistics(context.Background(), nil, t0.Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatalf("cannot compute statistics: %v", err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("expected statistics for two job specs, got %d: %v", len(res), res)
+	}
+
+	build, test := res[0], res[1]
+	if build.JobSpecName != "build" || test.JobSpecName != "test" {
+		t.Fatalf("unexpected job spec names or order: %s, %s", build.JobSpecName, test.JobSpecName)
+	}
+	if build.Repository.Repo != "werft" || build.Repository.Ref != "refs/heads/main" {
+		t.Errorf("unexpected repository: %v", build.Repository)
+	}
+
+	approx := func(a, b float64) bool { return a-b < 0.001 && b-a < 0.001 }
+	checks := []struct {
+		Name     string
+		Act, Exp float64
+	}{
+		{"build run count", float64(build.RunCount), 4},
+		{"build success count", float64(build.SuccessCount), 2},
+		{"build success rate", build.SuccessRate, 0.5},
+		{"build p50", build.DurationP50Seconds, 90},
+		{"build p95", build.DurationP95Seconds, 171},
+		{"build recovery count", float64(build.RecoveryCount), 1},
+		{"build mttr", build.MttrSeconds, (21 * time.Minute).Seconds()},
+		{"test run count", float64(test.RunCount), 1},
+		{"test success rate", test.SuccessRate, 1},
+		{"test p50", test.DurationP50Seconds, 30},
+		{"test mttr", test.MttrSeconds, 0},
+	}
+	for _, c := range checks {
+		if !approx(c.Act, c.Exp) {
+			t.Errorf("%s: expected %v, got %v", c.Name, c.Exp, c.Act)
+		}
+	}
+
+	res, err = s.Statistics(context.Background(), []*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "name", Value: "test-", Operation: v1.FilterOp_OP_STARTS_WITH}}}}, t0.Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatalf("cannot compute statistics: %v", err)
+	}
+	if len(res) != 1 || res[0].JobSpecName != "test" {
+		t.Errorf("expected filter to restrict statistics to the test job spec, got %v", res)
+	}
+}
@@ -0,0 +1,286 @@
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// SchedulesFactory produces a new, empty schedule store for a single test
+type SchedulesFactory func(t *testing.T) store.Schedules
+
+// RunSchedulesTests runs the schedule store conformance test suite against the store produced by the factory
+func RunSchedulesTests(t *testing.T, factory SchedulesFactory) {
+	tests := []struct {
+		Name string
+		Test func(t *testing.T, s store.Schedules)
+	}{
+		{"CreateGet", testScheduleCreateGet},
+		{"List", testScheduleList},
+		{"Delete", testScheduleDelete},
+		{"Pause", testSchedulePause},
+		{"Claim", testScheduleClaim},
+		{"ClaimConcurrently", testScheduleClaimConcurrently},
+		{"RecordRun", testScheduleRecordRun},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Test(t, factory(t))
+		})
+	}
+}
+
+// NewSchedule produces a schedule for use in tests
+func NewSchedule(name string, nextRun time.Time, mod ...func(*v1.Schedule)) v1.Schedule {
+	created, _ := ptypes.TimestampProto(time.Unix(1000, 0))
+	next, _ := ptypes.TimestampProto(nextRun)
+	res := v1.Schedule{
+		Name: name,
+		Cron: "0 4 * * *",
+		Metadata: &v1.JobMetadata{
+			Owner: "owner",
+			Repository: &v1.Repository{
+				Host:  "github.com",
+				Owner: "csweichel",
+				Repo:  "werft",
+				Ref:   "refs/heads/main",
+			},
+			Trigger:     v1.JobTrigger_TRIGGER_MANUAL,
+			Annotations: []*v1.Annotation{{Key: "nightly", Value: "true"}},
+		},
+		Spec:    &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/nightly.yaml"}},
+		Created: created,
+		NextRun: next,
+	}
+	for _, m := range mod {
+		m(&res)
+	}
+	return res
+}
+
+func createSchedules(t *testing.T, s store.Schedules, schedules ...v1.Schedule) {
+	for _, sched := range schedules {
+		err := s.Create(context.Background(), sched)
+		if err != nil {
+			t.Fatalf("cannot create schedule %s: %v", sched.Name, err)
+		}
+	}
+}
+
+func getSchedule(t *testing.T, s store.Schedules, name string) *v1.Schedule {
+	res, err := s.Get(context.Background(), name)
+	if err != nil {
+		t.Fatalf("cannot get schedule %s: %v", name, err)
+	}
+	return res
+}
+
+func testScheduleCreateGet(t *testing.T, s store.Schedules) {
+	sched := NewSchedule("nightly", time.Unix(2000, 0))
+	createSchedules(t, s, sched)
+
+	act := getSchedule(t, s, "nightly")
+	if !proto.Equal(act, &sched) {
+		t.Errorf("stored schedule differs from retrieved one: expected %v, got %v", &sched, act)
+	}
+
+	err := s.Create(context.Background(), NewSchedule("nightly", time.Unix(3000, 0)))
+	if err != store.ErrAlreadyExists {
+		t.Errorf("expected ErrAlreadyExists when creating a schedule twice, got %v", err)
+	}
+
+	_, err = s.Get(context.Background(), "does-not-exist")
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for unknown schedule, got %v", err)
+	}
+}
+
+func testScheduleList(t *testing.T, s store.Schedules) {
+	createSchedules(t, s,
+		NewSchedule("b", time.Unix(2000, 0)),
+		NewSchedule("c", time.Unix(2000, 0)),
+		NewSchedule("a", time.Unix(2000, 0)),
+	)
+
+	res, err := s.List(context.Background())
+	if err != nil {
+		t.Fatalf("cannot list schedules: %v", err)
+	}
+	var act []string
+	for _, sched := range res {
+		act = append(act, sched.Name)
+	}
+	if exp := []string{"a", "b", "c"}; fmt.Sprint(act) != fmt.Sprint(exp) {
+		t.Errorf("expected schedules %v, got %v", exp, act)
+	}
+}
+
+func testScheduleDelete(t *testing.T, s store.Schedules) {
+	createSchedules(t, s, NewSchedule("a", time.Unix(2000, 0)), NewSchedule("b", time.Unix(2000, 0)))
+
+	err := s.Delete(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("cannot delete schedule: %v", err)
+	}
+	_, err = s.Get(context.Background(), "a")
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for deleted schedule, got %v", err)
+	}
+	getSchedule(t, s, "b")
+
+	err = s.Delete(context.Background(), "a")
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound when deleting an unknown schedule, got %v", err)
+	}
+
+	// a deleted schedule's name can be used again
+	createSchedules(t, s, NewSchedule("a", time.Unix(2000, 0)))
+}
+
+func testSchedulePause(t *testing.T, s store.Schedules) {
+	ctx := context.Background()
+	createSchedules(t, s, NewSchedule("nightly", time.Unix(2000, 0)))
+
+	err := s.Pause(ctx, "nightly", true, time.Unix(3000, 0))
+	if err != nil {
+		t.Fatalf("cannot pause schedule: %v", err)
+	}
+	act := getSchedule(t, s, "nightly")
+	if !act.Paused || act.NextRun.Seconds != 3000 {
+		t.Errorf("expected paused schedule with next run at 3000, got paused=%v nextRun=%v", act.Paused, act.NextRun)
+	}
+
+	ok, err := s.Claim(ctx, "nightly", time.Unix(3000, 0), time.Unix(4000, 0))
+	if err != nil {
+		t.Fatalf("cannot claim run: %v", err)
+	}
+	if ok {
+		t.Errorf("claimed the run of a paused schedule")
+	}
+
+	err = s.Pause(ctx, "nightly", false, time.Unix(5000, 0))
+	if err != nil {
+		t.Fatalf("cannot resume schedule: %v", err)
+	}
+	act = getSchedule(t, s, "nightly")
+	if act.Paused || act.NextRun.Seconds != 5000 {
+		t.Errorf("expected resumed schedule with next run at 5000, got paused=%v nextRun=%v", act.Paused, act.NextRun)
+	}
+
+	err = s.Pause(ctx, "does-not-exist", true, time.Unix(3000, 0))
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound when pausing an unknown schedule, got %v", err)
+	}
+}
+
+func testScheduleClaim(t *testing.T, s store.Schedules) {
+	ctx := context.Background()
+	createSchedules(t, s, NewSchedule("nightly", time.Unix(2000, 0)))
+
+	ok, err := s.Claim(ctx, "nightly", time.Unix(1000, 0), time.Unix(3000, 0))
+	if err != nil {
+		t.Fatalf("cannot claim run: %v", err)
+	}
+	if ok {
+		t.Errorf("claimed a run which was not due")
+	}
+
+	ok, err = s.Claim(ctx, "nightly", time.Unix(2000, 0), time.Unix(3000, 0))
+	if err != nil {
+		t.Fatalf("cannot claim run: %v", err)
+	}
+	if !ok {
+		t.Fatalf("cannot claim due run")
+	}
+	act := getSchedule(t, s, "nightly")
+	if act.NextRun.Seconds != 3000 || act.LastRun.GetSeconds() != 2000 {
+		t.Errorf("expected next run at 3000 and last run at 2000, got %v and %v", act.NextRun, act.LastRun)
+	}
+
+	ok, err = s.Claim(ctx, "nightly", time.Unix(2000, 0), time.Unix(3000, 0))
+	if err != nil {
+		t.Fatalf("cannot claim run: %v", err)
+	}
+	if ok {
+		t.Errorf("claimed a run twice")
+	}
+
+	ok, err = s.Claim(ctx, "does-not-exist", time.Unix(2000, 0), time.Unix(3000, 0))
+	if err != nil {
+		t.Fatalf("cannot claim run: %v", err)
+	}
+	if ok {
+		t.Errorf("claimed a run of an unknown schedule")
+	}
+}
+
+func testScheduleClaimConcurrently(t *testing.T, s store.Schedules) {
+	createSchedules(t, s, NewSchedule("nightly", time.Unix(2000, 0)))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		claimed int
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := s.Claim(context.Background(), "nightly", time.Unix(2000, 0), time.Unix(3000, 0))
+			if err != nil {
+				t.Errorf("cannot claim run: %v", err)
+				return
+			}
+			if ok {
+				mu.Lock()
+				claimed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if claimed != 1 {
+		t.Errorf("expected the run to be claimed exactly once, was claimed %d times", claimed)
+	}
+}
+
+func testScheduleRecordRun(t *testing.T, s store.Schedules) {
+	ctx := context.Background()
+	createSchedules(t, s, NewSchedule("nightly", time.Unix(2000, 0)))
+
+	err := s.RecordRun(ctx, "nightly", "", "cannot start job")
+	if err != nil {
+		t.Fatalf("cannot record run: %v", err)
+	}
+	act := getSchedule(t, s, "nightly")
+	if act.LastJob != "" || act.LastError != "cannot start job" {
+		t.Errorf("expected last error to be recorded, got job %q and error %q", act.LastJob, act.LastError)
+	}
+
+	// claiming the next run resets the outcome of the last one
+	_, err = s.Claim(ctx, "nightly", time.Unix(2000, 0), time.Unix(3000, 0))
+	if err != nil {
+		t.Fatalf("cannot claim run: %v", err)
+	}
+	err = s.RecordRun(ctx, "nightly", "werft-nightly.1", "")
+	if err != nil {
+		t.Fatalf("cannot record run: %v", err)
+	}
+	act = getSchedule(t, s, "nightly")
+	if act.LastJob != "werft-nightly.1" || act.LastError != "" {
+		t.Errorf("expected last job to be recorded, got job %q and error %q", act.LastJob, act.LastError)
+	}
+
+	err = s.RecordRun(ctx, "does-not-exist", "werft-nightly.1", "")
+	if err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound when recording a run of an unknown schedule, got %v", err)
+	}
+}
@@ -803,6 +803,10 @@ export interface FilterOpMap {
   OP_ENDS_WITH: 2;
   OP_CONTAINS: 3;
   OP_EXISTS: 4;
+  OP_LESS_THAN: 5;
+  OP_GREATER_THAN: 6;
+  OP_REGEX: 7;
+  OP_GLOB: 8;
 }
 
 export const FilterOp: FilterOpMap;
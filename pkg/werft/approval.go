@@ -0,0 +1,108 @@
+package werft
+
+import (
+	"context"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/auth"
+	"github.com/csweichel/werft/pkg/executor"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/golang/protobuf/ptypes"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ApproveJob starts a job which is awaiting approval
+func (srv *Service) ApproveJob(ctx context.Context, req *v1.ApproveJobRequest) (*v1.ApproveJobResponse, error) {
+	err := srv.decideApproval(ctx, req.Name, true, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ApproveJobResponse{}, nil
+}
+
+// RejectJob fails a job which is awaiting approval
+func (srv *Service) RejectJob(ctx context.Context, req *v1.RejectJobRequest) (*v1.RejectJobResponse, error) {
+	err := srv.decideApproval(ctx, req.Name, false, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.RejectJobResponse{}, nil
+}
+
+func (srv *Service) decideApproval(ctx context.Context, name string, approved bool, comment string) error {
+	if name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	job, err := srv.Jobs.Get(ctx, name)
+	if err == store.ErrNotFound {
+		return status.Error(codes.NotFound, "not found")
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if job.Approval == nil {
+		return status.Error(codes.FailedPrecondition, "job does not require approval")
+	}
+	if job.Phase != v1.JobPhase_PHASE_WAITING || !job.Conditions.GetAwaitingApproval() {
+		return status.Error(codes.FailedPrecondition, "job is not awaiting approval")
+	}
+
+	user := auth.AuthResponseFromContext(ctx)
+	if !mayApprove(job.Approval, user) {
+		return status.Error(codes.PermissionDenied, "not allowed to approve this job")
+	}
+
+	decision := &v1.ApprovalDecision{
+		User:     user.Username,
+		Approved: approved,
+		Comment:  comment,
+		Time:     ptypes.TimestampNow(),
+	}
+	err = srv.Executor.Approve(name, decision)
+	if err == executor.ErrNotAwaitingApproval {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	log.WithField("name", name).WithField("user", decision.User).WithField("approved", approved).Info("job approval decided")
+
+	return nil
+}
+
+// mayApprove determines if a user may approve or reject a job. Only authenticated users may ever do so.
+// Jobs which list neither approvers nor teams can be approved by any authenticated user.
+func mayApprove(approval *v1.JobApproval, user *auth.AuthResponse) bool {
+	if user == nil || !user.Known {
+		return false
+	}
+	if len(approval.Approvers) == 0 && len(approval.Teams) == 0 {
+		return true
+	}
+
+	for _, a := range approval.Approvers {
+		if a == user.Username {
+			return true
+		}
+	}
+	for _, t := range approval.Teams {
+		for _, ut := range user.Teams {
+			if t == ut {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jobApproval produces the approval of a job from its spec
+func jobApproval(spec *repoconfig.ApprovalSpec) *v1.JobApproval {
+	return &v1.JobApproval{
+		Approvers: spec.Approvers,
+		Teams:     spec.Teams,
+	}
+}
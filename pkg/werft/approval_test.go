@@ -0,0 +1,161 @@
+package werft
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/auth"
+	"github.com/csweichel/werft/pkg/executor"
+	"github.com/csweichel/werft/pkg/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMayApprove(t *testing.T) {
+	var (
+		alice = &auth.AuthResponse{Known: true, Username: "alice", Teams: []string{"acme/dev"}}
+		bob   = &auth.AuthResponse{Known: true, Username: "bob", Teams: []string{"acme/dev", "acme/ops"}}
+	)
+	tests := []struct {
+		Name        string
+		Approval    *v1.JobApproval
+		User        *auth.AuthResponse
+		Expectation bool
+	}{
+		{"any authenticated user", &v1.JobApproval{}, alice, true},
+		{"anyone unauthenticated", &v1.JobApproval{}, nil, false},
+		{"anyone unknown", &v1.JobApproval{}, &auth.AuthResponse{Username: "alice"}, false},
+		{"approver", &v1.JobApproval{Approvers: []string{"alice"}}, alice, true},
+		{"not an approver", &v1.JobApproval{Approvers: []string{"alice"}}, bob, false},
+		{"team member", &v1.JobApproval{Approvers: []string{"alice"}, Teams: []string{"acme/ops"}}, bob, true},
+		{"not a team member", &v1.JobApproval{Teams: []string{"acme/ops"}}, alice, false},
+		{"unauthenticated", &v1.JobApproval{Approvers: []string{"alice"}}, nil, false},
+		{"unknown user", &v1.JobApproval{Approvers: []string{"alice"}}, &auth.AuthResponse{Username: "alice"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := mayApprove(test.Approval, test.User)
+			if act != test.Expectation {
+				t.Errorf("expected %v, got %v", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestDecideApproval(t *testing.T) {
+	jobs := []v1.JobStatus{
+		{
+			Name:       "deploy",
+			Phase:      v1.JobPhase_PHASE_WAITING,
+			Metadata:   &v1.JobMetadata{Repository: &v1.Repository{}},
+			Conditions: &v1.JobConditions{AwaitingApproval: true},
+			Approval:   &v1.JobApproval{Teams: []string{"acme/ops"}},
+		},
+		{
+			Name:       "build",
+			Phase:      v1.JobPhase_PHASE_RUNNING,
+			Metadata:   &v1.JobMetadata{Repository: &v1.Repository{}},
+			Conditions: &v1.JobConditions{},
+		},
+		{
+			Name:       "approved",
+			Phase:      v1.JobPhase_PHASE_RUNNING,
+			Metadata:   &v1.JobMetadata{Repository: &v1.Repository{}},
+			Conditions: &v1.JobConditions{},
+			Approval:   &v1.JobApproval{Decisions: []*v1.ApprovalDecision{{User: "alice", Approved: true}}},
+		},
+	}
+
+	tests := []struct {
+		Name string
+		Job  string
+		User *auth.AuthResponse
+		Code codes.Code
+	}{
+		{"no name", "", nil, codes.InvalidArgument},
+		{"unknown job", "does-not-exist", nil, codes.NotFound},
+		{"no approval", "build", nil, codes.FailedPrecondition},
+		{"approved already", "approved", nil, codes.FailedPrecondition},
+		{"unauthenticated", "deploy", nil, codes.PermissionDenied},
+		{"not a team member", "deploy", &auth.AuthResponse{Known: true, Username: "alice", Teams: []string{"acme/dev"}}, codes.PermissionDenied},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			srv := &Service{Jobs: store.NewInMemoryJobStore()}
+			for _, job := range jobs {
+				err := srv.Jobs.Store(context.Background(), job)
+				if err != nil {
+					t.Fatalf("cannot store job: %v", err)
+				}
+			}
+
+			ctx := context.Background()
+			if test.User != nil {
+				ctx = auth.WithAuthResponse(ctx, test.User)
+			}
+			_, err := srv.ApproveJob(ctx, &v1.ApproveJobRequest{Name: test.Job})
+			if code := status.Code(err); code != test.Code {
+				t.Errorf("expected code %v, got %v", test.Code, err)
+			}
+		})
+	}
+}
+
+func TestPendingApprovalKeepsSecrets(t *testing.T) {
+	const secretValue = "s3cr3t-t0k3n"
+
+	exec, client := newTestExecutor(t, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy-token", Namespace: "werft", Labels: map[string]string{"werft.dev/templateSecret": "true"}},
+		Data:       map[string][]byte{"token": []byte(secretValue)},
+	})
+
+	srv := &Service{Executor: exec, RepositoryProvider: &testRepositoryProvider{}}
+	md := v1.JobMetadata{
+		Owner:      "csweichel",
+		Repository: &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Ref: "refs/heads/main", Revision: "abc"},
+	}
+	jobYAML := `
+pod:
+  containers:
+  - name: deploy
+    image: alpine
+    env:
+    - name: TOKEN
+      value: {{ secret "deploy-token" }}
+    - name: ENCODED_TOKEN
+      value: {{ secret "deploy-token" | b64enc }}
+`
+	jobspec, _, err := srv.prepareJob(context.Background(), "deploy.1", md, &testRepositoryProvider{}, []byte(jobYAML))
+	if err != nil {
+		t.Fatalf("cannot prepare job: %v", err)
+	}
+	_, err = exec.Start(*jobspec.Pod, md, executor.WithName("deploy.1"), executor.WithApproval(&v1.JobApproval{}))
+	if err != nil {
+		t.Fatalf("cannot start job: %v", err)
+	}
+
+	cms, err := client.CoreV1().ConfigMaps("werft").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("cannot list config maps: %v", err)
+	}
+	for _, cm := range cms.Items {
+		for k, v := range cm.Data {
+			if strings.Contains(v, secretValue) {
+				t.Errorf("config map %s contains the secret value under %s", cm.Name, k)
+			}
+		}
+	}
+	if _, err := client.CoreV1().Secrets("werft").Get(context.Background(), "deploy.1", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the pending job to be kept in a secret: %v", err)
+	}
+
+	// the job must be approvable as soon as it was started
+	err = exec.Approve("deploy.1", &v1.ApprovalDecision{User: "alice", Approved: true})
+	if err != nil {
+		t.Errorf("cannot approve job: %v", err)
+	}
+}
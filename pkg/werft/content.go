@@ -31,6 +31,11 @@ type RepositoryProvider interface {
 	// Implementors can expect the revision of the repo object to be set.
 	RemoteAnnotations(ctx context.Context, repo *v1.Repository) (annotations map[string]string, err error)
 
+	// ChangedFiles lists the files which changed between the base revision and the repo's revision.
+	// If base is empty, the revision's parent is used. Implementors can expect the revision of the repo object to be set.
+	// If not all changed files can be listed, implementors return an error rather than an incomplete list.
+	ChangedFiles(ctx context.Context, repo *v1.Repository, base string) (paths []string, err error)
+
 	// ContentProvider produces a content provider for a particular repo
 	ContentProvider(ctx context.Context, repo *v1.Repository, path ...string) (ContentProvider, error)
 
@@ -56,6 +61,11 @@ func (NoopRepositoryProvider) RemoteAnnotations(ctx context.Context, repo *v1.Re
 	return nil, errNotSupported
 }
 
+// ChangedFiles lists the files which changed between the base revision and the repo's revision.
+func (NoopRepositoryProvider) ChangedFiles(ctx context.Context, repo *v1.Repository, base string) (paths []string, err error) {
+	return nil, errNotSupported
+}
+
 // ContentProvider produces a content provider for a particular repo
 func (NoopRepositoryProvider) ContentProvider(ctx context.Context, repo *v1.Repository, paths ...string) (ContentProvider, error) {
 	return nil, errNotSupported
@@ -0,0 +1,235 @@
+package werft
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/reporef"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+)
+
+const keyExtends = "extends"
+
+// hasExtends finds top-level extends keys without parsing the job spec, which might not be valid YAML prior to templating
+var hasExtends = regexp.MustCompile(`(?m)^extends\s*:`)
+
+// jobSpecRef points to a job spec file in a repository
+type jobSpecRef struct {
+	Repo *v1.Repository
+	Path string
+}
+
+func (r jobSpecRef) String() string {
+	if r.Repo == nil {
+		return r.Path
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", r.Repo.Host, r.Repo.Owner, r.Repo.Repo, strings.TrimPrefix(r.Path, "/"))
+}
+
+// jobSpecLoader loads the content of a job spec file
+type jobSpecLoader func(ctx context.Context, ref jobSpecRef) ([]byte, error)
+
+// resolveExtends merges the job specs a job extends into its job spec. The job spec was loaded from path in repo,
+// or was provided directly if path is empty.
+func (srv *Service) resolveExtends(ctx context.Context, repo *v1.Repository, path string, jobYAML []byte) ([]byte, error) {
+	return resolveExtends(ctx, jobSpecRef{Repo: repo, Path: path}, jobYAML, srv.loadJobSpec)
+}
+
+// loadJobSpec downloads a job spec file using the repository provider
+func (srv *Service) loadJobSpec(ctx context.Context, ref jobSpecRef) ([]byte, error) {
+	if ref.Repo == nil {
+		return nil, xerrors.Errorf("no repository to load %s from", ref.Path)
+	}
+
+	repo := *ref.Repo
+	if repo.Revision == "" {
+		err := srv.RepositoryProvider.Resolve(ctx, &repo)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot resolve %s: %w", ref.String(), err)
+		}
+	}
+	fp, err := srv.RepositoryProvider.FileProvider(ctx, &repo)
+	if err != nil {
+		return nil, err
+	}
+	in, err := fp.Download(ctx, ref.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	return ioutil.ReadAll(in)
+}
+
+// resolveExtends merges the job specs a job spec extends into it. Job specs which do not extend
+// another one are returned as they are.
+func resolveExtends(ctx context.Context, ref jobSpecRef, jobYAML []byte, load jobSpecLoader) ([]byte, error) {
+	if !hasExtends.Match(jobYAML) {
+		return jobYAML, nil
+	}
+
+	res, err := resolveExtendsNode(ctx, ref, jobYAML, load, []string{ref.String()})
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(res)
+}
+
+func resolveExtendsNode(ctx context.Context, ref jobSpecRef, jobYAML []byte, load jobSpecLoader, chain []string) (*yaml.Node, error) {
+	var doc yaml.Node
+	err := yaml.Unmarshal(jobYAML, &doc)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot parse %s - job specs which extend others must be valid YAML prior to templating: %w", ref.String(), err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, xerrors.Errorf("%s is not a valid job spec", ref.String())
+	}
+	root := doc.Content[0]
+
+	idx := mappingIndex(root, keyExtends)
+	if idx < 0 {
+		return root, nil
+	}
+	parent, err := parseExtends(ref, root.Content[idx+1])
+	if err != nil {
+		return nil, xerrors.Errorf("invalid extends in %s: %w", ref.String(), err)
+	}
+	root.Content = append(root.Content[:idx:idx], root.Content[idx+2:]...)
+
+	id := parent.String()
+	for _, c := range chain {
+		if c == id {
+			return nil, xerrors.Errorf("job spec extends itself: %s -> %s", strings.Join(chain, " -> "), id)
+		}
+	}
+
+	content, err := load(ctx, parent)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot load %s which %s extends: %w", id, ref.String(), err)
+	}
+	base, err := resolveExtendsNode(ctx, parent, content, load, append(chain, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeYAML(base, root), nil
+}
+
+// parseExtends interprets the value of an extends field. This is either a path in the same repository,
+// or a repository in reporef syntax and a path within that repository.
+func parseExtends(ref jobSpecRef, nde *yaml.Node) (jobSpecRef, error) {
+	switch nde.Kind {
+	case yaml.ScalarNode:
+		if nde.Value == "" {
+			return jobSpecRef{}, xerrors.Errorf("path must not be empty")
+		}
+		return jobSpecRef{Repo: ref.Repo, Path: nde.Value}, nil
+	case yaml.MappingNode:
+		var spec struct {
+			Repo string `yaml:"repo"`
+			Path string `yaml:"path"`
+		}
+		err := nde.Decode(&spec)
+		if err != nil {
+			return jobSpecRef{}, err
+		}
+		if spec.Path == "" {
+			return jobSpecRef{}, xerrors.Errorf("path must not be empty")
+		}
+		if spec.Repo == "" {
+			return jobSpecRef{Repo: ref.Repo, Path: spec.Path}, nil
+		}
+
+		repo, err := reporef.Parse(spec.Repo)
+		if err != nil {
+			return jobSpecRef{}, xerrors.Errorf("cannot parse repo %s: %w", spec.Repo, err)
+		}
+		if repo.Ref == "" && repo.Revision == "" {
+			return jobSpecRef{}, xerrors.Errorf("repo %s must name a ref or revision", spec.Repo)
+		}
+		if repo.Host == "" && ref.Repo != nil {
+			repo.Host = ref.Repo.Host
+		}
+		return jobSpecRef{Repo: repo, Path: spec.Path}, nil
+	default:
+		return jobSpecRef{}, xerrors.Errorf("expected a path or a repo and path")
+	}
+}
+
+// mergeYAML deep-merges override into base. Values of override take precedence, mappings are merged
+// key by key and lists of named elements (e.g. containers or env vars) are merged element by element.
+// All other lists are replaced.
+func mergeYAML(base, override *yaml.Node) *yaml.Node {
+	switch {
+	case base.Kind == yaml.MappingNode && override.Kind == yaml.MappingNode:
+		res := *base
+		res.Content = append([]*yaml.Node(nil), base.Content...)
+		for i := 0; i+1 < len(override.Content); i += 2 {
+			key, val := override.Content[i], override.Content[i+1]
+			idx := mappingIndex(&res, key.Value)
+			if idx < 0 {
+				res.Content = append(res.Content, key, val)
+				continue
+			}
+			res.Content[idx+1] = mergeYAML(res.Content[idx+1], val)
+		}
+		return &res
+	case base.Kind == yaml.SequenceNode && override.Kind == yaml.SequenceNode && isNamedList(base) && isNamedList(override):
+		res := *base
+		res.Content = append([]*yaml.Node(nil), base.Content...)
+		for _, elem := range override.Content {
+			idx := namedListIndex(&res, elementName(elem))
+			if idx < 0 {
+				res.Content = append(res.Content, elem)
+				continue
+			}
+			res.Content[idx] = mergeYAML(res.Content[idx], elem)
+		}
+		return &res
+	default:
+		return override
+	}
+}
+
+func mappingIndex(nde *yaml.Node, key string) int {
+	for i := 0; i+1 < len(nde.Content); i += 2 {
+		if nde.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func isNamedList(nde *yaml.Node) bool {
+	for _, elem := range nde.Content {
+		if elementName(elem) == "" {
+			return false
+		}
+	}
+	return len(nde.Content) > 0
+}
+
+func namedListIndex(nde *yaml.Node, name string) int {
+	for i, elem := range nde.Content {
+		if elementName(elem) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func elementName(nde *yaml.Node) string {
+	if nde.Kind != yaml.MappingNode {
+		return ""
+	}
+	idx := mappingIndex(nde, "name")
+	if idx < 0 {
+		return ""
+	}
+	return nde.Content[idx+1].Value
+}
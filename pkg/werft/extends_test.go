@@ -0,0 +1,162 @@
+package werft
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+)
+
+func TestResolveExtends(t *testing.T) {
+	repo := &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Revision: "abc"}
+	files := map[string]string{
+		"github.com/csweichel/werft/.werft/base.yaml": `
+pod:
+  serviceAccount: werft
+  containers:
+  - name: build
+    image: golang
+    env:
+    - name: FOO
+      value: foo
+    - name: BAR
+      value: "{{ .Name }}"
+  - name: sidecar
+    image: redis
+sidecars: ["sidecar"]
+`,
+		"github.com/csweichel/werft/.werft/middle.yaml": `
+extends: .werft/base.yaml
+description: middle
+`,
+		"github.com/csweichel/templates/go.yaml": `
+pod:
+  containers:
+  - name: build
+    image: golang:1.16
+`,
+		"github.com/csweichel/werft/.werft/cycle-a.yaml": "extends: .werft/cycle-b.yaml",
+		"github.com/csweichel/werft/.werft/cycle-b.yaml": "extends: .werft/cycle-a.yaml",
+	}
+	load := func(ctx context.Context, ref jobSpecRef) ([]byte, error) {
+		if ref.Repo.Owner == "csweichel" && ref.Repo.Repo == "templates" && ref.Repo.Ref != "main" {
+			return nil, xerrors.Errorf("unexpected ref %s", ref.Repo.Ref)
+		}
+		c, ok := files[ref.String()]
+		if !ok {
+			return nil, xerrors.Errorf("not found")
+		}
+		return []byte(c), nil
+	}
+
+	type Expectation struct {
+		YAML  map[string]interface{}
+		Error string
+	}
+	tests := []struct {
+		Name        string
+		Input       string
+		Expectation Expectation
+	}{
+		{
+			Name:  "no extends",
+			Input: "pod: {{ .Name }}",
+			Expectation: Expectation{
+				YAML: nil,
+			},
+		},
+		{
+			Name: "merge",
+			Input: `
+extends: .werft/middle.yaml
+pod:
+  containers:
+  - name: build
+    command: ["make"]
+    env:
+    - name: FOO
+      value: bar
+  - name: other
+    image: alpine
+sidecars: []
+`,
+			Expectation: Expectation{YAML: map[string]interface{}{
+				"description": "middle",
+				"pod": map[string]interface{}{
+					"serviceAccount": "werft",
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":    "build",
+							"image":   "golang",
+							"command": []interface{}{"make"},
+							"env": []interface{}{
+								map[string]interface{}{"name": "FOO", "value": "bar"},
+								map[string]interface{}{"name": "BAR", "value": "{{ .Name }}"},
+							},
+						},
+						map[string]interface{}{"name": "sidecar", "image": "redis"},
+						map[string]interface{}{"name": "other", "image": "alpine"},
+					},
+				},
+				"sidecars": []interface{}{},
+			}},
+		},
+		{
+			Name: "other repo",
+			Input: `
+extends:
+  repo: csweichel/templates:main
+  path: go.yaml
+`,
+			Expectation: Expectation{YAML: map[string]interface{}{
+				"pod": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "build", "image": "golang:1.16"},
+					},
+				},
+			}},
+		},
+		{
+			Name:        "other repo without ref",
+			Input:       "extends: {repo: csweichel/templates, path: go.yaml}",
+			Expectation: Expectation{Error: "invalid extends in github.com/csweichel/werft/.werft/job.yaml: repo csweichel/templates must name a ref or revision"},
+		},
+		{
+			Name:        "missing file",
+			Input:       "extends: .werft/missing.yaml",
+			Expectation: Expectation{Error: "cannot load github.com/csweichel/werft/.werft/missing.yaml which github.com/csweichel/werft/.werft/job.yaml extends: not found"},
+		},
+		{
+			Name:        "cycle",
+			Input:       "extends: .werft/cycle-a.yaml",
+			Expectation: Expectation{Error: "job spec extends itself: github.com/csweichel/werft/.werft/job.yaml -> github.com/csweichel/werft/.werft/cycle-a.yaml -> github.com/csweichel/werft/.werft/cycle-b.yaml -> github.com/csweichel/werft/.werft/cycle-a.yaml"},
+		},
+		{
+			Name:        "self",
+			Input:       "extends: .werft/job.yaml",
+			Expectation: Expectation{Error: "job spec extends itself: github.com/csweichel/werft/.werft/job.yaml -> github.com/csweichel/werft/.werft/job.yaml"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var act Expectation
+			res, err := resolveExtends(context.Background(), jobSpecRef{Repo: repo, Path: ".werft/job.yaml"}, []byte(test.Input), load)
+			if err != nil {
+				act.Error = err.Error()
+			} else if string(res) != test.Input {
+				err = yaml.Unmarshal(res, &act.YAML)
+				if err != nil {
+					t.Fatalf("cannot unmarshal result: %v", err)
+				}
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("resolveExtends() mismatch (-want +got):\n%s", strings.TrimSpace(diff))
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package werft
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/executor"
+	"github.com/csweichel/werft/pkg/filterexpr"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// RetentionRule determines how long the jobs matching a filter expression are kept
+type RetentionRule struct {
+	// Matches is a filter expression which selects the jobs this rule applies to,
+	// e.g. "repo.ref |= refs/tags/". An empty expression matches all jobs.
+	Matches string `yaml:"matches,omitempty"`
+
+	// KeepFor is the duration for which matching jobs and their logs are kept.
+	// If KeepFor is not set, matching jobs are kept forever.
+	KeepFor *executor.Duration `yaml:"keepFor,omitempty"`
+
+	// KeepLast keeps the most recent matching jobs of each repository branch, regardless of their age.
+	KeepLast int `yaml:"keepLast,omitempty"`
+}
+
+type retentionRule struct {
+	RetentionRule
+	filter []*v1.FilterExpression
+}
+
+// compileRetentionRules parses the filter expressions of all retention rules
+func compileRetentionRules(rules []RetentionRule) ([]retentionRule, error) {
+	res := make([]retentionRule, len(rules))
+	for i, r := range rules {
+		filter, err := filterexpr.ParseExpression(r.Matches)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid retention rule %d: %w", i, err)
+		}
+		if r.KeepLast < 0 {
+			return nil, xerrors.Errorf("invalid retention rule %d: keepLast must not be negative", i)
+		}
+		res[i] = retentionRule{RetentionRule: r, filter: filter}
+	}
+	return res, nil
+}
+
+type retentionKey struct {
+	Rule                   int
+	Host, Owner, Repo, Ref string
+}
+
+// retentionPageSize is the number of jobs applyRetention loads at once
+const retentionPageSize = 500
+
+// retentionTracker determines which jobs are no longer retained. Jobs are passed in pages ordered by creation time,
+// newest first, so that applying retention doesn't have to load all jobs at once.
+type retentionTracker struct {
+	rules    []retentionRule
+	fallback *time.Duration
+	now      time.Time
+
+	seen map[retentionKey]int
+	last *v1.JobStatus
+}
+
+func newRetentionTracker(rules []retentionRule, fallback *time.Duration, now time.Time) *retentionTracker {
+	return &retentionTracker{
+		rules:    rules,
+		fallback: fallback,
+		now:      now,
+		seen:     make(map[retentionKey]int),
+	}
+}
+
+// expiredJobs returns the names of all jobs which are no longer retained. Jobs must be ordered by creation time,
+// newest first. Pinned jobs are always retained. The first rule matching a job determines its retention.
+// Jobs which match no rule are kept for fallback, or forever if fallback is nil.
+func expiredJobs(jobs []v1.JobStatus, rules []retentionRule, fallback *time.Duration, now time.Time) []string {
+	return newRetentionTracker(rules, fallback, now).Expired(jobs)
+}
+
+// Expired returns the names of all jobs of the page which are no longer retained, see expiredJobs.
+// Jobs which do not come after the last job of the previous page, e.g. because a job finished
+// in between and moved the page boundary, are skipped so that they don't count twice towards keepLast.
+func (rt *retentionTracker) Expired(jobs []v1.JobStatus) []string {
+	var res []string
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Phase != v1.JobPhase_PHASE_DONE || job.Pinned || job.Metadata == nil || job.Metadata.Created == nil {
+			continue
+		}
+		if rt.last != nil && !newestFirstAfter(job, rt.last) {
+			continue
+		}
+		rt.last = job
+		created := time.Unix(job.Metadata.Created.Seconds, int64(job.Metadata.Created.Nanos))
+
+		keepFor := rt.fallback
+		for ri, r := range rt.rules {
+			if !filterexpr.MatchesFilter(job, r.filter) {
+				continue
+			}
+
+			key := retentionKey{Rule: ri}
+			if repo := job.Metadata.Repository; repo != nil {
+				key.Host, key.Owner, key.Repo, key.Ref = repo.Host, repo.Owner, repo.Repo, repo.Ref
+			}
+			rt.seen[key]++
+
+			keepFor = nil
+			if r.KeepFor != nil && rt.seen[key] > r.KeepLast {
+				keepFor = &r.KeepFor.Duration
+			}
+			break
+		}
+
+		if keepFor != nil && rt.now.Sub(created) > *keepFor {
+			res = append(res, job.Name)
+		}
+	}
+	return res
+}
+
+// newestFirstAfter returns true if job a comes after b in the order the job stores produce for
+// newest first, i.e. a was created before b, or in the same second with a greater name.
+func newestFirstAfter(a, b *v1.JobStatus) bool {
+	if a.Metadata.Created.Seconds != b.Metadata.Created.Seconds {
+		return a.Metadata.Created.Seconds < b.Metadata.Created.Seconds
+	}
+	return a.Name > b.Name
+}
+
+// applyRetention deletes all jobs and their logs which are no longer retained according to the retention rules
+func (srv *Service) applyRetention(ctx context.Context, rules []retentionRule) error {
+	var fallback *time.Duration
+	if srv.Config.GCOlderThan != nil {
+		fallback = &srv.Config.GCOlderThan.Duration
+	}
+
+	var (
+		filter = []*v1.FilterExpression{
+			{Terms: []*v1.FilterTerm{{Field: "phase", Value: "done", Operation: v1.FilterOp_OP_EQUALS}}},
+			{Terms: []*v1.FilterTerm{{Field: "pinned", Value: "true", Operation: v1.FilterOp_OP_EQUALS, Negate: true}}},
+		}
+		order   = []*v1.OrderExpression{{Field: "created", Ascending: false}}
+		tracker = newRetentionTracker(rules, fallback, time.Now())
+		expired []string
+	)
+	// we delete only once we've seen all jobs, as deleting them would move the pages
+	for start := 0; ; start += retentionPageSize {
+		jobs, total, err := srv.Jobs.Find(ctx, filter, order, start, retentionPageSize)
+		if err != nil {
+			return err
+		}
+		expired = append(expired, tracker.Expired(jobs)...)
+		if len(jobs) < retentionPageSize || start+len(jobs) >= total {
+			break
+		}
+	}
+
+	log.WithField("count", len(expired)).Info("deleting jobs which are no longer retained")
+	for _, name := range expired {
+		// we delete the log first so that we never leave logs behind which no job refers to
+		err := srv.Logs.Delete(name)
+		if err != nil {
+			log.WithError(err).WithField("name", name).Warn("cannot delete job log")
+			continue
+		}
+		err = srv.Jobs.Delete(ctx, name)
+		if err != nil {
+			log.WithError(err).WithField("name", name).Warn("cannot delete job")
+		}
+	}
+	return nil
+}
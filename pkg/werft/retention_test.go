@@ -0,0 +1,214 @@
+package werft
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/executor"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/csweichel/werft/pkg/store/storetest"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func TestExpiredJobs(t *testing.T) {
+	now := time.Now()
+	job := func(name, ref string, age time.Duration, success bool) v1.JobStatus {
+		created, _ := ptypes.TimestampProto(now.Add(-age))
+		return v1.JobStatus{
+			Name: name,
+			Metadata: &v1.JobMetadata{
+				Repository: &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Ref: ref},
+				Created:    created,
+			},
+			Phase:      v1.JobPhase_PHASE_DONE,
+			Conditions: &v1.JobConditions{Success: success},
+		}
+	}
+	day := 24 * time.Hour
+	rules, err := compileRetentionRules([]RetentionRule{
+		{Matches: "repo.ref |= refs/tags/"},
+		{Matches: "repo.ref == refs/heads/main", KeepFor: &executor.Duration{Duration: 90 * day}, KeepLast: 1},
+		{Matches: "success == false", KeepFor: &executor.Duration{Duration: 7 * day}},
+	})
+	if err != nil {
+		t.Fatalf("cannot compile retention rules: %v", err)
+	}
+	fallback := 30 * day
+
+	tests := []struct {
+		Name     string
+		Jobs     []v1.JobStatus
+		Rules    []retentionRule
+		Fallback *time.Duration
+		Expired  []string
+	}{
+		{
+			Name:    "tags are kept forever",
+			Jobs:    []v1.JobStatus{job("tag", "refs/tags/v1.0", 1000*day, true)},
+			Rules:   rules,
+			Expired: nil,
+		},
+		{
+			Name: "main is kept for 90 days",
+			Jobs: []v1.JobStatus{
+				job("main-new", "refs/heads/main", 10*day, true),
+				job("main-old", "refs/heads/main", 100*day, false),
+			},
+			Rules:   rules,
+			Expired: []string{"main-old"},
+		},
+		{
+			Name:    "keep last",
+			Jobs:    []v1.JobStatus{job("main-old", "refs/heads/main", 100*day, true)},
+			Rules:   rules,
+			Expired: nil,
+		},
+		{
+			Name: "failed branch builds",
+			Jobs: []v1.JobStatus{
+				job("failed-new", "refs/heads/foo", 1*day, false),
+				job("failed-old", "refs/heads/foo", 8*day, false),
+				job("success", "refs/heads/foo", 8*day, true),
+			},
+			Rules:   rules,
+			Expired: []string{"failed-old"},
+		},
+		{
+			Name: "fallback",
+			Jobs: []v1.JobStatus{
+				job("new", "refs/heads/foo", 1*day, true),
+				job("old", "refs/heads/foo", 31*day, true),
+			},
+			Rules:    rules,
+			Fallback: &fallback,
+			Expired:  []string{"old"},
+		},
+		{
+			Name: "pinned jobs are kept",
+			Jobs: []v1.JobStatus{
+				func() v1.JobStatus {
+					j := job("pinned", "refs/heads/foo", 31*day, false)
+					j.Pinned = true
+					return j
+				}(),
+			},
+			Rules:    rules,
+			Fallback: &fallback,
+			Expired:  nil,
+		},
+		{
+			Name: "unfinished jobs are kept",
+			Jobs: []v1.JobStatus{
+				func() v1.JobStatus {
+					j := job("running", "refs/heads/foo", 31*day, false)
+					j.Phase = v1.JobPhase_PHASE_RUNNING
+					return j
+				}(),
+			},
+			Rules:    rules,
+			Fallback: &fallback,
+			Expired:  nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := expiredJobs(test.Jobs, test.Rules, test.Fallback, now)
+			if fmt.Sprint(act) != fmt.Sprint(test.Expired) {
+				t.Errorf("expected %v, got %v", test.Expired, act)
+			}
+		})
+	}
+}
+
+func TestRetentionTrackerShiftedPages(t *testing.T) {
+	now := time.Now()
+	job := func(name string, age time.Duration) v1.JobStatus {
+		created, _ := ptypes.TimestampProto(now.Add(-age))
+		return v1.JobStatus{
+			Name:     name,
+			Metadata: &v1.JobMetadata{Repository: &v1.Repository{Ref: "refs/heads/main"}, Created: created},
+			Phase:    v1.JobPhase_PHASE_DONE,
+		}
+	}
+	rules, err := compileRetentionRules([]RetentionRule{{KeepFor: &executor.Duration{Duration: time.Hour}, KeepLast: 2}})
+	if err != nil {
+		t.Fatalf("cannot compile retention rules: %v", err)
+	}
+
+	// a job which finished after the first page was loaded moves "b" onto the second page as well
+	tracker := newRetentionTracker(rules, nil, now)
+	first := tracker.Expired([]v1.JobStatus{job("a", 2*time.Hour), job("b", 3*time.Hour)})
+	second := tracker.Expired([]v1.JobStatus{job("b", 3*time.Hour), job("c", 4*time.Hour)})
+	if act := fmt.Sprint(append(first, second...)); act != "[c]" {
+		t.Errorf("expected only c to expire, got %s", act)
+	}
+}
+
+func TestApplyRetentionPaging(t *testing.T) {
+	ctx := context.Background()
+	logs, err := store.NewFileLogStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create log store: %v", err)
+	}
+	srv := &Service{
+		Jobs: store.NewInMemoryJobStore(),
+		Logs: logs,
+	}
+
+	// more jobs than fit on a page, so that keepLast has to count across pages
+	n := 2*retentionPageSize + 100
+	old := time.Now().Add(-48 * time.Hour)
+	for i := 0; i < n; i++ {
+		job := storetest.NewJob(fmt.Sprintf("job-%04d", i), old.Add(time.Duration(i)*time.Second))
+		err := srv.Jobs.Store(ctx, job)
+		if err != nil {
+			t.Fatalf("cannot store job: %v", err)
+		}
+	}
+	err = srv.Jobs.Pin(ctx, "job-0000", true)
+	if err != nil {
+		t.Fatalf("cannot pin job: %v", err)
+	}
+
+	rules, err := compileRetentionRules([]RetentionRule{
+		{KeepFor: &executor.Duration{Duration: time.Hour}, KeepLast: 2 * retentionPageSize},
+	})
+	if err != nil {
+		t.Fatalf("cannot compile retention rules: %v", err)
+	}
+	err = srv.applyRetention(ctx, rules)
+	if err != nil {
+		t.Fatalf("cannot apply retention: %v", err)
+	}
+
+	// the newest jobs are kept because of keepLast, the oldest one because it's pinned
+	_, total, err := srv.Jobs.Find(ctx, nil, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("cannot find jobs: %v", err)
+	}
+	if exp := 2*retentionPageSize + 1; total != exp {
+		t.Errorf("expected %d jobs to be retained, got %d", exp, total)
+	}
+	for _, name := range []string{"job-0000", fmt.Sprintf("job-%04d", n-1), fmt.Sprintf("job-%04d", n-2*retentionPageSize)} {
+		if _, err := srv.Jobs.Get(ctx, name); err != nil {
+			t.Errorf("expected %s to be retained: %v", name, err)
+		}
+	}
+	if _, err := srv.Jobs.Get(ctx, fmt.Sprintf("job-%04d", n-2*retentionPageSize-1)); err != store.ErrNotFound {
+		t.Errorf("expected job-%04d to be deleted, got %v", n-2*retentionPageSize-1, err)
+	}
+}
+
+func TestCompileRetentionRules(t *testing.T) {
+	_, err := compileRetentionRules([]RetentionRule{{Matches: "repo.ref =="}})
+	if err == nil {
+		t.Errorf("expected error for invalid filter expression")
+	}
+	_, err = compileRetentionRules([]RetentionRule{{KeepLast: -1}})
+	if err == nil {
+		t.Errorf("expected error for negative keepLast")
+	}
+}
@@ -0,0 +1,220 @@
+package werft
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/auth"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	cron "github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scheduleInterval is the time between two checks for due schedules
+const scheduleInterval = 10 * time.Second
+
+// CreateSchedule creates a schedule which starts a job regularly
+func (srv *Service) CreateSchedule(ctx context.Context, req *v1.CreateScheduleRequest) (*v1.CreateScheduleResponse, error) {
+	if srv.Schedules == nil {
+		return nil, status.Error(codes.Unimplemented, "schedules are not available")
+	}
+
+	s := req.Schedule
+	if s == nil || s.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "schedule name is required")
+	}
+	if s.Metadata == nil || s.Metadata.Repository == nil {
+		return nil, status.Error(codes.InvalidArgument, "metadata and repository are required")
+	}
+	if s.Spec == nil {
+		return nil, status.Error(codes.InvalidArgument, "spec is required")
+	}
+	sched, err := cron.ParseStandard(s.Cron)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid cron expression %q: %v", s.Cron, err)
+	}
+
+	// jobs started by a schedule run on behalf of whoever created it, hence we must not trust the owner in the request
+	md := proto.Clone(s.Metadata).(*v1.JobMetadata)
+	if user := auth.AuthResponseFromContext(ctx); user != nil {
+		if !user.Known {
+			return nil, status.Error(codes.PermissionDenied, "only known users can create schedules")
+		}
+		md.Owner = user.Username
+	}
+
+	now := time.Now()
+	s = &v1.Schedule{
+		Name:     s.Name,
+		Cron:     s.Cron,
+		Metadata: md,
+		Spec:     s.Spec,
+		Paused:   s.Paused,
+	}
+	s.Created, err = ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.NextRun, err = ptypes.TimestampProto(sched.Next(now))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	err = srv.Schedules.Create(ctx, *s)
+	if err == store.ErrAlreadyExists {
+		return nil, status.Errorf(codes.AlreadyExists, "schedule %s exists already", s.Name)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.WithField("name", s.Name).WithField("cron", s.Cron).Info("schedule created")
+
+	return &v1.CreateScheduleResponse{Schedule: s}, nil
+}
+
+// ListSchedules lists all schedules
+func (srv *Service) ListSchedules(ctx context.Context, req *v1.ListSchedulesRequest) (*v1.ListSchedulesResponse, error) {
+	if srv.Schedules == nil {
+		return nil, status.Error(codes.Unimplemented, "schedules are not available")
+	}
+
+	res, err := srv.Schedules.List(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &v1.ListSchedulesResponse{Schedules: res}, nil
+}
+
+// DeleteSchedule removes a schedule
+func (srv *Service) DeleteSchedule(ctx context.Context, req *v1.DeleteScheduleRequest) (*v1.DeleteScheduleResponse, error) {
+	if srv.Schedules == nil {
+		return nil, status.Error(codes.Unimplemented, "schedules are not available")
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	err := srv.Schedules.Delete(ctx, req.Name)
+	if err == store.ErrNotFound {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.WithField("name", req.Name).Info("schedule deleted")
+
+	return &v1.DeleteScheduleResponse{}, nil
+}
+
+// PauseSchedule pauses or resumes a schedule
+func (srv *Service) PauseSchedule(ctx context.Context, req *v1.PauseScheduleRequest) (*v1.PauseScheduleResponse, error) {
+	if srv.Schedules == nil {
+		return nil, status.Error(codes.Unimplemented, "schedules are not available")
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	s, err := srv.Schedules.Get(ctx, req.Name)
+	if err == store.ErrNotFound {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	sched, err := cron.ParseStandard(s.Cron)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid cron expression %q: %v", s.Cron, err)
+	}
+
+	// resumed schedules continue with their next regular run, i.e. don't start the runs they missed
+	err = srv.Schedules.Pause(ctx, req.Name, req.Paused, sched.Next(time.Now()))
+	if err == store.ErrNotFound {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.WithField("name", req.Name).WithField("paused", req.Paused).Info("schedule paused")
+
+	s, err = srv.Schedules.Get(ctx, req.Name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &v1.PauseScheduleResponse{Schedule: s}, nil
+}
+
+// runSchedules regularly starts the jobs of all due schedules
+func (srv *Service) runSchedules() {
+	tick := time.NewTicker(scheduleInterval)
+	for now := range tick.C {
+		srv.startDueSchedules(context.Background(), now)
+	}
+}
+
+// startDueSchedules starts a job for each schedule whose next run is due. Each run is claimed in the
+// schedule store before the job starts, so that no run starts twice - not even when werft restarts or several
+// werft instances share the store. Runs which were missed, e.g. because werft was down, start once.
+func (srv *Service) startDueSchedules(ctx context.Context, now time.Time) {
+	schedules, err := srv.Schedules.List(ctx)
+	if err != nil {
+		log.WithError(err).Warn("cannot list schedules")
+		return
+	}
+
+	for _, s := range schedules {
+		if s.Paused || s.NextRun == nil || s.NextRun.Seconds > now.Unix() {
+			continue
+		}
+
+		log := log.WithField("schedule", s.Name)
+		sched, err := cron.ParseStandard(s.Cron)
+		if err != nil {
+			log.WithError(err).Warn("schedule has an invalid cron expression")
+			continue
+		}
+		ok, err := srv.Schedules.Claim(ctx, s.Name, time.Unix(s.NextRun.Seconds, 0), sched.Next(now))
+		if err != nil {
+			log.WithError(err).Warn("cannot claim schedule run")
+			continue
+		}
+		if !ok {
+			// some other werft instance started this run already
+			continue
+		}
+
+		var jobName, errMsg string
+		resp, err := srv.StartJob2(ctx, scheduledJobRequest(s))
+		if err != nil {
+			errMsg = status.Convert(err).Message()
+			log.WithError(err).Warn("cannot start scheduled job")
+		} else {
+			jobName = resp.Status.Name
+			log.WithField("job", jobName).Info("started scheduled job")
+		}
+
+		err = srv.Schedules.RecordRun(ctx, s.Name, jobName, errMsg)
+		if err != nil {
+			log.WithError(err).Warn("cannot record schedule run")
+		}
+	}
+}
+
+// scheduledJobRequest produces the request to start the job of a schedule
+func scheduledJobRequest(s *v1.Schedule) *v1.StartJobRequest2 {
+	md := proto.Clone(s.Metadata).(*v1.JobMetadata)
+	// scheduled jobs run on the latest revision of their ref
+	md.Repository.Revision = ""
+	if md.Trigger == v1.JobTrigger_TRIGGER_UNKNOWN {
+		md.Trigger = v1.JobTrigger_TRIGGER_MANUAL
+	}
+	return &v1.StartJobRequest2{
+		Metadata: md,
+		Spec:     proto.Clone(s.Spec).(*v1.JobSpec),
+	}
+}
@@ -0,0 +1,161 @@
+package werft
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/auth"
+	"github.com/csweichel/werft/pkg/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestSchedule(name, cron string) *v1.Schedule {
+	return &v1.Schedule{
+		Name: name,
+		Cron: cron,
+		Metadata: &v1.JobMetadata{
+			Owner:      "csweichel",
+			Repository: &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Ref: "refs/heads/main"},
+		},
+		Spec: &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/missing.yaml"}},
+	}
+}
+
+func TestCreateSchedule(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Schedule *v1.Schedule
+		Code     codes.Code
+	}{
+		{"valid", newTestSchedule("nightly", "@daily"), codes.OK},
+		{"exists already", newTestSchedule("existing", "@daily"), codes.AlreadyExists},
+		{"no name", newTestSchedule("", "@daily"), codes.InvalidArgument},
+		{"invalid cron", newTestSchedule("nightly", "every day"), codes.InvalidArgument},
+		{"no spec", &v1.Schedule{Name: "nightly", Cron: "@daily", Metadata: newTestSchedule("", "").Metadata}, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			srv := &Service{Schedules: store.NewInMemoryScheduleStore()}
+			_, err := srv.CreateSchedule(context.Background(), &v1.CreateScheduleRequest{Schedule: newTestSchedule("existing", "@daily")})
+			if err != nil {
+				t.Fatalf("cannot create schedule: %v", err)
+			}
+
+			resp, err := srv.CreateSchedule(context.Background(), &v1.CreateScheduleRequest{Schedule: test.Schedule})
+			if code := status.Code(err); code != test.Code {
+				t.Fatalf("expected code %v, got %v", test.Code, err)
+			}
+			if err != nil {
+				return
+			}
+			if resp.Schedule.NextRun == nil || resp.Schedule.Created == nil {
+				t.Errorf("expected next run and creation time to be set, got %v", resp.Schedule)
+			}
+		})
+	}
+}
+
+func TestCreateScheduleOwner(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Auth  *auth.AuthResponse
+		Owner string
+		Code  codes.Code
+	}{
+		{"no auth", nil, "csweichel", codes.OK},
+		{"known user", &auth.AuthResponse{Known: true, Username: "someone-else"}, "someone-else", codes.OK},
+		{"unknown user", &auth.AuthResponse{Known: false, Username: "anonymous"}, "", codes.PermissionDenied},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.Auth != nil {
+				ctx = auth.WithAuthResponse(ctx, test.Auth)
+			}
+			srv := &Service{Schedules: store.NewInMemoryScheduleStore()}
+			req := newTestSchedule("nightly", "@daily")
+			resp, err := srv.CreateSchedule(ctx, &v1.CreateScheduleRequest{Schedule: req})
+			if code := status.Code(err); code != test.Code {
+				t.Fatalf("expected code %v, got %v", test.Code, err)
+			}
+			if err != nil {
+				return
+			}
+			if resp.Schedule.Metadata.Owner != test.Owner {
+				t.Errorf("expected owner %q, got %q", test.Owner, resp.Schedule.Metadata.Owner)
+			}
+			if req.Metadata.Owner != "csweichel" {
+				t.Errorf("request metadata was modified")
+			}
+			stored, err := srv.Schedules.Get(ctx, "nightly")
+			if err != nil {
+				t.Fatalf("cannot get schedule: %v", err)
+			}
+			if stored.Metadata.Owner != test.Owner {
+				t.Errorf("expected stored owner %q, got %q", test.Owner, stored.Metadata.Owner)
+			}
+		})
+	}
+}
+
+func TestStartDueSchedules(t *testing.T) {
+	ctx := context.Background()
+	schedules := store.NewInMemoryScheduleStore()
+	srv := &Service{
+		Schedules:          schedules,
+		RepositoryProvider: &testRepositoryProvider{},
+	}
+	_, err := srv.CreateSchedule(ctx, &v1.CreateScheduleRequest{Schedule: newTestSchedule("hourly", "@hourly")})
+	if err != nil {
+		t.Fatalf("cannot create schedule: %v", err)
+	}
+	_, err = srv.CreateSchedule(ctx, &v1.CreateScheduleRequest{Schedule: newTestSchedule("paused", "@hourly")})
+	if err != nil {
+		t.Fatalf("cannot create schedule: %v", err)
+	}
+	_, err = srv.PauseSchedule(ctx, &v1.PauseScheduleRequest{Name: "paused", Paused: true})
+	if err != nil {
+		t.Fatalf("cannot pause schedule: %v", err)
+	}
+
+	before, _ := schedules.Get(ctx, "hourly")
+	due := time.Unix(before.NextRun.Seconds, 0)
+
+	// nothing is due yet
+	srv.startDueSchedules(ctx, due.Add(-time.Second))
+	s, _ := schedules.Get(ctx, "hourly")
+	if s.LastRun != nil {
+		t.Fatalf("schedule ran before it was due")
+	}
+
+	srv.startDueSchedules(ctx, due)
+	s, _ = schedules.Get(ctx, "hourly")
+	if s.LastRun.GetSeconds() != due.Unix() {
+		t.Errorf("expected last run at %v, got %v", due, s.LastRun)
+	}
+	if exp := due.Add(time.Hour).Unix(); s.NextRun.Seconds != exp {
+		t.Errorf("expected next run at %v, got %v", time.Unix(exp, 0), s.NextRun)
+	}
+	if s.LastJob != "" || s.LastError == "" {
+		t.Errorf("expected the run to fail because the job spec is missing, got job %q and error %q", s.LastJob, s.LastError)
+	}
+
+	// running the schedule again, e.g. after a restart, must not start the run twice
+	err = schedules.RecordRun(ctx, "hourly", "marker", "")
+	if err != nil {
+		t.Fatalf("cannot record run: %v", err)
+	}
+	srv.startDueSchedules(ctx, due.Add(time.Second))
+	s, _ = schedules.Get(ctx, "hourly")
+	if s.LastJob != "marker" {
+		t.Errorf("schedule ran twice for the same due time")
+	}
+
+	paused, _ := schedules.Get(ctx, "paused")
+	if paused.LastRun != nil {
+		t.Errorf("paused schedule ran")
+	}
+}
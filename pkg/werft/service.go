@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	termtohtml "github.com/buildkite/terminal-to-html"
 	"github.com/csweichel/werft/pkg/api/repoconfig"
@@ -18,6 +19,7 @@ import (
 	"github.com/csweichel/werft/pkg/logcutter"
 	"github.com/csweichel/werft/pkg/store"
 	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	log "github.com/sirupsen/logrus"
 	"github.com/technosophos/moniker"
 	"golang.org/x/xerrors"
@@ -124,6 +126,11 @@ func (srv *Service) StartLocalJob(inc v1.WerftService_StartLocalJobServer) error
 	flatOwner := strings.ReplaceAll(strings.ToLower(md.Owner), " ", "")
 	name := cleanupPodName(fmt.Sprintf("local-%s-%s", flatOwner, moniker.New().NameSep("-")))
 
+	jobYAML, err = srv.resolveJobArgs(inc.Context(), &md, &v1.JobSpec{}, jobYAML)
+	if err != nil {
+		return err
+	}
+
 	jobStatus, err := srv.RunJob(inc.Context(), name, md, v1.JobSpec{}, cp, jobYAML, false)
 
 	if err != nil {
@@ -188,63 +195,106 @@ func (srv *Service) StartJob2(ctx context.Context, req *v1.StartJobRequest2) (re
 		}
 	}
 
-	cp, err := srv.getContentProvider(ctx, md, req.Spec)
+	src, err := srv.findJobSource(ctx, req.Metadata, req.Spec)
 	if err != nil {
 		return nil, err
 	}
-
 	var (
-		jobYAML     []byte
-		jobPath     string
-		jobRepo     *v1.Repository
-		jobSpecName = "custom"
+		jobYAML         = src.YAML
+		jobPath         string
+		additionalPaths []string
 	)
-	switch src := req.Spec.Source.(type) {
-	case *v1.JobSpec_JobYaml:
-		jobYAML = src.JobYaml
-	case *v1.JobSpec_Repo:
-		jobPath = src.Repo.Path
-		jobRepo = src.Repo.Repo
-	case *v1.JobSpec_JobPath:
-		jobPath = src.JobPath
-		jobRepo = req.Metadata.Repository
-	default:
-		return nil, status.Errorf(codes.InvalidArgument, "unknown job source type")
-	}
-	if len(jobYAML) == 0 {
-		var fp FileProvider
-		fp, err = srv.RepositoryProvider.FileProvider(ctx, jobRepo)
+	if len(src.Paths) > 0 {
+		jobPath, additionalPaths = src.Paths[0], src.Paths[1:]
+		jobYAML, err = downloadJobSpec(ctx, src.FP, jobPath)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "cannot produce file provider: %q", err)
+			return nil, err
 		}
+	}
 
-		if jobPath == "" {
-			repoCfg, err := getRepoCfg(ctx, fp)
-			if err != nil {
-				return nil, status.Error(codes.Internal, err.Error())
-			}
-			jobPath = repoCfg.TemplatePath(req.Metadata)
-		}
+	spec := req.Spec
+	if len(additionalPaths) > 0 {
+		// each job remembers which of the matching job specs it runs
+		spec = specWithPath(spec, jobPath)
+	}
+	jobStatus, err := srv.startJob(ctx, *md, spec, jobPath, jobYAML, src.RepoCfg)
+	if err != nil {
+		return nil, err
+	}
+	resp = &v1.StartJobResponse{
+		Status: jobStatus,
+	}
 
-		in, err := fp.Download(ctx, jobPath)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "cannot download jobspec from %s: %s", jobPath, err.Error())
+	for _, path := range additionalPaths {
+		jobYAML, err := downloadJobSpec(ctx, src.FP, path)
+		if err == nil {
+			jobStatus, err = srv.startJob(ctx, *md, specWithPath(req.Spec, path), path, jobYAML, src.RepoCfg)
 		}
-		jobYAML, err = ioutil.ReadAll(in)
-		in.Close()
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "cannot download jobspec from %s: %s", jobPath, err.Error())
+			// the first job started already, hence we don't fail the request altogether
+			log.WithError(err).WithField("path", path).Warn("cannot start job for matching rule")
+			continue
 		}
+		resp.AdditionalJobs = append(resp.AdditionalJobs, jobStatus)
+	}
+
+	return resp, nil
+}
+
+// startJob names and runs a job whose job spec was downloaded from jobPath.
+// If jobPath is empty, the job spec was part of the request.
+func (srv *Service) startJob(ctx context.Context, md v1.JobMetadata, spec *v1.JobSpec, jobPath string, jobYAML []byte, repoCfg *repoconfig.C) (*v1.JobStatus, error) {
+	jobYAML, err := srv.resolveJobArgs(ctx, &md, spec, jobYAML)
+	if err != nil {
+		return nil, err
+	}
+
+	cp, err := srv.getContentProvider(ctx, &md, spec)
+	if err != nil {
+		return nil, err
+	}
 
-		if jobPath != "" {
-			jobSpecName = strings.TrimSpace(strings.TrimSuffix(filepath.Base(jobPath), filepath.Ext(jobPath)))
+	md.JobSpecName = jobSpecName(jobPath)
+	name, refname, err := jobName(&md, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if refname != "" {
+		// we have a valid refname, hence need to acquire job number
+		t, err := srv.Groups.Next(name)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
 		}
+
+		name = fmt.Sprintf("%s.%d", name, t)
+	}
+
+	canReplay := true
+
+	jobStatus, err := srv.RunJob(ctx, name, md, *spec, cp, jobYAML, canReplay)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	md.JobSpecName = jobSpecName
+	log.WithField("status", jobStatus).Info(("started new job"))
+	if md.Trigger != v1.JobTrigger_TRIGGER_DELETED && srv.shouldCancelSuperseded(repoCfg) {
+		srv.cancelSupersededJobs(ctx, jobStatus)
+	}
+	return jobStatus, nil
+}
+
+// jobSpecName derives the job spec name from the path of the job spec
+func jobSpecName(jobPath string) string {
+	if jobPath == "" {
+		return "custom"
+	}
+	return strings.TrimSpace(strings.TrimSuffix(filepath.Base(jobPath), filepath.Ext(jobPath)))
+}
 
-	// build job name
-	refname := md.Repository.Ref
+// jobName builds the name of a job without its number
+func jobName(md *v1.JobMetadata, spec *v1.JobSpec) (name, refname string, err error) {
+	refname = md.Repository.Ref
 	refname = strings.TrimPrefix(refname, "refs/heads/")
 	refname = strings.TrimPrefix(refname, "refs/tags/")
 	refname = strings.ReplaceAll(refname, "/", "-")
@@ -256,36 +306,149 @@ func (srv *Service) StartJob2(ctx context.Context, req *v1.StartJobRequest2) (re
 		// we did not compute a sensible refname - use moniker
 		refname = moniker.New().NameSep("-")
 	}
-	name := cleanupPodName(fmt.Sprintf("%s-%s-%s", md.Repository.Repo, jobSpecName, refname))
-	if ns := req.Spec.NameSuffix; ns != "" {
+	name = cleanupPodName(fmt.Sprintf("%s-%s-%s", md.Repository.Repo, md.JobSpecName, refname))
+	if ns := spec.NameSuffix; ns != "" {
 		if len(ns) > 20 {
-			return nil, status.Error(codes.InvalidArgument, "name suffix must be less than 20 characters")
+			return "", "", status.Error(codes.InvalidArgument, "name suffix must be less than 20 characters")
 		}
 
 		name += "-" + ns
 	}
+	return name, refname, nil
+}
 
-	if refname != "" {
-		// we have a valid refname, hence need to acquire job number
-		t, err := srv.Groups.Next(name)
-		if err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
-		}
+// jobSpecOrigin returns the repository and path a job spec was loaded from. The path is empty if the job spec
+// was part of the request, or if the repository config determined the job spec.
+func jobSpecOrigin(md *v1.JobMetadata, spec *v1.JobSpec) (repo *v1.Repository, path string) {
+	if src := spec.GetRepo(); src != nil {
+		return src.Repo, src.Path
+	}
+	return md.Repository, spec.GetJobPath()
+}
 
-		name = fmt.Sprintf("%s.%d", name, t)
+// resolveJobArgs resolves the job specs a job extends before enforcing its arguments, because extended job specs
+// can declare arguments, too. The resolved job YAML is returned.
+func (srv *Service) resolveJobArgs(ctx context.Context, md *v1.JobMetadata, spec *v1.JobSpec, jobYAML []byte) ([]byte, error) {
+	jobRepo, jobPath := jobSpecOrigin(md, spec)
+	jobYAML, err := srv.resolveExtends(ctx, jobRepo, jobPath, jobYAML)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot resolve extended job spec: %v", err)
 	}
+	err = enforceJobArgs(md, jobYAML)
+	if err != nil {
+		return nil, err
+	}
+	return jobYAML, nil
+}
 
-	canReplay := true
+// enforceJobArgs makes sure the annotations of a job satisfy the arguments its job spec declares
+func enforceJobArgs(md *v1.JobMetadata, jobYAML []byte) error {
+	args, err := repoconfig.ParseArgs(jobYAML)
+	if err != nil {
+		// we'd rather run the job than refuse it because of args we cannot parse
+		log.WithError(err).Warn("cannot parse job arguments - not enforcing them")
+		return nil
+	}
+	err = repoconfig.ValidateArgs(args, annotationMap(md))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
 
-	jobStatus, err := srv.RunJob(ctx, name, *md, *req.Spec, cp, jobYAML, canReplay)
+// annotationMap returns the annotations of a job as map
+func annotationMap(md *v1.JobMetadata) map[string]string {
+	res := make(map[string]string, len(md.Annotations))
+	for _, a := range md.Annotations {
+		res[a.Key] = a.Value
+	}
+	return res
+}
+
+// jobSource describes where the job YAML of a request comes from
+type jobSource struct {
+	// YAML is the job YAML if it was part of the request
+	YAML []byte
+	// Paths are the job spec paths if the job YAML has to be downloaded using FP
+	Paths []string
+
+	FP      FileProvider
+	RepoCfg *repoconfig.C
+}
+
+// findJobSource determines the job specs a request refers to. If the request names neither a job YAML
+// nor a job path, the repository config determines the job paths.
+func (srv *Service) findJobSource(ctx context.Context, md *v1.JobMetadata, spec *v1.JobSpec) (*jobSource, error) {
+	var (
+		res     jobSource
+		jobPath string
+		jobRepo *v1.Repository
+		err     error
+	)
+	switch src := spec.Source.(type) {
+	case *v1.JobSpec_JobYaml:
+		res.YAML = src.JobYaml
+	case *v1.JobSpec_Repo:
+		jobPath = src.Repo.Path
+		jobRepo = src.Repo.Repo
+	case *v1.JobSpec_JobPath:
+		jobPath = src.JobPath
+		jobRepo = md.Repository
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown job source type")
+	}
+	if len(res.YAML) > 0 {
+		return &res, nil
+	}
+
+	res.FP, err = srv.RepositoryProvider.FileProvider(ctx, jobRepo)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Errorf(codes.Internal, "cannot produce file provider: %q", err)
 	}
 
-	log.WithField("status", jobStatus).Info(("started new job"))
-	return &v1.StartJobResponse{
-		Status: jobStatus,
-	}, nil
+	var cfgErr error
+	res.RepoCfg, cfgErr = getRepoCfg(ctx, res.FP)
+	if jobPath != "" {
+		res.Paths = []string{jobPath}
+		return &res, nil
+	}
+	if cfgErr != nil {
+		return nil, status.Error(codes.Internal, cfgErr.Error())
+	}
+	res.Paths = srv.templatePaths(ctx, res.RepoCfg, md)
+	if len(res.Paths) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "no job matches this change")
+	}
+	return &res, nil
+}
+
+// downloadJobSpec downloads the job spec YAML from a repository
+func downloadJobSpec(ctx context.Context, fp FileProvider, jobPath string) ([]byte, error) {
+	in, err := fp.Download(ctx, jobPath)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot download jobspec from %s: %s", jobPath, err.Error())
+	}
+	defer in.Close()
+
+	res, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot download jobspec from %s: %s", jobPath, err.Error())
+	}
+	return res, nil
+}
+
+// specWithPath copies a job spec and makes it point to a particular job path
+func specWithPath(spec *v1.JobSpec, path string) *v1.JobSpec {
+	res := *spec
+	switch src := spec.Source.(type) {
+	case *v1.JobSpec_JobPath:
+		res.Source = &v1.JobSpec_JobPath{JobPath: path}
+	case *v1.JobSpec_Repo:
+		repo := *src.Repo
+		repo.Path = path
+		res.Source = &v1.JobSpec_Repo{Repo: &repo}
+	}
+	return &res
 }
 
 // getContentProvider produces a content provider for the given job spec
@@ -374,6 +537,28 @@ func getRepoCfg(ctx context.Context, fp FileProvider) (*repoconfig.C, error) {
 	return &repoCfg, nil
 }
 
+// templatePaths determines the job paths from the repo config. If the config's rules filter
+// by path, the files changed by the job's revision are taken into account.
+func (srv *Service) templatePaths(ctx context.Context, repoCfg *repoconfig.C, md *v1.JobMetadata) []string {
+	if !repoCfg.NeedsChangedFiles() || md.Trigger == v1.JobTrigger_TRIGGER_DELETED {
+		return repoCfg.TemplatePaths(md)
+	}
+
+	var base string
+	for _, a := range md.Annotations {
+		if a.Key == repoconfig.AnnotationChangedFilesBase {
+			base = a.Value
+		}
+	}
+	changes, err := srv.RepositoryProvider.ChangedFiles(ctx, md.Repository, base)
+	if err != nil {
+		// we'd rather run too many jobs than silently skip one
+		log.WithError(err).WithField("repo", md.Repository).Warn("cannot list changed files - ignoring path filters")
+		return repoCfg.TemplatePaths(md)
+	}
+	return repoCfg.TemplatePathsForChanges(md, changes)
+}
+
 func cleanupPodName(name string) string {
 	name = strings.TrimSpace(name)
 	if len(name) == 0 {
@@ -479,6 +664,38 @@ func (srv *Service) ListJobs(ctx context.Context, req *v1.ListJobsRequest) (resp
 	}, nil
 }
 
+// GetStatistics computes job statistics per repository, branch and job spec
+func (srv *Service) GetStatistics(ctx context.Context, req *v1.GetStatisticsRequest) (*v1.GetStatisticsResponse, error) {
+	var (
+		from = time.Unix(0, 0)
+		to   = time.Now()
+	)
+	if req.From != nil {
+		f, err := ptypes.Timestamp(req.From)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		from = f
+	}
+	if req.To != nil {
+		t, err := ptypes.Timestamp(req.To)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		to = t
+	}
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	stats, err := srv.Jobs.Statistics(ctx, req.Filter, from, to)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &v1.GetStatisticsResponse{Statistics: stats}, nil
+}
+
 // Subscribe listens to job updates
 func (srv *Service) Subscribe(req *v1.SubscribeRequest, resp v1.WerftService_SubscribeServer) (err error) {
 	evts := srv.events.On("job")
@@ -648,3 +865,50 @@ func (srv *Service) StopJob(ctx context.Context, req *v1.StopJobRequest) (*v1.St
 
 	return &v1.StopJobResponse{}, nil
 }
+
+// PinJob protects a job and its logs from garbage collection
+func (srv *Service) PinJob(ctx context.Context, req *v1.PinJobRequest) (*v1.PinJobResponse, error) {
+	job, err := srv.setPinned(ctx, req.Name, true)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.PinJobResponse{Status: job}, nil
+}
+
+// UnpinJob removes the garbage collection protection of a job
+func (srv *Service) UnpinJob(ctx context.Context, req *v1.UnpinJobRequest) (*v1.UnpinJobResponse, error) {
+	job, err := srv.setPinned(ctx, req.Name, false)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.UnpinJobResponse{Status: job}, nil
+}
+
+func (srv *Service) setPinned(ctx context.Context, name string, pinned bool) (*v1.JobStatus, error) {
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	err := srv.Jobs.Pin(ctx, name, pinned)
+	if err == store.ErrNotFound {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	err = srv.Logs.Pin(name, pinned)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	job, err := srv.Jobs.Get(ctx, name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.WithField("name", name).WithField("pinned", pinned).Info("job pin changed")
+
+	// tell our Listen subscribers about this change
+	<-srv.events.Emit("job", job)
+
+	return job, nil
+}
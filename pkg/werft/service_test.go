@@ -1,6 +1,15 @@
 package werft
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
 
 func TestCleanupPodName(t *testing.T) {
 	tests := []struct {
@@ -23,3 +32,110 @@ func TestCleanupPodName(t *testing.T) {
 		})
 	}
 }
+
+type changedFilesProvider struct {
+	NoopRepositoryProvider
+
+	Changes map[string][]string
+	Base    string
+}
+
+func (p *changedFilesProvider) ChangedFiles(ctx context.Context, repo *v1.Repository, base string) ([]string, error) {
+	p.Base = base
+	res, ok := p.Changes[repo.Revision]
+	if !ok {
+		return nil, xerrors.Errorf("unknown revision %s", repo.Revision)
+	}
+	return res, nil
+}
+
+func TestTemplatePaths(t *testing.T) {
+	cfg := &repoconfig.C{
+		DefaultJob: "build.yaml",
+		Rules: []*repoconfig.JobStartRule{
+			{Path: "docs.yaml", Paths: []string{"docs/**"}},
+		},
+	}
+	prov := &changedFilesProvider{
+		Changes: map[string][]string{
+			"docs": {"docs/index.md"},
+			"code": {"main.go"},
+		},
+	}
+	srv := &Service{RepositoryProvider: prov}
+
+	tests := []struct {
+		Name         string
+		Revision     string
+		Trigger      v1.JobTrigger
+		Annotations  []*v1.Annotation
+		Expectation  string
+		ExpectedBase string
+	}{
+		{Name: "docs changed", Revision: "docs", Expectation: "docs.yaml"},
+		{Name: "code changed", Revision: "code", Expectation: "build.yaml"},
+		{Name: "with base", Revision: "code", Annotations: []*v1.Annotation{{Key: repoconfig.AnnotationChangedFilesBase, Value: "base"}}, Expectation: "build.yaml", ExpectedBase: "base"},
+		{Name: "changes unknown", Revision: "unknown", Expectation: "docs.yaml"},
+		{Name: "deleted", Trigger: v1.JobTrigger_TRIGGER_DELETED, Expectation: "docs.yaml"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			prov.Base = ""
+			act := srv.templatePaths(context.Background(), cfg, &v1.JobMetadata{
+				Repository:  &v1.Repository{Revision: test.Revision},
+				Trigger:     test.Trigger,
+				Annotations: test.Annotations,
+			})
+			if len(act) != 1 || act[0] != test.Expectation {
+				t.Errorf("expected %s, actual %v", test.Expectation, act)
+			}
+			if prov.Base != test.ExpectedBase {
+				t.Errorf("expected base %q, actual %q", test.ExpectedBase, prov.Base)
+			}
+		})
+	}
+}
+
+func TestResolveJobArgs(t *testing.T) {
+	srv := &Service{RepositoryProvider: &testRepositoryProvider{
+		Files: map[string]string{
+			".werft/base.yaml": `
+args:
+- name: target
+  required: true
+pod:
+  containers:
+  - name: build
+    image: golang
+`,
+			".werft/job.yaml":    "extends: .werft/base.yaml",
+			".werft/broken.yaml": "extends: .werft/missing.yaml",
+		},
+	}}
+
+	tests := []struct {
+		Name        string
+		Path        string
+		Annotations []*v1.Annotation
+		Error       codes.Code
+	}{
+		{Name: "extended args satisfied", Path: ".werft/job.yaml", Annotations: []*v1.Annotation{{Key: "target", Value: "all"}}},
+		{Name: "extended args missing", Path: ".werft/job.yaml", Error: codes.InvalidArgument},
+		{Name: "extends missing job spec", Path: ".werft/broken.yaml", Annotations: []*v1.Annotation{{Key: "target", Value: "all"}}, Error: codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			md := &v1.JobMetadata{
+				Repository:  &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Revision: "abc"},
+				Annotations: test.Annotations,
+			}
+			spec := &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: test.Path}}
+			jobYAML := srv.RepositoryProvider.(*testRepositoryProvider).Files[test.Path]
+
+			_, err := srv.resolveJobArgs(context.Background(), md, spec, []byte(jobYAML))
+			if code := status.Code(err); code != test.Error {
+				t.Errorf("expected %v, got %v: %v", test.Error, code, err)
+			}
+		})
+	}
+}
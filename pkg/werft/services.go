@@ -0,0 +1,126 @@
+package werft
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const defaultServiceReadinessTimeout = 60
+
+// addServices adds the services as containers to the pod and returns their names. Services with a readiness command
+// get a readiness probe which leaves a marker file in the control volume once the service is ready. The entrypoint
+// of all other containers, except for sidecars, waits for those marker files before it runs the container's command.
+// Hence these containers must specify their command, and their image must contain a POSIX shell.
+func addServices(podspec *corev1.PodSpec, services []repoconfig.ServiceSpec, sidecars []string) (names []string, err error) {
+	existing := make(map[string]struct{}, len(podspec.Containers)+len(podspec.InitContainers))
+	for _, c := range podspec.Containers {
+		existing[c.Name] = struct{}{}
+	}
+	for _, c := range podspec.InitContainers {
+		existing[c.Name] = struct{}{}
+	}
+
+	var (
+		markerDir  = controlPath + "/services"
+		containers = make([]corev1.Container, 0, len(services))
+	)
+	for i, s := range services {
+		if errs := validation.IsDNS1123Label(s.Name); len(errs) > 0 {
+			return nil, xerrors.Errorf("service %d has an invalid name %q: %s", i, s.Name, strings.Join(errs, ", "))
+		}
+		if _, exists := existing[s.Name]; exists {
+			return nil, xerrors.Errorf("service name %s is not unique", s.Name)
+		}
+		existing[s.Name] = struct{}{}
+		if s.Image == "" {
+			return nil, xerrors.Errorf("service %s has no image", s.Name)
+		}
+		if s.ReadinessTimeout < 0 {
+			return nil, xerrors.Errorf("service %s has a negative readiness timeout", s.Name)
+		}
+
+		c := corev1.Container{
+			Name:  s.Name,
+			Image: s.Image,
+			Env:   s.Env,
+		}
+		for _, p := range s.Ports {
+			c.Ports = append(c.Ports, corev1.ContainerPort{ContainerPort: p, Protocol: corev1.ProtocolTCP})
+		}
+		if s.Readiness != "" {
+			c.ReadinessProbe = &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					Exec: &corev1.ExecAction{
+						Command: []string{"sh", "-c", readinessScript(markerDir, s)},
+					},
+				},
+				PeriodSeconds: 1,
+			}
+		}
+		containers = append(containers, c)
+		names = append(names, s.Name)
+	}
+
+	if wait := waitForServicesScript(markerDir, services); wait != "" {
+		isSidecar := make(map[string]struct{}, len(sidecars))
+		for _, s := range sidecars {
+			isSidecar[s] = struct{}{}
+		}
+		for i, c := range podspec.Containers {
+			if _, ok := isSidecar[c.Name]; ok {
+				continue
+			}
+			if len(c.Command) == 0 {
+				return nil, xerrors.Errorf("container %s has no command, but must specify one to wait for the services", c.Name)
+			}
+
+			// the original command becomes the arguments of the wait script, which runs it once the services are ready
+			podspec.Containers[i].Command = []string{"sh", "-c", wait + "exec \"$0\" \"$@\"\n"}
+			podspec.Containers[i].Args = append(append([]string{}, c.Command...), c.Args...)
+		}
+	}
+	podspec.Containers = append(containers, podspec.Containers...)
+	addControlVolume(podspec)
+
+	return names, nil
+}
+
+// readinessScript produces the shell script of a service's readiness probe. The script runs the readiness command
+// of the service once, and leaves a marker file in markerDir if it succeeds.
+func readinessScript(markerDir string, s repoconfig.ServiceSpec) string {
+	var res strings.Builder
+	fmt.Fprintf(&res, "( %s\n) >/dev/null 2>&1 || exit 1\n", s.Readiness)
+	fmt.Fprintf(&res, "mkdir -p %s && touch %s\n", shellQuote(markerDir), shellQuote(fmt.Sprintf("%s/%s.ready", markerDir, s.Name)))
+	return res.String()
+}
+
+// waitForServicesScript produces a shell script which waits until all services with a readiness command have left
+// their marker file in markerDir. The script fails once it waited longer than the readiness timeout of a service
+// which is not ready yet. If no service has a readiness command, the script is empty.
+func waitForServicesScript(markerDir string, services []repoconfig.ServiceSpec) string {
+	var res strings.Builder
+	for _, s := range services {
+		if s.Readiness == "" {
+			continue
+		}
+		timeout := s.ReadinessTimeout
+		if timeout == 0 {
+			timeout = defaultServiceReadinessTimeout
+		}
+
+		if res.Len() == 0 {
+			fmt.Fprintf(&res, "i=0\n")
+		}
+		fmt.Fprintf(&res, "until [ -e %s ]; do\n", shellQuote(fmt.Sprintf("%s/%s.ready", markerDir, s.Name)))
+		fmt.Fprintf(&res, "  i=$((i+1))\n")
+		fmt.Fprintf(&res, "  if [ $i -gt %d ]; then echo %s >&2; exit 1; fi\n", timeout, shellQuote(fmt.Sprintf("service %s did not become ready within %d seconds", s.Name, timeout)))
+		fmt.Fprintf(&res, "  sleep 1\n")
+		fmt.Fprintf(&res, "done\n")
+	}
+	return res.String()
+}
@@ -0,0 +1,184 @@
+package werft
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAddServices(t *testing.T) {
+	type Expectation struct {
+		Error      string
+		Names      []string
+		Containers []string
+		Readiness  []bool
+		Waits      []bool
+	}
+	tests := []struct {
+		Name        string
+		Services    []repoconfig.ServiceSpec
+		Containers  []corev1.Container
+		Expectation Expectation
+	}{
+		{
+			Name: "services come first",
+			Services: []repoconfig.ServiceSpec{
+				{Name: "postgres", Image: "postgres:13", Ports: []int32{5432}, Readiness: "pg_isready"},
+				{Name: "redis", Image: "redis"},
+			},
+			Expectation: Expectation{
+				Names:      []string{"postgres", "redis"},
+				Containers: []string{"postgres", "redis", "build", "docker"},
+				Readiness:  []bool{true, false, false, false},
+				Waits:      []bool{false, false, true, false},
+			},
+		},
+		{
+			Name:     "no readiness command",
+			Services: []repoconfig.ServiceSpec{{Name: "redis", Image: "redis"}},
+			Expectation: Expectation{
+				Names:      []string{"redis"},
+				Containers: []string{"redis", "build", "docker"},
+				Readiness:  []bool{false, false, false},
+				Waits:      []bool{false, false, false},
+			},
+		},
+		{
+			Name:        "container without command",
+			Services:    []repoconfig.ServiceSpec{{Name: "postgres", Image: "postgres:13", Readiness: "pg_isready"}},
+			Containers:  []corev1.Container{{Name: "test", Image: "golang"}},
+			Expectation: Expectation{Error: "container test has no command, but must specify one to wait for the services"},
+		},
+		{
+			Name:        "container name clash",
+			Services:    []repoconfig.ServiceSpec{{Name: "build", Image: "redis"}},
+			Expectation: Expectation{Error: "service name build is not unique"},
+		},
+		{
+			Name:        "init container name clash",
+			Services:    []repoconfig.ServiceSpec{{Name: "checkout", Image: "redis"}},
+			Expectation: Expectation{Error: "service name checkout is not unique"},
+		},
+		{
+			Name:        "no name",
+			Services:    []repoconfig.ServiceSpec{{Image: "redis"}},
+			Expectation: Expectation{Error: `service 0 has an invalid name "": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`},
+		},
+		{
+			Name:        "no image",
+			Services:    []repoconfig.ServiceSpec{{Name: "redis"}},
+			Expectation: Expectation{Error: "service redis has no image"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			podspec := &corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "checkout"}},
+				Containers: []corev1.Container{
+					{Name: "build", Command: []string{"go", "build"}, Args: []string{"./..."}},
+					{Name: "docker"},
+				},
+			}
+			if test.Containers != nil {
+				podspec.Containers = test.Containers
+			}
+
+			var act Expectation
+			names, err := addServices(podspec, test.Services, []string{"docker"})
+			if err != nil {
+				act.Error = err.Error()
+			} else {
+				act.Names = names
+				for _, c := range podspec.Containers {
+					act.Containers = append(act.Containers, c.Name)
+					act.Readiness = append(act.Readiness, c.ReadinessProbe != nil)
+					waits := len(c.Command) > 0 && c.Command[0] == "sh"
+					act.Waits = append(act.Waits, waits)
+					if waits && strings.Join(c.Args, " ") != "go build ./..." {
+						t.Errorf("container %s does not run its original command: %v", c.Name, c.Args)
+					}
+					if len(c.VolumeMounts) != 1 || c.VolumeMounts[0].Name != controlVolume {
+						t.Errorf("container %s does not mount the control volume", c.Name)
+					}
+				}
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("addServices() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServiceReadiness(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell available")
+	}
+
+	type Expectation struct {
+		Output   string
+		ExitCode int
+	}
+	tests := []struct {
+		Name        string
+		Services    []repoconfig.ServiceSpec
+		Ready       []string
+		Expectation Expectation
+	}{
+		{
+			Name:        "ready",
+			Services:    []repoconfig.ServiceSpec{{Name: "db", Readiness: "echo hello && true"}, {Name: "cache"}},
+			Ready:       []string{"db"},
+			Expectation: Expectation{Output: "running\n"},
+		},
+		{
+			Name:        "not ready",
+			Services:    []repoconfig.ServiceSpec{{Name: "db", Readiness: "true"}, {Name: "queue", Readiness: "false", ReadinessTimeout: 1}},
+			Ready:       []string{"db"},
+			Expectation: Expectation{Output: "service queue did not become ready within 1 seconds\n", ExitCode: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			markers := t.TempDir()
+			for _, s := range test.Services {
+				if s.Readiness == "" {
+					continue
+				}
+				err := exec.Command("sh", "-c", readinessScript(markers, s)).Run()
+				_, ready := os.Stat(filepath.Join(markers, s.Name+".ready"))
+				if (err == nil) != (ready == nil) {
+					t.Errorf("readiness probe of %s returned %v, but the marker file says %v", s.Name, err, ready)
+				}
+			}
+			for _, name := range test.Ready {
+				if _, err := os.Stat(filepath.Join(markers, name+".ready")); err != nil {
+					t.Errorf("expected %s to be ready: %v", name, err)
+				}
+			}
+
+			var out strings.Builder
+			cmd := exec.Command("sh", "-c", waitForServicesScript(markers, test.Services)+`exec "$0" "$@"`, "echo", "running")
+			cmd.Stdout = &out
+			cmd.Stderr = &out
+			err := cmd.Run()
+
+			act := Expectation{Output: out.String()}
+			if eerr, ok := err.(*exec.ExitError); ok {
+				act.ExitCode = eerr.ExitCode()
+			} else if err != nil {
+				t.Fatalf("cannot run wait script: %v", err)
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("waitForServicesScript() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package werft
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// controlVolume is shared by the containers of a job, which coordinate through marker files in it
+	controlVolume = "werft-control"
+	// controlPath is where the control volume is mounted in the containers of a job
+	controlPath = "/.werft"
+)
+
+// compileSteps produces a pod which runs the steps one after the other. All steps are containers of the pod,
+// and each step's entrypoint waits until the previous step has marked itself done in the control volume.
+// Each step marks its log slice using the phase, done and fail markers the log cutter understands.
+func compileSteps(steps []repoconfig.StepSpec) (*corev1.PodSpec, error) {
+	if len(steps) == 0 {
+		return nil, xerrors.Errorf("job has no steps")
+	}
+
+	var (
+		res   corev1.PodSpec
+		names = make(map[string]struct{}, len(steps))
+		prev  string
+	)
+	for i, s := range steps {
+		name := s.Name
+		if name == "" {
+			name = fmt.Sprintf("step%d", i)
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, xerrors.Errorf("step %d has an invalid name %q: %s", i, name, strings.Join(errs, ", "))
+		}
+		if _, exists := names[name]; exists {
+			return nil, xerrors.Errorf("step name %s is not unique", name)
+		}
+		names[name] = struct{}{}
+
+		if s.Image == "" {
+			return nil, xerrors.Errorf("step %s has no image", name)
+		}
+		if len(s.Commands) == 0 {
+			return nil, xerrors.Errorf("step %s has no commands", name)
+		}
+
+		res.Containers = append(res.Containers, corev1.Container{
+			Name:       name,
+			Image:      s.Image,
+			Command:    []string{"sh", "-c", stepScript(controlPath+"/steps", name, prev, s)},
+			Env:        s.Env,
+			WorkingDir: "/workspace",
+		})
+		prev = name
+	}
+	addControlVolume(&res)
+	return &res, nil
+}
+
+// addControlVolume adds the control volume to the pod and mounts it in all containers which don't mount it yet
+func addControlVolume(podspec *corev1.PodSpec) {
+	var exists bool
+	for _, v := range podspec.Volumes {
+		if v.Name == controlVolume {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		podspec.Volumes = append(podspec.Volumes, corev1.Volume{
+			Name:         controlVolume,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+
+	for i, c := range podspec.Containers {
+		var mounted bool
+		for _, m := range c.VolumeMounts {
+			if m.Name == controlVolume {
+				mounted = true
+				break
+			}
+		}
+		if mounted {
+			continue
+		}
+		podspec.Containers[i].VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      controlVolume,
+			MountPath: controlPath,
+		})
+	}
+}
+
+// stepScript produces the shell script a step runs. The script waits until the previous step, if any, has left its
+// marker file in markerDir and leaves a marker file once the step succeeded. The commands run in a subshell with
+// errexit set, so that the first failing command fails the step.
+func stepScript(markerDir, name, prev string, step repoconfig.StepSpec) string {
+	desc := step.Description
+	if desc == "" {
+		desc = name
+	}
+	desc = strings.Join(strings.Fields(desc), " ")
+
+	var res strings.Builder
+	if prev != "" {
+		fmt.Fprintf(&res, "until [ -e %s ]; do sleep 1; done\n", shellQuote(fmt.Sprintf("%s/%s.done", markerDir, prev)))
+	}
+	fmt.Fprintf(&res, "echo %s\n", shellQuote(fmt.Sprintf("[%s|PHASE] %s", name, desc)))
+	res.WriteString("(\nset -e\n")
+	for _, cmd := range step.Commands {
+		res.WriteString(cmd)
+		res.WriteString("\n")
+	}
+	res.WriteString(")\n")
+	res.WriteString("code=$?\n")
+	fmt.Fprintf(&res, "if [ $code -ne 0 ]; then echo %s\"$code\"; exit $code; fi\n", shellQuote(fmt.Sprintf("[%s|FAIL] step failed with exit code ", name)))
+	fmt.Fprintf(&res, "echo %s\n", shellQuote(fmt.Sprintf("[%s|DONE]", name)))
+	fmt.Fprintf(&res, "mkdir -p %s && touch %s\n", shellQuote(markerDir), shellQuote(fmt.Sprintf("%s/%s.done", markerDir, name)))
+	return res.String()
+}
+
+// shellQuote quotes s for use in a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
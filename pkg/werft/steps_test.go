@@ -0,0 +1,198 @@
+package werft
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompileSteps(t *testing.T) {
+	type Expectation struct {
+		Error      string
+		Containers []string
+		Waits      []bool
+	}
+	tests := []struct {
+		Name        string
+		Steps       []repoconfig.StepSpec
+		Expectation Expectation
+	}{
+		{
+			Name: "single step",
+			Steps: []repoconfig.StepSpec{
+				{Name: "test", Image: "golang:1.17", Commands: []string{"go test ./..."}},
+			},
+			Expectation: Expectation{Containers: []string{"test=golang:1.17"}, Waits: []bool{false}},
+		},
+		{
+			Name: "several steps",
+			Steps: []repoconfig.StepSpec{
+				{Name: "build", Image: "golang:1.17", Commands: []string{"go build ./..."}},
+				{Image: "alpine", Commands: []string{"ls"}},
+				{Name: "publish", Image: "docker", Commands: []string{"docker push"}},
+			},
+			Expectation: Expectation{
+				Containers: []string{"build=golang:1.17", "step1=alpine", "publish=docker"},
+				Waits:      []bool{false, true, true},
+			},
+		},
+		{
+			Name:        "no steps",
+			Expectation: Expectation{Error: "job has no steps"},
+		},
+		{
+			Name:        "invalid name",
+			Steps:       []repoconfig.StepSpec{{Name: "Build_It", Image: "alpine", Commands: []string{"ls"}}},
+			Expectation: Expectation{Error: `step 0 has an invalid name "Build_It": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`},
+		},
+		{
+			Name: "duplicate name",
+			Steps: []repoconfig.StepSpec{
+				{Name: "build", Image: "alpine", Commands: []string{"ls"}},
+				{Name: "build", Image: "alpine", Commands: []string{"ls"}},
+			},
+			Expectation: Expectation{Error: "step name build is not unique"},
+		},
+		{
+			Name:        "no image",
+			Steps:       []repoconfig.StepSpec{{Name: "build", Commands: []string{"ls"}}},
+			Expectation: Expectation{Error: "step build has no image"},
+		},
+		{
+			Name:        "no commands",
+			Steps:       []repoconfig.StepSpec{{Name: "build", Image: "alpine"}},
+			Expectation: Expectation{Error: "step build has no commands"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var act Expectation
+			podspec, err := compileSteps(test.Steps)
+			if err != nil {
+				act.Error = err.Error()
+			} else {
+				if len(podspec.InitContainers) > 0 {
+					t.Errorf("steps must not run as init containers")
+				}
+				for _, c := range podspec.Containers {
+					act.Containers = append(act.Containers, c.Name+"="+c.Image)
+					act.Waits = append(act.Waits, strings.HasPrefix(c.Command[2], "until "))
+					if len(c.VolumeMounts) != 1 || c.VolumeMounts[0].Name != controlVolume {
+						t.Errorf("step %s does not mount the control volume", c.Name)
+					}
+				}
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("compileSteps() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStepScript(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell available")
+	}
+
+	type Expectation struct {
+		Output   string
+		ExitCode int
+	}
+	tests := []struct {
+		Name        string
+		Step        repoconfig.StepSpec
+		Expectation Expectation
+	}{
+		{
+			Name: "success",
+			Step: repoconfig.StepSpec{Description: "Say 'hello'", Commands: []string{"echo hello", "echo world"}},
+			Expectation: Expectation{
+				Output: "[test|PHASE] Say 'hello'\nhello\nworld\n[test|DONE]\n",
+			},
+		},
+		{
+			Name: "failure",
+			Step: repoconfig.StepSpec{Commands: []string{"echo hello", "exit 3", "echo unreachable"}},
+			Expectation: Expectation{
+				Output:   "[test|PHASE] test\nhello\n[test|FAIL] step failed with exit code 3\n",
+				ExitCode: 3,
+			},
+		},
+		{
+			Name: "failing command",
+			Step: repoconfig.StepSpec{Commands: []string{"false", "echo unreachable"}},
+			Expectation: Expectation{
+				Output:   "[test|PHASE] test\n[test|FAIL] step failed with exit code 1\n",
+				ExitCode: 1,
+			},
+		},
+		{
+			Name: "multi-line command",
+			Step: repoconfig.StepSpec{Description: "multi\nline", Commands: []string{"for i in 1 2; do\n  echo $i\ndone"}},
+			Expectation: Expectation{
+				Output: "[test|PHASE] multi line\n1\n2\n[test|DONE]\n",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var out strings.Builder
+			markers := t.TempDir()
+			cmd := exec.Command("sh", "-c", stepScript(markers, "test", "", test.Step))
+			cmd.Stdout = &out
+			err := cmd.Run()
+
+			act := Expectation{Output: out.String()}
+			if eerr, ok := err.(*exec.ExitError); ok {
+				act.ExitCode = eerr.ExitCode()
+			} else if err != nil {
+				t.Fatalf("cannot run step script: %v", err)
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("stepScript() mismatch (-want +got):\n%s", diff)
+			}
+			if _, err := os.Stat(filepath.Join(markers, "test.done")); (err == nil) != (act.ExitCode == 0) {
+				t.Errorf("expected the done marker to exist only if the step succeeded: %v", err)
+			}
+		})
+	}
+}
+
+func TestStepScriptWaitsForPreviousStep(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell available")
+	}
+
+	markers := t.TempDir()
+	var out strings.Builder
+	cmd := exec.Command("sh", "-c", stepScript(markers, "test", "build", repoconfig.StepSpec{Commands: []string{"echo hello"}}))
+	cmd.Stdout = &out
+	err := cmd.Start()
+	if err != nil {
+		t.Fatalf("cannot start step script: %v", err)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	if _, err := os.Stat(filepath.Join(markers, "test.done")); err == nil {
+		t.Errorf("step ran before the previous step was done")
+	}
+	err = os.WriteFile(filepath.Join(markers, "build.done"), nil, 0644)
+	if err != nil {
+		t.Fatalf("cannot mark previous step done: %v", err)
+	}
+	err = cmd.Wait()
+	if err != nil {
+		t.Fatalf("step failed: %v", err)
+	}
+	if exp := "[test|PHASE] test\nhello\n[test|DONE]\n"; out.String() != exp {
+		t.Errorf("expected %q, got %q", exp, out.String())
+	}
+}
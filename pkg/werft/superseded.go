@@ -0,0 +1,136 @@
+package werft
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	log "github.com/sirupsen/logrus"
+)
+
+// shouldCancelSuperseded determines if starting a job cancels the older jobs it supersedes.
+// The repository config takes precedence over the server config.
+func (srv *Service) shouldCancelSuperseded(repoCfg *repoconfig.C) bool {
+	if repoCfg != nil && repoCfg.CancelSuperseded != nil {
+		return *repoCfg.CancelSuperseded
+	}
+	return srv.Config.CancelSuperseded
+}
+
+// supersededJobs returns the names of all jobs which are still running and are superseded by job,
+// i.e. which were started before job for the same repository, ref, job spec and name suffix.
+func supersededJobs(jobs []v1.JobStatus, job *v1.JobStatus) []string {
+	var (
+		md  = job.Metadata
+		res []string
+	)
+	for i := range jobs {
+		j := &jobs[i]
+		if j.Name == job.Name || j.Metadata == nil || j.Metadata.Repository == nil {
+			continue
+		}
+		switch j.Phase {
+		case v1.JobPhase_PHASE_WAITING, v1.JobPhase_PHASE_PREPARING, v1.JobPhase_PHASE_STARTING, v1.JobPhase_PHASE_RUNNING:
+		default:
+			continue
+		}
+
+		repo := j.Metadata.Repository
+		if repo.Host != md.Repository.Host || repo.Owner != md.Repository.Owner || repo.Repo != md.Repository.Repo || repo.Ref != md.Repository.Ref {
+			continue
+		}
+		if j.Metadata.JobSpecName != md.JobSpecName || jobGroup(j.Name) != jobGroup(job.Name) {
+			continue
+		}
+		if j.Metadata.Created != nil && md.Created != nil && !createdBefore(j.Metadata, md) {
+			continue
+		}
+
+		res = append(res, j.Name)
+	}
+	return res
+}
+
+func createdBefore(a, b *v1.JobMetadata) bool {
+	if a.Created.Seconds != b.Created.Seconds {
+		return a.Created.Seconds < b.Created.Seconds
+	}
+	return a.Created.Nanos < b.Created.Nanos
+}
+
+// jobGroup returns the name of a job without its number. The name contains the repository, job spec, ref and
+// name suffix of the job.
+func jobGroup(name string) string {
+	idx := strings.LastIndex(name, ".")
+	if idx < 0 {
+		return name
+	}
+	if _, err := strconv.Atoi(name[idx+1:]); err != nil {
+		return name
+	}
+	return name[:idx]
+}
+
+// runsJobSpecFile returns true if the job runs a job spec file of its repository. All jobs which run job YAML
+// that was part of their request share the job spec name "custom", hence they are not the same job.
+func (srv *Service) runsJobSpecFile(name string) bool {
+	spec, _, err := srv.Jobs.GetJobSpec(name)
+	if err != nil {
+		return false
+	}
+	return spec.GetJobYaml() == nil
+}
+
+// findSupersededJobs returns the names of all jobs which are superseded by job. Only jobs which run a
+// job spec file supersede, or are superseded.
+func (srv *Service) findSupersededJobs(ctx context.Context, job *v1.JobStatus) ([]string, error) {
+	if !srv.runsJobSpecFile(job.Name) {
+		return nil, nil
+	}
+
+	repo := job.Metadata.Repository
+	jobs, _, err := srv.Jobs.Find(ctx, []*v1.FilterExpression{
+		{Terms: []*v1.FilterTerm{{Field: "repo.host", Value: repo.Host, Operation: v1.FilterOp_OP_EQUALS}}},
+		{Terms: []*v1.FilterTerm{{Field: "repo.owner", Value: repo.Owner, Operation: v1.FilterOp_OP_EQUALS}}},
+		{Terms: []*v1.FilterTerm{{Field: "repo.repo", Value: repo.Repo, Operation: v1.FilterOp_OP_EQUALS}}},
+		{Terms: []*v1.FilterTerm{{Field: "repo.ref", Value: repo.Ref, Operation: v1.FilterOp_OP_EQUALS}}},
+		{Terms: []*v1.FilterTerm{{Field: "phase", Value: "done", Operation: v1.FilterOp_OP_EQUALS, Negate: true}}},
+	}, nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []string
+	for _, name := range supersededJobs(jobs, job) {
+		if !srv.runsJobSpecFile(name) {
+			continue
+		}
+		res = append(res, name)
+	}
+	return res, nil
+}
+
+// cancelSupersededJobs stops all jobs which are superseded by job
+func (srv *Service) cancelSupersededJobs(ctx context.Context, job *v1.JobStatus) {
+	names, err := srv.findSupersededJobs(ctx, job)
+	if err != nil {
+		log.WithError(err).WithField("job", job.Name).Warn("cannot find superseded jobs")
+		return
+	}
+
+	reason := fmt.Sprintf("superseded by a newer job: %s", job.Name)
+	if srv.Config.BaseURL != "" {
+		reason = fmt.Sprintf("superseded by a newer job: %s/job/%s", srv.Config.BaseURL, job.Name)
+	}
+	for _, name := range names {
+		err := srv.Executor.Stop(name, reason)
+		if err != nil {
+			log.WithError(err).WithField("job", name).Warn("cannot stop superseded job")
+			continue
+		}
+		log.WithField("job", name).WithField("supersededBy", job.Name).Info("stopped superseded job")
+	}
+}
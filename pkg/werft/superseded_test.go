@@ -0,0 +1,192 @@
+package werft
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSupersededJobs(t *testing.T) {
+	now := time.Now()
+	job := func(name, ref, spec string, age time.Duration, phase v1.JobPhase) v1.JobStatus {
+		created, _ := ptypes.TimestampProto(now.Add(-age))
+		return v1.JobStatus{
+			Name: name,
+			Metadata: &v1.JobMetadata{
+				Repository:  &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Ref: ref},
+				JobSpecName: spec,
+				Created:     created,
+			},
+			Phase: phase,
+		}
+	}
+	newJob := job("werft-build-main.4", "refs/heads/main", "build", 0, v1.JobPhase_PHASE_PREPARING)
+
+	tests := []struct {
+		Name        string
+		Jobs        []v1.JobStatus
+		Expectation []string
+	}{
+		{
+			Name: "running jobs",
+			Jobs: []v1.JobStatus{
+				newJob,
+				job("werft-build-main.3", "refs/heads/main", "build", time.Minute, v1.JobPhase_PHASE_RUNNING),
+				job("werft-build-main.2", "refs/heads/main", "build", 2*time.Minute, v1.JobPhase_PHASE_WAITING),
+				job("werft-build-main.1", "refs/heads/main", "build", 3*time.Minute, v1.JobPhase_PHASE_DONE),
+				job("werft-build-main.0", "refs/heads/main", "build", 4*time.Minute, v1.JobPhase_PHASE_CLEANUP),
+			},
+			Expectation: []string{"werft-build-main.3", "werft-build-main.2"},
+		},
+		{
+			Name: "other ref or job spec",
+			Jobs: []v1.JobStatus{
+				newJob,
+				job("werft-build-feature.1", "refs/heads/feature", "build", time.Minute, v1.JobPhase_PHASE_RUNNING),
+				job("werft-deploy-main.1", "refs/heads/main", "deploy", time.Minute, v1.JobPhase_PHASE_RUNNING),
+			},
+		},
+		{
+			Name: "other name suffix",
+			Jobs: []v1.JobStatus{
+				newJob,
+				job("werft-build-main-fork.3", "refs/heads/main", "build", time.Minute, v1.JobPhase_PHASE_RUNNING),
+			},
+		},
+		{
+			Name: "newer job",
+			Jobs: []v1.JobStatus{
+				newJob,
+				job("werft-build-main.5", "refs/heads/main", "build", -time.Minute, v1.JobPhase_PHASE_RUNNING),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := supersededJobs(test.Jobs, &newJob)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("supersededJobs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFindSupersededJobs(t *testing.T) {
+	var (
+		now      = time.Now()
+		fromFile = v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/build.yaml"}}
+		inline   = v1.JobSpec{Source: &v1.JobSpec_JobYaml{JobYaml: []byte("pod: {}")}}
+	)
+	type storedJob struct {
+		Name string
+		Spec v1.JobSpec
+		Age  time.Duration
+	}
+	tests := []struct {
+		Name        string
+		Jobs        []storedJob
+		Expectation []string
+	}{
+		{
+			Name: "job spec file",
+			Jobs: []storedJob{
+				{"werft-build-main.2", fromFile, 0},
+				{"werft-build-main.1", fromFile, time.Minute},
+			},
+			Expectation: []string{"werft-build-main.1"},
+		},
+		{
+			Name: "custom job supersedes nothing",
+			Jobs: []storedJob{
+				{"werft-custom-main.2", inline, 0},
+				{"werft-custom-main.1", inline, time.Minute},
+			},
+		},
+		{
+			Name: "custom job is not superseded",
+			Jobs: []storedJob{
+				{"werft-custom-main.2", v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/custom.yaml"}}, 0},
+				{"werft-custom-main.1", inline, time.Minute},
+			},
+		},
+		{
+			Name: "name suffix",
+			Jobs: []storedJob{
+				{"werft-build-main.2", fromFile, 0},
+				{"werft-build-main-fork.1", fromFile, time.Minute},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			srv := &Service{Jobs: store.NewInMemoryJobStore()}
+			var jobs []v1.JobStatus
+			for _, j := range test.Jobs {
+				created, _ := ptypes.TimestampProto(now.Add(-j.Age))
+				spec := "build"
+				if j.Spec.GetJobYaml() != nil || j.Spec.GetJobPath() == ".werft/custom.yaml" {
+					spec = "custom"
+				}
+				job := v1.JobStatus{
+					Name: j.Name,
+					Metadata: &v1.JobMetadata{
+						Repository:  &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Ref: "refs/heads/main"},
+						JobSpecName: spec,
+						Created:     created,
+					},
+					Phase: v1.JobPhase_PHASE_RUNNING,
+				}
+				err := srv.Jobs.Store(context.Background(), job)
+				if err != nil {
+					t.Fatalf("cannot store job: %v", err)
+				}
+				err = srv.Jobs.StoreJobSpec(j.Name, j.Spec, nil)
+				if err != nil {
+					t.Fatalf("cannot store job spec: %v", err)
+				}
+				jobs = append(jobs, job)
+			}
+
+			act, err := srv.findSupersededJobs(context.Background(), &jobs[0])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("findSupersededJobs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShouldCancelSuperseded(t *testing.T) {
+	var (
+		yes = true
+		no  = false
+	)
+	tests := []struct {
+		Name        string
+		Server      bool
+		Repo        *repoconfig.C
+		Expectation bool
+	}{
+		{Name: "default", Expectation: false},
+		{Name: "server enabled", Server: true, Expectation: true},
+		{Name: "server enabled without repo setting", Server: true, Repo: &repoconfig.C{}, Expectation: true},
+		{Name: "repo enabled", Repo: &repoconfig.C{CancelSuperseded: &yes}, Expectation: true},
+		{Name: "repo disabled", Server: true, Repo: &repoconfig.C{CancelSuperseded: &no}, Expectation: false},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			srv := &Service{Config: Config{CancelSuperseded: test.Server}}
+			if act := srv.shouldCancelSuperseded(test.Repo); act != test.Expectation {
+				t.Errorf("expected %v, got %v", test.Expectation, act)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package werft
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	sprig "github.com/Masterminds/sprig/v3"
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/golang/protobuf/ptypes"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// templateObj is the data job templates are rendered with
+type templateObj struct {
+	Name        string
+	Number      int
+	Owner       string
+	Repository  v1.Repository
+	Trigger     string
+	Annotations map[string]string
+	BaseURL     string
+	Created     time.Time
+	Plugins     map[string]string
+
+	changedFiles func() ([]string, error)
+	previousJob  func() (*v1.JobStatus, error)
+}
+
+// ChangedFiles lists the files changed by the job's revision. The files are determined
+// only if the template uses them.
+func (t templateObj) ChangedFiles() ([]string, error) {
+	if t.changedFiles == nil {
+		return nil, nil
+	}
+	return t.changedFiles()
+}
+
+// PreviousJob returns the most recent job for the same ref and job spec, or nil if there is none
+func (t templateObj) PreviousJob() (*v1.JobStatus, error) {
+	if t.previousJob == nil {
+		return nil, nil
+	}
+	return t.previousJob()
+}
+
+func (srv *Service) newTemplateObj(ctx context.Context, name string, md *v1.JobMetadata, jobYAML []byte) templateObj {
+	args, err := repoconfig.ParseArgs(jobYAML)
+	if err != nil {
+		// broken args don't keep the job from running, they just don't provide defaults
+		log.WithError(err).WithField("name", name).Warn("cannot parse job arguments")
+	}
+	annotations := annotationMap(md)
+	repoconfig.ApplyArgDefaults(args, annotations)
+
+	plugins, err := repoconfig.ParsePlugins(jobYAML)
+	if err != nil {
+		log.WithError(err).WithField("name", name).Warn("cannot parse job plugins")
+	}
+
+	var number int
+	if idx := strings.LastIndex(name, "."); idx > -1 {
+		number, _ = strconv.Atoi(name[idx+1:])
+	}
+
+	created := time.Now()
+	if md.Created != nil {
+		if t, err := ptypes.Timestamp(md.Created); err == nil {
+			created = t
+		}
+	}
+
+	return templateObj{
+		Name:        name,
+		Number:      number,
+		Owner:       md.Owner,
+		Repository:  *md.Repository,
+		Trigger:     strings.ToLower(strings.TrimPrefix(md.Trigger.String(), "TRIGGER_")),
+		Annotations: annotations,
+		BaseURL:     srv.Config.BaseURL,
+		Created:     created,
+		Plugins:     plugins,
+
+		changedFiles: func() ([]string, error) {
+			var base string
+			for _, a := range md.Annotations {
+				if a.Key == repoconfig.AnnotationChangedFilesBase {
+					base = a.Value
+				}
+			}
+			return srv.RepositoryProvider.ChangedFiles(ctx, md.Repository, base)
+		},
+		previousJob: func() (*v1.JobStatus, error) {
+			return srv.findPreviousJob(ctx, name, md)
+		},
+	}
+}
+
+// findPreviousJob finds the most recent job for the same repository, ref and job spec as md
+func (srv *Service) findPreviousJob(ctx context.Context, name string, md *v1.JobMetadata) (*v1.JobStatus, error) {
+	repo := md.Repository
+	jobs, _, err := srv.Jobs.Find(ctx, []*v1.FilterExpression{
+		{Terms: []*v1.FilterTerm{{Field: "repo.host", Value: repo.Host, Operation: v1.FilterOp_OP_EQUALS}}},
+		{Terms: []*v1.FilterTerm{{Field: "repo.owner", Value: repo.Owner, Operation: v1.FilterOp_OP_EQUALS}}},
+		{Terms: []*v1.FilterTerm{{Field: "repo.repo", Value: repo.Repo, Operation: v1.FilterOp_OP_EQUALS}}},
+		{Terms: []*v1.FilterTerm{{Field: "repo.ref", Value: repo.Ref, Operation: v1.FilterOp_OP_EQUALS}}},
+	}, []*v1.OrderExpression{{Field: "created", Ascending: false}}, 0, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	// not all job stores can filter by job spec, hence we do that here
+	for i := range jobs {
+		j := &jobs[i]
+		if j.Name == name || j.Metadata == nil || j.Metadata.JobSpecName != md.JobSpecName {
+			continue
+		}
+		return j, nil
+	}
+	return nil, nil
+}
+
+type secretPlaceholdersKey struct{}
+
+// withSecretPlaceholders makes the secret func of job templates rendered with the returned context produce placeholders
+// instead of secret values. The secrets must exist nonetheless. We render job templates like that if we show the result
+// to users, e.g. when validating a job, because values of secrets could end up anywhere, e.g. in error messages.
+func withSecretPlaceholders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, secretPlaceholdersKey{}, true)
+}
+
+// jobTemplateFuncs produces the functions available to job templates in addition to the sprig functions.
+// All secrets the template uses are recorded in secrets, so that the rendered template is not shown to users.
+func (srv *Service) jobTemplateFuncs(ctx context.Context, md *v1.JobMetadata, secrets *templateSecrets) template.FuncMap {
+	res := sprig.TxtFuncMap()
+	res["secret"] = func(name string, key ...string) (string, error) {
+		if srv.Executor == nil {
+			return "", xerrors.Errorf("secrets are not available")
+		}
+		data, err := srv.Executor.GetTemplateSecret(ctx, name)
+		if err != nil {
+			return "", err
+		}
+
+		var k string
+		switch {
+		case len(key) > 1:
+			return "", xerrors.Errorf("secret expects a name and an optional key")
+		case len(key) == 1:
+			k = key[0]
+		case len(data) == 1:
+			for dk := range data {
+				k = dk
+			}
+		default:
+			keys := make([]string, 0, len(data))
+			for dk := range data {
+				keys = append(keys, dk)
+			}
+			sort.Strings(keys)
+			return "", xerrors.Errorf("secret %s has several keys, please choose one of %s", name, strings.Join(keys, ", "))
+		}
+		val, ok := data[k]
+		if !ok {
+			return "", xerrors.Errorf("secret %s has no key %s", name, k)
+		}
+
+		if ctx.Value(secretPlaceholdersKey{}) != nil {
+			return fmt.Sprintf("<secret %s/%s>", name, k), nil
+		}
+
+		secrets.Add(name)
+		return string(val), nil
+	}
+	res["fileFromRepo"] = func(path string) (string, error) {
+		fp, err := srv.RepositoryProvider.FileProvider(ctx, md.Repository)
+		if err != nil {
+			return "", err
+		}
+		in, err := fp.Download(ctx, path)
+		if err != nil {
+			return "", xerrors.Errorf("cannot download %s: %w", path, err)
+		}
+		defer in.Close()
+
+		fc, err := ioutil.ReadAll(in)
+		if err != nil {
+			return "", xerrors.Errorf("cannot download %s: %w", path, err)
+		}
+		return string(fc), nil
+	}
+	res["isDefaultBranch"] = func() bool {
+		return isDefaultBranch(md.Repository)
+	}
+	return res
+}
+
+// isDefaultBranch returns true if the repository's ref is its default branch
+func isDefaultBranch(repo *v1.Repository) bool {
+	if repo.DefaultBranch == "" {
+		return false
+	}
+	return strings.TrimPrefix(repo.Ref, "refs/heads/") == strings.TrimPrefix(repo.DefaultBranch, "refs/heads/")
+}
+
+// renderJobYAML executes the job YAML as Go template. If strict is true, referring to
+// missing values, e.g. annotations which are not set, is an error.
+func (srv *Service) renderJobYAML(ctx context.Context, name string, md *v1.JobMetadata, jobYAML []byte, strict bool) (rendered []byte, secrets *templateSecrets, err error) {
+	secrets = &templateSecrets{}
+	tpl := template.New("job").Funcs(srv.jobTemplateFuncs(ctx, md, secrets))
+	if strict {
+		tpl = tpl.Option("missingkey=error")
+	}
+	tpl, err = tpl.Parse(string(jobYAML))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err = tpl.Execute(buf, srv.newTemplateObj(ctx, name, md, jobYAML))
+	if err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), secrets, nil
+}
+
+// templateSecrets collects the names of the secrets a job template uses. Once the template has used a secret,
+// its value can appear in the rendered template in any form, e.g. base64 encoded, hence we cannot redact it reliably.
+type templateSecrets struct {
+	mu    sync.Mutex
+	names []string
+}
+
+// Add records the use of a secret
+func (s *templateSecrets) Add(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, n := range s.names {
+		if n == name {
+			return
+		}
+	}
+	s.names = append(s.names, name)
+}
+
+// Names returns the sorted names of all secrets the template used
+func (s *templateSecrets) Names() []string {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := append([]string(nil), s.names...)
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,94 @@
+package werft
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/store"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRenderJobYAML(t *testing.T) {
+	jobs := store.NewInMemoryJobStore()
+	repo := &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Ref: "refs/heads/main", DefaultBranch: "main"}
+	for _, j := range []v1.JobStatus{
+		{Name: "werft-build-main.1", Phase: v1.JobPhase_PHASE_DONE, Conditions: &v1.JobConditions{Success: false}, Metadata: &v1.JobMetadata{Repository: repo, JobSpecName: "build", Created: &timestamp.Timestamp{Seconds: 10}}},
+		{Name: "werft-deploy-main.1", Phase: v1.JobPhase_PHASE_DONE, Conditions: &v1.JobConditions{Success: true}, Metadata: &v1.JobMetadata{Repository: repo, JobSpecName: "deploy", Created: &timestamp.Timestamp{Seconds: 20}}},
+	} {
+		err := jobs.Store(context.Background(), j)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	srv := &Service{
+		Jobs:   jobs,
+		Config: Config{BaseURL: "https://werft.example.com"},
+		RepositoryProvider: &testRepositoryProvider{Files: map[string]string{
+			"VERSION": "1.2.3",
+		}},
+	}
+
+	tests := []struct {
+		Name        string
+		Ref         string
+		Template    string
+		Expectation string
+		Error       string
+	}{
+		{Name: "number", Template: "{{ .Name }} {{ .Number }}", Expectation: "werft-build-main.2 2"},
+		{Name: "base URL", Template: "{{ .BaseURL }}/job/{{ .Name }}", Expectation: "https://werft.example.com/job/werft-build-main.2"},
+		{Name: "created", Template: `{{ .Created.UTC.Format "2006-01-02" }}`, Expectation: "1970-01-01"},
+		{Name: "plugins", Template: "schedule: {{ .Plugins.cron }}", Expectation: "schedule: 0 * * * *"},
+		{Name: "default branch", Template: "{{ isDefaultBranch }}", Expectation: "true"},
+		{Name: "other branch", Ref: "refs/heads/feature", Template: "{{ isDefaultBranch }}", Expectation: "false"},
+		{Name: "file from repo", Template: `{{ fileFromRepo "VERSION" }}`, Expectation: "1.2.3"},
+		{Name: "missing file", Template: `{{ fileFromRepo "missing" }}`, Error: `template: job:1:3: executing "job" at <fileFromRepo "missing">: error calling fileFromRepo: cannot download missing: missing not found`},
+		{Name: "previous job", Template: "{{ with .PreviousJob }}{{ .Name }} {{ .Conditions.Success }}{{ end }}", Expectation: "werft-build-main.1 false"},
+		{Name: "no previous job", Ref: "refs/heads/feature", Template: "{{ with .PreviousJob }}{{ .Name }}{{ else }}none{{ end }}", Expectation: "none"},
+		{Name: "secret without executor", Template: `{{ secret "foo" }}`, Error: `template: job:1:3: executing "job" at <secret "foo">: error calling secret: secrets are not available`},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			r := *repo
+			if test.Ref != "" {
+				r.Ref = test.Ref
+			}
+			md := &v1.JobMetadata{
+				Repository:  &r,
+				JobSpecName: "build",
+				Created:     &timestamp.Timestamp{Seconds: 30},
+			}
+
+			act, _, err := srv.renderJobYAML(context.Background(), "werft-build-main.2", md, []byte(test.Template+"\nplugins:\n  cron: \"0 * * * *\""), false)
+			var actErr string
+			if err != nil {
+				actErr = err.Error()
+			}
+			if actErr != test.Error {
+				t.Fatalf("expected error %q, got %q", test.Error, actErr)
+			}
+			if err != nil {
+				return
+			}
+
+			exp := test.Expectation + "\nplugins:\n  cron: \"0 * * * *\""
+			if string(act) != exp {
+				t.Errorf("expected %q, got %q", exp, string(act))
+			}
+		})
+	}
+}
+
+func TestTemplateSecretsNames(t *testing.T) {
+	var s templateSecrets
+	s.Add("npm")
+	s.Add("docker")
+	s.Add("npm")
+
+	act := s.Names()
+	if diff := cmp.Diff([]string{"docker", "npm"}, act); diff != "" {
+		t.Errorf("Names() mismatch (-want +got):\n%s", diff)
+	}
+}
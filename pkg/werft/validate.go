@@ -0,0 +1,165 @@
+package werft
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	k8sjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+)
+
+// Stages of the job preparation as reported by ValidateJob
+const (
+	stageRepository = "repository"
+	stageJobSpec    = "jobspec"
+	stageExtends    = "extends"
+	stageArgs       = "args"
+	stageTemplate   = "template"
+	stagePodspec    = "podspec"
+	stageSteps      = "steps"
+	stageServices   = "services"
+	stageSidecars   = "sidecars"
+	stageWorkspace  = "workspace"
+)
+
+// jobPreparationError is returned when a job cannot be prepared, and names the stage of the preparation that failed
+type jobPreparationError struct {
+	Stage string
+	Err   error
+}
+
+func (e *jobPreparationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *jobPreparationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateJob prepares a job like StartJob2 would, but instead of starting the job it returns the
+// rendered pod spec and all problems found while preparing the job.
+func (srv *Service) ValidateJob(ctx context.Context, req *v1.ValidateJobRequest) (*v1.ValidateJobResponse, error) {
+	if req.Metadata == nil || req.Metadata.Repository == nil {
+		return nil, status.Error(codes.InvalidArgument, "metadata and repository are required")
+	}
+	if req.Spec == nil {
+		return nil, status.Error(codes.InvalidArgument, "spec is required")
+	}
+	// the response shows the rendered job and errors of the template, neither of which must reveal secrets
+	ctx = withSecretPlaceholders(ctx)
+
+	var (
+		md   = proto.Clone(req.Metadata).(*v1.JobMetadata)
+		spec = req.Spec
+		resp = &v1.ValidateJobResponse{}
+	)
+	report := func(severity v1.DiagnosticSeverity, stage string, msg string) {
+		resp.Diagnostics = append(resp.Diagnostics, &v1.JobDiagnostic{
+			Severity: severity,
+			Stage:    stage,
+			Message:  msg,
+		})
+	}
+	fail := func(stage string, err error) (*v1.ValidateJobResponse, error) {
+		if s, ok := status.FromError(err); ok {
+			report(v1.DiagnosticSeverity_SEVERITY_ERROR, stage, s.Message())
+		} else {
+			report(v1.DiagnosticSeverity_SEVERITY_ERROR, stage, err.Error())
+		}
+		return resp, nil
+	}
+
+	if md.Trigger != v1.JobTrigger_TRIGGER_DELETED {
+		err := srv.RepositoryProvider.Resolve(ctx, md.Repository)
+		if err != nil {
+			return fail(stageRepository, xerrors.Errorf("cannot resolve repository: %w", err))
+		}
+
+		atns, err := srv.RepositoryProvider.RemoteAnnotations(ctx, md.Repository)
+		if err != nil {
+			report(v1.DiagnosticSeverity_SEVERITY_WARNING, stageRepository, "cannot get remote annotations: "+err.Error())
+		}
+		for k, v := range atns {
+			md.Annotations = append(md.Annotations, &v1.Annotation{Key: k, Value: v})
+		}
+	}
+
+	src, err := srv.findJobSource(ctx, md, spec)
+	if err != nil {
+		return fail(stageJobSpec, err)
+	}
+	jobYAML := src.YAML
+	if len(src.Paths) > 0 {
+		resp.JobPath = src.Paths[0]
+		if others := src.Paths[1:]; len(others) > 0 {
+			report(v1.DiagnosticSeverity_SEVERITY_INFO, stageJobSpec, "the repository config also starts "+strings.Join(others, ", ")+", which were not validated")
+			spec = specWithPath(spec, resp.JobPath)
+		}
+
+		jobYAML, err = downloadJobSpec(ctx, src.FP, resp.JobPath)
+		if err != nil {
+			return fail(stageJobSpec, err)
+		}
+	}
+
+	md.JobSpecName = jobSpecName(resp.JobPath)
+	resp.Name, _, err = jobName(md, spec)
+	if err != nil {
+		return fail(stageJobSpec, err)
+	}
+
+	jobRepo, jobPath := jobSpecOrigin(md, spec)
+	if jobPath == "" {
+		jobPath = resp.JobPath
+	}
+	jobYAML, err = srv.resolveExtends(ctx, jobRepo, jobPath, jobYAML)
+	if err != nil {
+		return fail(stageExtends, err)
+	}
+
+	args, err := repoconfig.ParseArgs(jobYAML)
+	if err != nil {
+		report(v1.DiagnosticSeverity_SEVERITY_WARNING, stageArgs, err.Error())
+	}
+	err = repoconfig.ValidateArgs(args, annotationMap(md))
+	if err != nil {
+		return fail(stageArgs, err)
+	}
+
+	cp, err := srv.getContentProvider(ctx, md, spec)
+	if err != nil {
+		return fail(stageWorkspace, err)
+	}
+	jobspec, _, err := srv.prepareJob(ctx, resp.Name, *md, cp, jobYAML)
+	if err != nil {
+		stage := stagePodspec
+		var perr *jobPreparationError
+		if xerrors.As(err, &perr) {
+			stage = perr.Stage
+		}
+		return fail(stage, err)
+	}
+
+	var podspec bytes.Buffer
+	err = k8sjson.NewYAMLSerializer(k8sjson.DefaultMetaFactory, nil, nil).Encode(&corev1.Pod{Spec: *redactPodSpec(jobspec.Pod)}, &podspec)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp.PodSpec = podspec.String()
+
+	// templates render missing values, e.g. annotations which are not set, as "<no value>" - which is rarely intended
+	_, _, err = srv.renderJobYAML(ctx, resp.Name, md, jobYAML, true)
+	if err != nil {
+		report(v1.DiagnosticSeverity_SEVERITY_WARNING, stageTemplate, err.Error())
+	}
+
+	resp.Valid = true
+	return resp, nil
+}
@@ -0,0 +1,335 @@
+package werft
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/executor"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+type testRepositoryProvider struct {
+	NoopRepositoryProvider
+
+	Files map[string]string
+}
+
+func (p *testRepositoryProvider) Resolve(ctx context.Context, repo *v1.Repository) error {
+	if repo.Revision == "" {
+		repo.Revision = "abc"
+	}
+	return nil
+}
+
+func (p *testRepositoryProvider) RemoteAnnotations(ctx context.Context, repo *v1.Repository) (map[string]string, error) {
+	return nil, nil
+}
+
+func (p *testRepositoryProvider) FileProvider(ctx context.Context, repo *v1.Repository) (FileProvider, error) {
+	return p, nil
+}
+
+func (p *testRepositoryProvider) ContentProvider(ctx context.Context, repo *v1.Repository, path ...string) (ContentProvider, error) {
+	return p, nil
+}
+
+func (p *testRepositoryProvider) Download(ctx context.Context, path string) (io.ReadCloser, error) {
+	c, ok := p.Files[path]
+	if !ok {
+		return nil, xerrors.Errorf("%s not found", path)
+	}
+	return ioutil.NopCloser(strings.NewReader(c)), nil
+}
+
+func (p *testRepositoryProvider) ListFiles(ctx context.Context, path string) ([]string, error) {
+	return nil, nil
+}
+
+func (p *testRepositoryProvider) InitContainer() ([]corev1.Container, error) {
+	return []corev1.Container{{Name: "checkout", Image: "alpine/git"}}, nil
+}
+
+func (p *testRepositoryProvider) Serve(jobName string) error {
+	return nil
+}
+
+// newTestExecutor produces an executor for the werft namespace which talks to a fake Kubernetes client with the objects
+func newTestExecutor(t *testing.T, objects ...runtime.Object) (*executor.Executor, *fake.Clientset) {
+	exec, err := executor.NewExecutor(executor.Config{
+		Namespace:       "werft",
+		JobPrepTimeout:  &executor.Duration{Duration: time.Minute},
+		JobTotalTimeout: &executor.Duration{Duration: time.Hour},
+	}, &rest.Config{})
+	if err != nil {
+		t.Fatalf("cannot create executor: %v", err)
+	}
+	client := fake.NewSimpleClientset(objects...)
+	exec.Client = client
+	return exec, client
+}
+
+func TestValidateJob(t *testing.T) {
+	srv := &Service{RepositoryProvider: &testRepositoryProvider{
+		Files: map[string]string{
+			PathWerftConfig: "defaultJob: .werft/build.yaml",
+			".werft/build.yaml": `
+pod:
+  containers:
+  - name: build
+    image: golang
+    args: ["{{ .Name }}"]
+`,
+			".werft/missing-annotation.yaml": `
+pod:
+  containers:
+  - name: build
+    image: "golang:{{ .Annotations.version }}"
+`,
+			".werft/sidecar.yaml": `
+pod:
+  containers:
+  - name: build
+    image: golang
+sidecars: ["redis"]
+`,
+			".werft/args.yaml": `
+args:
+- name: version
+  type: enum
+  values: ["1.15", "1.16"]
+  default: "1.16"
+- name: target
+  required: true
+pod:
+  containers:
+  - name: build
+    image: "golang:{{ .Annotations.version }}"
+    args: ["{{ .Annotations.target }}"]
+`,
+			".werft/steps.yaml": `
+steps:
+- name: build
+  image: golang:1.17
+  commands: ["go build ./..."]
+- name: test
+  image: golang:1.17
+  commands: ["go test ./..."]
+`,
+			".werft/pod-and-steps.yaml": `
+pod:
+  containers:
+  - name: build
+    image: golang
+steps:
+- image: golang
+  commands: ["go build ./..."]
+`,
+			".werft/services.yaml": `
+steps:
+- image: golang:1.17
+  commands: ["go build ./..."]
+- image: golang:1.17
+  commands: ["go test ./..."]
+services:
+- name: postgres
+  image: postgres:13
+  ports: [5432]
+  readiness: pg_isready
+`,
+			".werft/broken-template.yaml": "pod: {{ .Foo",
+			".werft/no-pod.yaml":          "description: nothing to see",
+		},
+	}}
+
+	type Expectation struct {
+		Valid       bool
+		Name        string
+		JobPath     string
+		Diagnostics []string
+	}
+	tests := []struct {
+		Name        string
+		Spec        *v1.JobSpec
+		Annotations []*v1.Annotation
+		Expectation Expectation
+		PodSpec     []string
+	}{
+		{
+			Name:        "from repo config",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{}},
+			Expectation: Expectation{Valid: true, Name: "werft-build-main", JobPath: ".werft/build.yaml"},
+			PodSpec:     []string{"- werft-build-main", "name: checkout", "mountPath: /workspace"},
+		},
+		{
+			Name:        "job yaml",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobYaml{JobYaml: []byte("pod:\n  containers:\n  - name: build\n    image: golang")}},
+			Expectation: Expectation{Valid: true, Name: "werft-custom-main"},
+		},
+		{
+			Name:        "missing annotation",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/missing-annotation.yaml"}},
+			Expectation: Expectation{Valid: true, Name: "werft-missing-annotation-main", JobPath: ".werft/missing-annotation.yaml", Diagnostics: []string{`SEVERITY_WARNING template: template: job:5:34: executing "job" at <.Annotations.version>: map has no entry for key "version"`}},
+		},
+		{
+			Name:        "args",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/args.yaml"}},
+			Annotations: []*v1.Annotation{{Key: "target", Value: "all"}},
+			Expectation: Expectation{Valid: true, Name: "werft-args-main", JobPath: ".werft/args.yaml"},
+			PodSpec:     []string{"image: golang:1.16", "- all"},
+		},
+		{
+			Name:        "invalid args",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/args.yaml"}},
+			Annotations: []*v1.Annotation{{Key: "version", Value: "1.14"}},
+			Expectation: Expectation{Name: "werft-args-main", JobPath: ".werft/args.yaml", Diagnostics: []string{`SEVERITY_ERROR args: invalid job arguments: version must be one of 1.15, 1.16, not "1.14"; target is required`}},
+		},
+		{
+			Name:        "steps",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/steps.yaml"}},
+			Expectation: Expectation{Valid: true, Name: "werft-steps-main", JobPath: ".werft/steps.yaml"},
+			PodSpec:     []string{"name: checkout", "[build|PHASE] build", "[test|DONE]", "workingDir: /workspace", "/.werft/steps/build.done", "mountPath: /.werft"},
+		},
+		{
+			Name:        "pod and steps",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/pod-and-steps.yaml"}},
+			Expectation: Expectation{Name: "werft-pod-and-steps-main", JobPath: ".werft/pod-and-steps.yaml", Diagnostics: []string{"SEVERITY_ERROR steps: cannot handle job for werft-pod-and-steps-main: job has both pod and steps"}},
+		},
+		{
+			Name:        "services",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/services.yaml"}},
+			Expectation: Expectation{Valid: true, Name: "werft-services-main", JobPath: ".werft/services.yaml"},
+			PodSpec:     []string{"name: postgres", "containerPort: 5432", "readinessProbe:", "pg_isready", "/.werft/services/postgres.ready"},
+		},
+		{
+			Name:        "missing sidecar",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/sidecar.yaml"}},
+			Expectation: Expectation{Name: "werft-sidecar-main", JobPath: ".werft/sidecar.yaml", Diagnostics: []string{`SEVERITY_ERROR sidecars: pod has no container "redis", but the job lists it as sidecar`}},
+		},
+		{
+			Name:        "broken template",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/broken-template.yaml"}},
+			Expectation: Expectation{Name: "werft-broken-template-main", JobPath: ".werft/broken-template.yaml", Diagnostics: []string{`SEVERITY_ERROR template: cannot handle job for werft-broken-template-main: template: job:1: unclosed action`}},
+		},
+		{
+			Name:        "no pod",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/no-pod.yaml"}},
+			Expectation: Expectation{Name: "werft-no-pod-main", JobPath: ".werft/no-pod.yaml", Diagnostics: []string{"SEVERITY_ERROR podspec: cannot handle job for werft-no-pod-main: no podspec present"}},
+		},
+		{
+			Name:        "missing job",
+			Spec:        &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: ".werft/missing.yaml"}},
+			Expectation: Expectation{JobPath: ".werft/missing.yaml", Diagnostics: []string{"SEVERITY_ERROR jobspec: cannot download jobspec from .werft/missing.yaml: .werft/missing.yaml not found"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			resp, err := srv.ValidateJob(context.Background(), &v1.ValidateJobRequest{
+				Metadata: &v1.JobMetadata{
+					Owner:       "csweichel",
+					Repository:  &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Ref: "refs/heads/main"},
+					Trigger:     v1.JobTrigger_TRIGGER_MANUAL,
+					Annotations: test.Annotations,
+				},
+				Spec: test.Spec,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			act := Expectation{
+				Valid:   resp.Valid,
+				Name:    resp.Name,
+				JobPath: resp.JobPath,
+			}
+			for _, d := range resp.Diagnostics {
+				act.Diagnostics = append(act.Diagnostics, d.Severity.String()+" "+d.Stage+": "+d.Message)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("ValidateJob() mismatch (-want +got):\n%s", diff)
+			}
+			for _, s := range test.PodSpec {
+				if !strings.Contains(resp.PodSpec, s) {
+					t.Errorf("expected pod spec to contain %q:\n%s", s, resp.PodSpec)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateJobSecrets(t *testing.T) {
+	const secretValue = "s3cr3t-t0k3n"
+	exec, _ := newTestExecutor(t, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy-token", Namespace: "werft", Labels: map[string]string{"werft.dev/templateSecret": "true"}},
+		Data:       map[string][]byte{"token": []byte(secretValue)},
+	})
+	srv := &Service{Executor: exec, RepositoryProvider: &testRepositoryProvider{
+		Files: map[string]string{
+			".werft/deploy.yaml": `
+pod:
+  containers:
+  - name: deploy
+    image: alpine
+    env:
+    - name: ENCODED_TOKEN
+      value: {{ secret "deploy-token" | b64enc }}
+`,
+			".werft/fail.yaml":    `{{ fail (secret "deploy-token") }}`,
+			".werft/missing.yaml": `{{ secret "does-not-exist" }}`,
+		},
+	}}
+
+	tests := []struct {
+		Name        string
+		Path        string
+		Valid       bool
+		PodSpec     string
+		Diagnostics []string
+	}{
+		{Name: "placeholder", Path: ".werft/deploy.yaml", Valid: true, PodSpec: "PHNlY3JldCBkZXBsb3ktdG9rZW4vdG9rZW4+"},
+		{Name: "error message", Path: ".werft/fail.yaml", Diagnostics: []string{`template: cannot handle job for werft-fail-main: template: job:1:3: executing "job" at <fail (secret "deploy-token")>: error calling fail: <secret deploy-token/token>`}},
+		{Name: "missing secret", Path: ".werft/missing.yaml", Diagnostics: []string{`template: cannot handle job for werft-missing-main: template: job:1:3: executing "job" at <secret "does-not-exist">: error calling secret: secret does-not-exist is not available to job templates`}},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			resp, err := srv.ValidateJob(context.Background(), &v1.ValidateJobRequest{
+				Metadata: &v1.JobMetadata{
+					Owner:      "csweichel",
+					Repository: &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Ref: "refs/heads/main"},
+					Trigger:    v1.JobTrigger_TRIGGER_MANUAL,
+				},
+				Spec: &v1.JobSpec{Source: &v1.JobSpec_JobPath{JobPath: test.Path}},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var diagnostics []string
+			for _, d := range resp.Diagnostics {
+				diagnostics = append(diagnostics, d.Stage+": "+d.Message)
+			}
+			if diff := cmp.Diff(test.Diagnostics, diagnostics); diff != "" {
+				t.Errorf("ValidateJob() diagnostics mismatch (-want +got):\n%s", diff)
+			}
+			if resp.Valid != test.Valid {
+				t.Errorf("expected valid to be %v", test.Valid)
+			}
+			if !strings.Contains(resp.PodSpec, test.PodSpec) {
+				t.Errorf("expected pod spec to contain %q:\n%s", test.PodSpec, resp.PodSpec)
+			}
+			if act := resp.String(); strings.Contains(act, secretValue) {
+				t.Errorf("response reveals the secret value: %s", act)
+			}
+		})
+	}
+}
@@ -10,10 +10,8 @@ import (
 	"reflect"
 	"strings"
 	"sync"
-	"text/template"
 	"time"
 
-	sprig "github.com/Masterminds/sprig/v3"
 	"github.com/csweichel/werft/pkg/api/repoconfig"
 	v1 "github.com/csweichel/werft/pkg/api/v1"
 	"github.com/csweichel/werft/pkg/executor"
@@ -54,6 +52,14 @@ type Config struct {
 	// logs older than the configured duration.
 	GCOlderThan *executor.Duration `yaml:"gcOlderThan,omitempty"`
 
+	// Retention configures how long jobs and their logs are kept. The first rule matching a job determines
+	// its retention. If GCOlderThan is set, jobs which match no rule are kept for that duration, otherwise forever.
+	Retention []RetentionRule `yaml:"retention,omitempty"`
+
+	// CancelSuperseded stops the running jobs of a repository, ref and job spec when a newer job for them is started.
+	// Repositories can override this using cancelSuperseded in their .werft/config.yaml.
+	CancelSuperseded bool `yaml:"cancelSuperseded,omitempty"`
+
 	// Enables the webui debug proxy pointing to this address
 	DebugProxy string
 }
@@ -84,6 +90,7 @@ type Service struct {
 	Logs               store.Logs
 	Jobs               store.Jobs
 	Groups             store.NumberGroup
+	Schedules          store.Schedules
 	Executor           *executor.Executor
 	Cutter             logcutter.Cutter
 	RepositoryProvider RepositoryProvider
@@ -92,6 +99,7 @@ type Service struct {
 
 	mu          sync.RWMutex
 	logListener map[string]*jobLog
+	retention   []retentionRule
 
 	events  emitter.Emitter
 	metrics struct {
@@ -109,6 +117,12 @@ func (srv *Service) Start() error {
 	}
 	srv.Executor.OnUpdate = srv.handleJobUpdate
 
+	retention, err := compileRetentionRules(srv.Config.Retention)
+	if err != nil {
+		return err
+	}
+	srv.retention = retention
+
 	// set up prometheus gauges
 	srv.metrics.GithubJobPreparationSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
 		Name:    "github_job_preparation_seconds",
@@ -136,6 +150,9 @@ func (srv *Service) Start() error {
 	})
 
 	go srv.doHousekeeping()
+	if srv.Schedules != nil {
+		go srv.runSchedules()
+	}
 
 	return nil
 }
@@ -153,7 +170,13 @@ func (srv *Service) doHousekeeping() {
 	for {
 		log.Debug("performing werft service housekeeping")
 
-		if srv.Config.GCOlderThan != nil {
+		if len(srv.retention) > 0 {
+			log.Info("running garbage collection using retention rules")
+			err := srv.applyRetention(context.Background(), srv.retention)
+			if err != nil {
+				log.WithError(err).Error("job GC error")
+			}
+		} else if srv.Config.GCOlderThan != nil {
 			olderThan := srv.Config.GCOlderThan.Duration
 			log.WithField("olderThan", olderThan.String()).Info("running garbage collection")
 
@@ -445,6 +468,12 @@ func (srv *Service) RunJob(ctx context.Context, name string, metadata v1.JobMeta
 		<-srv.events.Emit("job", status)
 	}(&err)
 
+	jobRepo, jobPath := jobSpecOrigin(&metadata, &spec)
+	jobYAML, err = srv.resolveExtends(ctx, jobRepo, jobPath, jobYAML)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot handle job for %s: %w", name, err)
+	}
+
 	if canReplay {
 		// save job yaml
 		err = srv.Jobs.StoreJobSpec(name, spec, jobYAML)
@@ -453,27 +482,95 @@ func (srv *Service) RunJob(ctx context.Context, name string, metadata v1.JobMeta
 		}
 	}
 
-	jobTpl, err := template.New("job").Funcs(sprig.TxtFuncMap()).Parse(string(jobYAML))
+	jobspec, secrets, err := srv.prepareJob(ctx, name, metadata, cp, jobYAML)
 	if err != nil {
-		return nil, xerrors.Errorf("cannot handle job for %s: %w", name, err)
+		return nil, err
+	}
+	podspec := jobspec.Pod
+
+	logs, err = srv.Logs.Open(name)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot start logging for %s: %w", name, err)
+	}
+	srv.mu.Lock()
+	srv.logListener[name] = &jobLog{LogStore: logs}
+	srv.mu.Unlock()
+	fmt.Fprintln(logs, "[preparing|PHASE] job preparation")
+
+	// dump podspec into logs - unless it may contain secrets
+	pw := textio.NewPrefixWriter(logs, "[werft:template] ")
+	if names := secrets.Names(); len(names) > 0 {
+		fmt.Fprintf(pw, "pod spec is not shown because the job template uses the secrets %s\n", strings.Join(names, ", "))
+	} else {
+		k8sjson.NewYAMLSerializer(k8sjson.DefaultMetaFactory, nil, nil).Encode(&corev1.Pod{Spec: *redactPodSpec(podspec)}, pw)
 	}
+	pw.Flush()
 
-	buf := bytes.NewBuffer(nil)
-	err = jobTpl.Execute(buf, newTemplateObj(name, &metadata))
+	// schedule/start job
+	tExecutorPrepStart := time.Now()
+	opts := []executor.StartOpt{
+		executor.WithName(name),
+		executor.WithCanReplay(canReplay),
+		executor.WithMutex(jobspec.Mutex),
+		executor.WithSidecars(jobspec.Sidecars),
+	}
+	if jobspec.Approval != nil {
+		opts = append(opts, executor.WithApproval(jobApproval(jobspec.Approval)))
+		fmt.Fprintln(logs, "[werft] job is awaiting approval")
+	}
+	status, err = srv.Executor.Start(*podspec, metadata, opts...)
+	srv.metrics.ExecutorJobStartsCounter.Inc()
 	if err != nil {
+		srv.metrics.ExecutorJobFailedStartsCounter.Inc()
 		return nil, xerrors.Errorf("cannot handle job for %s: %w", name, err)
 	}
+	name = status.Name
+	srv.metrics.ExecutorJobPreperationSeconds.Observe(time.Since(tExecutorPrepStart).Seconds())
+
+	err = cp.Serve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// prepareJob renders the job YAML and produces the job spec with the pod spec that's ready to run
+// The secrets the job template used are returned, so that the pod spec is not shown to users if it may contain them.
+func (srv *Service) prepareJob(ctx context.Context, name string, metadata v1.JobMetadata, cp ContentProvider, jobYAML []byte) (*repoconfig.JobSpec, *templateSecrets, error) {
+	rendered, secrets, err := srv.renderJobYAML(ctx, name, &metadata, jobYAML, false)
+	if err != nil {
+		return nil, nil, &jobPreparationError{Stage: stageTemplate, Err: xerrors.Errorf("cannot handle job for %s: %w", name, err)}
+	}
 
 	// we have to use the Kubernetes YAML decoder to decode the podspec
 	var jobspec repoconfig.JobSpec
-	err = k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(buf.Bytes()), 4096).Decode(&jobspec)
+	err = k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(rendered), 4096).Decode(&jobspec)
 	if err != nil {
-		return nil, xerrors.Errorf("cannot handle job for %s: %w", name, err)
+		return nil, nil, &jobPreparationError{Stage: stagePodspec, Err: xerrors.Errorf("cannot handle job for %s: %w", name, err)}
+	}
+
+	if len(jobspec.Steps) > 0 {
+		if jobspec.Pod != nil {
+			return nil, nil, &jobPreparationError{Stage: stageSteps, Err: xerrors.Errorf("cannot handle job for %s: job has both pod and steps", name)}
+		}
+		jobspec.Pod, err = compileSteps(jobspec.Steps)
+		if err != nil {
+			return nil, nil, &jobPreparationError{Stage: stageSteps, Err: xerrors.Errorf("cannot handle job for %s: %w", name, err)}
+		}
 	}
 
 	podspec := jobspec.Pod
 	if podspec == nil {
-		return nil, xerrors.Errorf("cannot handle job for %s: no podspec present", name)
+		return nil, nil, &jobPreparationError{Stage: stagePodspec, Err: xerrors.Errorf("cannot handle job for %s: no podspec present", name)}
+	}
+
+	if len(jobspec.Services) > 0 {
+		svcs, err := addServices(podspec, jobspec.Services, jobspec.Sidecars)
+		if err != nil {
+			return nil, nil, &jobPreparationError{Stage: stageServices, Err: xerrors.Errorf("cannot handle job for %s: %w", name, err)}
+		}
+		jobspec.Sidecars = append(jobspec.Sidecars, svcs...)
 	}
 
 	for _, s := range jobspec.Sidecars {
@@ -486,7 +583,7 @@ func (srv *Service) RunJob(ctx context.Context, name string, metadata v1.JobMeta
 		}
 
 		if !found {
-			return nil, xerrors.Errorf("pod has no container \"%s\", but the job lists it as sidecar", s)
+			return nil, nil, &jobPreparationError{Stage: stageSidecars, Err: xerrors.Errorf("pod has no container \"%s\", but the job lists it as sidecar", s)}
 		}
 	}
 
@@ -514,7 +611,7 @@ func (srv *Service) RunJob(ctx context.Context, name string, metadata v1.JobMeta
 
 	ics, err := cp.InitContainer()
 	if err != nil {
-		return nil, xerrors.Errorf("cannot produce init container: %w", err)
+		return nil, nil, &jobPreparationError{Stage: stageWorkspace, Err: xerrors.Errorf("cannot produce init container: %w", err)}
 	}
 	for i, ic := range ics {
 		ics[i].VolumeMounts = append(ic.VolumeMounts, corev1.VolumeMount{
@@ -532,19 +629,13 @@ func (srv *Service) RunJob(ctx context.Context, name string, metadata v1.JobMeta
 		})
 	}
 
-	logs, err = srv.Logs.Open(name)
-	if err != nil {
-		return nil, xerrors.Errorf("cannot start logging for %s: %w", name, err)
-	}
-	srv.mu.Lock()
-	srv.logListener[name] = &jobLog{LogStore: logs}
-	srv.mu.Unlock()
-	fmt.Fprintln(logs, "[preparing|PHASE] job preparation")
+	return &jobspec, secrets, nil
+}
 
-	// dump podspec into logs
-	pw := textio.NewPrefixWriter(logs, "[werft:template] ")
-	redactedSpec := podspec.DeepCopy()
-	for ci, c := range redactedSpec.InitContainers {
+// redactPodSpec copies a pod spec and hides the values of secret env vars of its init containers
+func redactPodSpec(podspec *corev1.PodSpec) *corev1.PodSpec {
+	res := podspec.DeepCopy()
+	for ci, c := range res.InitContainers {
 		for ei, e := range c.Env {
 			log.WithField("conts", strings.Contains(strings.ToLower(e.Name), "secret")).WithField("name", e.Name).Debug("redacting")
 			if !strings.Contains(strings.ToLower(e.Name), "secret") {
@@ -553,34 +644,10 @@ func (srv *Service) RunJob(ctx context.Context, name string, metadata v1.JobMeta
 
 			e.Value = "[redacted]"
 			c.Env[ei] = e
-			redactedSpec.InitContainers[ci] = c
+			res.InitContainers[ci] = c
 		}
 	}
-	k8sjson.NewYAMLSerializer(k8sjson.DefaultMetaFactory, nil, nil).Encode(&corev1.Pod{Spec: *redactedSpec}, pw)
-	pw.Flush()
-
-	// schedule/start job
-	tExecutorPrepStart := time.Now()
-	status, err = srv.Executor.Start(*podspec, metadata,
-		executor.WithName(name),
-		executor.WithCanReplay(canReplay),
-		executor.WithMutex(jobspec.Mutex),
-		executor.WithSidecars(jobspec.Sidecars),
-	)
-	srv.metrics.ExecutorJobStartsCounter.Inc()
-	if err != nil {
-		srv.metrics.ExecutorJobFailedStartsCounter.Inc()
-		return nil, xerrors.Errorf("cannot handle job for %s: %w", name, err)
-	}
-	name = status.Name
-	srv.metrics.ExecutorJobPreperationSeconds.Observe(time.Since(tExecutorPrepStart).Seconds())
-
-	err = cp.Serve(name)
-	if err != nil {
-		return nil, err
-	}
-
-	return status, nil
+	return res
 }
 
 // cleanupWorkspace starts a cleanup job for a previously run job
@@ -640,26 +707,3 @@ func (srv *Service) cleanupJobWorkspace(s *v1.JobStatus) {
 		log.WithError(err).WithField("name", name).Error("cannot start cleanup job")
 	}
 }
-
-type templateObj struct {
-	Name        string
-	Owner       string
-	Repository  v1.Repository
-	Trigger     string
-	Annotations map[string]string
-}
-
-func newTemplateObj(name string, md *v1.JobMetadata) templateObj {
-	annotations := make(map[string]string)
-	for _, a := range md.Annotations {
-		annotations[a.Key] = a.Value
-	}
-
-	return templateObj{
-		Name:        name,
-		Owner:       md.Owner,
-		Repository:  *md.Repository,
-		Trigger:     strings.ToLower(strings.TrimPrefix(md.Trigger.String(), "TRIGGER_")),
-		Annotations: annotations,
-	}
-}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/filterexpr"
+	plugin "github.com/csweichel/werft/pkg/plugin/client"
+	"github.com/csweichel/werft/pkg/reporef"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultInterval = time.Minute
+	defaultRefs     = "refs/heads/*"
+	jobOwner        = "git-poll"
+)
+
+// Config configures this plugin
+type Config struct {
+	// Interval is the time between two polls, e.g. 30s. Defaults to 1m.
+	Interval string `yaml:"interval,omitempty"`
+
+	// StateFile is where the last seen revisions are stored, so that restarts don't re-trigger jobs.
+	StateFile string `yaml:"stateFile"`
+
+	Repositories []RepositoryConfig `yaml:"repositories"`
+}
+
+// RepositoryConfig configures a single polled repository
+type RepositoryConfig struct {
+	// URL is passed to git ls-remote, e.g. https://gitea.example.com/org/repo.git
+	URL string `yaml:"url"`
+
+	// Repo is the repository jobs are started for in the form of host/owner/repo. A repository plugin
+	// which serves this host must be configured.
+	Repo string `yaml:"repo"`
+
+	// DefaultBranch is the name of the default branch, e.g. main. When a ref is deleted, the job is read from this branch.
+	DefaultBranch string `yaml:"defaultBranch"`
+
+	// Refs are glob patterns of the refs which trigger jobs. Defaults to refs/heads/*.
+	Refs []string `yaml:"refs,omitempty"`
+}
+
+func main() {
+	plugin.Serve(&Config{},
+		plugin.WithIntegrationPlugin(&gitPollPlugin{}),
+	)
+}
+
+type gitPollPlugin struct{}
+
+func (*gitPollPlugin) Run(ctx context.Context, config interface{}, srv *plugin.Services) error {
+	cfg, ok := config.(*Config)
+	if !ok {
+		return fmt.Errorf("config has wrong type %s", reflect.TypeOf(config))
+	}
+
+	interval := defaultInterval
+	if cfg.Interval != "" {
+		var err error
+		interval, err = time.ParseDuration(cfg.Interval)
+		if err != nil {
+			return fmt.Errorf("invalid interval: %w", err)
+		}
+	}
+
+	p, err := newPoller(cfg, srv)
+	if err != nil {
+		return err
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		p.Poll(ctx)
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// state maps repository URLs to the last seen revision of each ref
+type state map[string]map[string]string
+
+type repository struct {
+	RepositoryConfig
+	Repo *v1.Repository
+}
+
+type poller struct {
+	Repos     []repository
+	Werft     v1.WerftServiceClient
+	StateFile string
+
+	state state
+	mu    sync.Mutex
+}
+
+func newPoller(cfg *Config, werft v1.WerftServiceClient) (*poller, error) {
+	if cfg.StateFile == "" {
+		return nil, fmt.Errorf("stateFile is required")
+	}
+
+	var repos []repository
+	for _, r := range cfg.Repositories {
+		if r.URL == "" {
+			return nil, fmt.Errorf("repository without url")
+		}
+		repo, err := reporef.Parse(r.Repo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repo for %s: %w", r.URL, err)
+		}
+		if repo.Host == "" {
+			return nil, fmt.Errorf("invalid repo for %s: host is missing", r.URL)
+		}
+		repo.DefaultBranch = r.DefaultBranch
+
+		if len(r.Refs) == 0 {
+			r.Refs = []string{defaultRefs}
+		}
+		repos = append(repos, repository{RepositoryConfig: r, Repo: repo})
+	}
+
+	st, err := loadState(cfg.StateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &poller{
+		Repos:     repos,
+		Werft:     werft,
+		StateFile: cfg.StateFile,
+		state:     st,
+	}, nil
+}
+
+// Poll checks all repositories for changed refs once and starts jobs for them
+func (p *poller) Poll(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, repo := range p.Repos {
+		err := p.pollRepo(ctx, repo)
+		if err != nil {
+			log.WithError(err).WithField("url", repo.URL).Warn("cannot poll repository")
+		}
+	}
+
+	err := saveState(p.StateFile, p.state)
+	if err != nil {
+		log.WithError(err).Error("cannot save state")
+	}
+}
+
+func (p *poller) pollRepo(ctx context.Context, repo repository) error {
+	refs, err := lsRemote(ctx, repo.URL)
+	if err != nil {
+		return err
+	}
+	for ref := range refs {
+		if !matchesAny(repo.Refs, ref) {
+			delete(refs, ref)
+		}
+	}
+
+	known, ok := p.state[repo.URL]
+	if !ok {
+		// We've never seen this repository before. Rather than starting a job for every ref that exists,
+		// we remember where the refs are and start jobs for changes from here on.
+		log.WithField("url", repo.URL).WithField("refs", len(refs)).Info("polling new repository")
+		p.state[repo.URL] = refs
+		return nil
+	}
+
+	for _, ref := range sortedKeys(refs) {
+		rev := refs[ref]
+		if known[ref] == rev {
+			continue
+		}
+
+		err := p.startJob(ctx, repo, ref, known[ref], rev, v1.JobTrigger_TRIGGER_PUSH)
+		if status.Code(err) == codes.FailedPrecondition {
+			// no job matches this change, e.g. because of path filters - trying again won't change that
+			log.WithError(err).WithField("ref", ref).WithField("url", repo.URL).Debug("not starting job")
+			err = nil
+		}
+		if err != nil {
+			// we don't update the state so that we try again during the next poll
+			log.WithError(err).WithField("ref", ref).WithField("url", repo.URL).Warn("cannot start job")
+			continue
+		}
+		known[ref] = rev
+	}
+	for _, ref := range sortedKeys(known) {
+		if _, exists := refs[ref]; exists {
+			continue
+		}
+
+		err := p.startJob(ctx, repo, ref, "", "", v1.JobTrigger_TRIGGER_DELETED)
+		if err != nil {
+			log.WithError(err).WithField("ref", ref).WithField("url", repo.URL).Warn("cannot start job")
+			continue
+		}
+		delete(known, ref)
+	}
+
+	return nil
+}
+
+func (p *poller) startJob(ctx context.Context, repo repository, ref, before, rev string, trigger v1.JobTrigger) error {
+	req := &v1.StartJobRequest2{
+		Metadata: &v1.JobMetadata{
+			Owner: jobOwner,
+			Repository: &v1.Repository{
+				Host:          repo.Repo.Host,
+				Owner:         repo.Repo.Owner,
+				Repo:          repo.Repo.Repo,
+				Ref:           ref,
+				Revision:      rev,
+				DefaultBranch: repo.Repo.DefaultBranch,
+			},
+			Trigger: trigger,
+		},
+		Spec: &v1.JobSpec{
+			// let werft decide where to get the job from
+			Source: &v1.JobSpec_JobPath{},
+		},
+	}
+	if before != "" {
+		// path filters consider all changes since the last revision we've seen
+		req.Metadata.Annotations = append(req.Metadata.Annotations, &v1.Annotation{
+			Key:   repoconfig.AnnotationChangedFilesBase,
+			Value: before,
+		})
+	}
+	if trigger == v1.JobTrigger_TRIGGER_DELETED {
+		if repo.Repo.DefaultBranch == "" {
+			return fmt.Errorf("cannot start job for deleted ref without default branch")
+		}
+
+		// the ref is gone, hence the job has to come from the default branch
+		defaultBranch := &v1.Repository{
+			Host:          repo.Repo.Host,
+			Owner:         repo.Repo.Owner,
+			Repo:          repo.Repo.Repo,
+			Ref:           "refs/heads/" + repo.Repo.DefaultBranch,
+			DefaultBranch: repo.Repo.DefaultBranch,
+		}
+		req.Spec.Source = &v1.JobSpec_Repo{
+			Repo: &v1.JobSpec_FromRepo{
+				Repo: defaultBranch,
+			},
+		}
+		req.Spec.RepoSideload = append(req.Spec.RepoSideload, &v1.JobSpec_FromRepo{
+			Repo: defaultBranch,
+			Path: ".werft",
+		})
+	}
+
+	resp, err := p.Werft.StartJob2(ctx, req)
+	if err != nil {
+		return err
+	}
+	log.WithField("name", resp.Status.Name).WithField("ref", ref).WithField("trigger", trigger).Info("started job")
+	return nil
+}
+
+// lsRemote lists the refs of a remote repository and their revisions. Annotated tags are resolved to the commit they point to.
+func lsRemote(ctx context.Context, url string) (map[string]string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", "--", url)
+	cmd.Stderr = &stderr
+	// never ask for credentials - there's no-one to answer
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-remote failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var (
+		res    = make(map[string]string)
+		peeled = make(map[string]string)
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		segs := strings.Fields(scanner.Text())
+		if len(segs) != 2 {
+			continue
+		}
+		rev, ref := segs[0], segs[1]
+		if strings.HasSuffix(ref, "^{}") {
+			peeled[strings.TrimSuffix(ref, "^{}")] = rev
+			continue
+		}
+		res[ref] = rev
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for ref, rev := range peeled {
+		res[ref] = rev
+	}
+	return res, nil
+}
+
+func matchesAny(patterns []string, ref string) bool {
+	for _, p := range patterns {
+		if filterexpr.GlobToRegexp(p).MatchString(ref) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func loadState(fn string) (state, error) {
+	fc, err := ioutil.ReadFile(fn)
+	if os.IsNotExist(err) {
+		return make(state), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read state: %w", err)
+	}
+
+	var res state
+	err = json.Unmarshal(fc, &res)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read state: %w", err)
+	}
+	if res == nil {
+		res = make(state)
+	}
+	return res, nil
+}
+
+// saveState writes the state to a temporary file first, so that we never leave a partially written state behind
+func saveState(fn string, st state) error {
+	fc, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(fn), filepath.Base(fn)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(fc)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), fn)
+}
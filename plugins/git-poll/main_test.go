@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+)
+
+type startedJob struct {
+	Ref      string
+	Revision string
+	Base     string
+	Trigger  v1.JobTrigger
+}
+
+type fakeWerft struct {
+	v1.WerftServiceClient
+	Jobs []startedJob
+}
+
+func (f *fakeWerft) StartJob2(ctx context.Context, in *v1.StartJobRequest2, opts ...grpc.CallOption) (*v1.StartJobResponse, error) {
+	repo := in.Metadata.Repository
+	job := startedJob{Ref: repo.Ref, Revision: repo.Revision, Trigger: in.Metadata.Trigger}
+	for _, a := range in.Metadata.Annotations {
+		if a.Key == repoconfig.AnnotationChangedFilesBase {
+			job.Base = a.Value
+		}
+	}
+	f.Jobs = append(f.Jobs, job)
+	return &v1.StartJobResponse{Status: &v1.JobStatus{Name: repo.Repo}}, nil
+}
+
+func git(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=werft", "GIT_AUTHOR_EMAIL=werft@example.com",
+		"GIT_COMMITTER_NAME=werft", "GIT_COMMITTER_EMAIL=werft@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v: %s", args, err, out)
+	}
+	return string(out)
+}
+
+func revParse(t *testing.T, dir, ref string) string {
+	out := git(t, dir, "rev-parse", ref)
+	return out[:len(out)-1]
+}
+
+func TestPoll(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	var (
+		base      = t.TempDir()
+		remote    = filepath.Join(base, "remote.git")
+		work      = filepath.Join(base, "work")
+		stateFile = filepath.Join(base, "state.json")
+	)
+	git(t, base, "init", "--bare", "-b", "main", remote)
+	git(t, base, "clone", remote, work)
+	git(t, work, "commit", "--allow-empty", "-m", "initial")
+	git(t, work, "push", "origin", "HEAD:refs/heads/main")
+	git(t, work, "push", "origin", "HEAD:refs/heads/old")
+
+	cfg := &Config{
+		StateFile: stateFile,
+		Repositories: []RepositoryConfig{
+			{URL: remote, Repo: "git.example.com/org/repo", DefaultBranch: "main", Refs: []string{"refs/heads/*", "refs/tags/v*"}},
+		},
+	}
+	newTestPoller := func(werft *fakeWerft) *poller {
+		p, err := newPoller(cfg, werft)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	// the first poll must not start jobs for refs which existed before
+	werft := &fakeWerft{}
+	newTestPoller(werft).Poll(context.Background())
+	if len(werft.Jobs) != 0 {
+		t.Fatalf("expected no jobs on first poll, got %v", werft.Jobs)
+	}
+
+	initial := revParse(t, work, "HEAD")
+	git(t, work, "commit", "--allow-empty", "-m", "second")
+	git(t, work, "push", "origin", "HEAD:refs/heads/main")
+	git(t, work, "push", "origin", "HEAD:refs/heads/feature/foo")
+	git(t, work, "push", "origin", ":refs/heads/old")
+	git(t, work, "tag", "-a", "v1.0", "-m", "release")
+	git(t, work, "tag", "other")
+	git(t, work, "push", "origin", "v1.0", "other")
+	head := revParse(t, work, "HEAD")
+
+	// restarting the plugin must not lose track of what we've seen
+	p := newTestPoller(werft)
+	p.Poll(context.Background())
+	expectation := []startedJob{
+		{Ref: "refs/heads/feature/foo", Revision: head, Trigger: v1.JobTrigger_TRIGGER_PUSH},
+		{Ref: "refs/heads/main", Revision: head, Base: initial, Trigger: v1.JobTrigger_TRIGGER_PUSH},
+		{Ref: "refs/tags/v1.0", Revision: head, Trigger: v1.JobTrigger_TRIGGER_PUSH},
+		{Ref: "refs/heads/old", Trigger: v1.JobTrigger_TRIGGER_DELETED},
+	}
+	if diff := cmp.Diff(expectation, werft.Jobs); diff != "" {
+		t.Errorf("started jobs mismatch (-want +got):\n%s", diff)
+	}
+
+	// nothing changed, hence nothing should be started
+	werft.Jobs = nil
+	p.Poll(context.Background())
+	newTestPoller(werft).Poll(context.Background())
+	if len(werft.Jobs) != 0 {
+		t.Errorf("expected no jobs without changes, got %v", werft.Jobs)
+	}
+}
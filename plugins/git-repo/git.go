@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mirrorCache maintains bare mirrors of remote repositories
+type mirrorCache struct {
+	Base string
+	Auth AuthConfig
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock serialises all operations on a single mirror
+func (m *mirrorCache) lock(dir string) func() {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*sync.Mutex)
+	}
+	l, ok := m.locks[dir]
+	if !ok {
+		l = &sync.Mutex{}
+		m.locks[dir] = l
+	}
+	m.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// git runs git with the configured credentials and returns its stdout
+func (m *mirrorCache) git(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	var (
+		gitArgs []string
+		// never ask for credentials - there's no-one to answer
+		env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	)
+	if m.Auth.PasswordFile != "" {
+		// the password is read by the credential helper so that it never shows up in the process list
+		gitArgs = append(gitArgs, "-c", "credential.helper=", "-c", `credential.helper=!f() { echo username=$GIT_USER_SECRET; echo password=$(cat "$GIT_PASS_FILE"); }; f`)
+		env = append(env, "GIT_USER_SECRET="+m.Auth.Username, "GIT_PASS_FILE="+m.Auth.PasswordFile)
+	}
+	if m.Auth.SSHKeyFile != "" {
+		env = append(env, "GIT_SSH_COMMAND=ssh -i "+m.Auth.SSHKeyFile+" -o StrictHostKeyChecking=accept-new -o BatchMode=yes")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", append(gitArgs, args...)...)
+	cmd.Dir = dir
+	cmd.Env = env
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Resolve resolves a ref to a revision using ls-remote. Refs which don't start with refs/ are tried as branch and tag.
+func (m *mirrorCache) Resolve(ctx context.Context, url, ref string) (string, error) {
+	if strings.HasPrefix(ref, "-") {
+		return "", status.Errorf(codes.InvalidArgument, "invalid ref %s", ref)
+	}
+
+	out, err := m.git(ctx, "", "ls-remote", "--", url)
+	if err != nil {
+		return "", status.Error(codes.Unavailable, err.Error())
+	}
+
+	refs := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		segs := strings.Fields(scanner.Text())
+		if len(segs) != 2 {
+			continue
+		}
+		refs[segs[1]] = segs[0]
+	}
+
+	candidates := []string{ref}
+	if !strings.HasPrefix(ref, "refs/") {
+		candidates = []string{"refs/heads/" + ref, "refs/tags/" + ref}
+	}
+	for _, c := range candidates {
+		// annotated tags are resolved to the commit they point to
+		if rev, ok := refs[c+"^{}"]; ok {
+			return rev, nil
+		}
+		if rev, ok := refs[c]; ok {
+			return rev, nil
+		}
+	}
+	return "", status.Errorf(codes.NotFound, "ref %s not found", ref)
+}
+
+// ensureRevision makes sure the mirror in dir exists and contains the revision. Callers must hold the mirror's lock.
+func (m *mirrorCache) ensureRevision(ctx context.Context, dir, url, rev string) error {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return status.Errorf(codes.InvalidArgument, "invalid revision %q", rev)
+	}
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		err = os.MkdirAll(filepath.Dir(dir), 0755)
+		if err != nil {
+			return status.Errorf(codes.Internal, "cannot create mirror: %q", err)
+		}
+		log.WithField("url", url).WithField("dir", dir).Info("creating mirror")
+		_, err = m.git(ctx, "", "clone", "--mirror", "--", url, dir)
+		if err != nil {
+			os.RemoveAll(dir)
+			return status.Error(codes.Unavailable, err.Error())
+		}
+	}
+
+	if _, err := m.git(ctx, dir, "cat-file", "-e", rev+"^{commit}"); err == nil {
+		return nil
+	}
+	log.WithField("url", url).WithField("rev", rev).Debug("updating mirror")
+	_, err := m.git(ctx, dir, "fetch", "--prune", "origin")
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if _, err := m.git(ctx, dir, "cat-file", "-e", rev+"^{commit}"); err != nil {
+		return status.Errorf(codes.NotFound, "revision %s not found", rev)
+	}
+	return nil
+}
+
+// ReadFile reads a file at a particular revision
+func (m *mirrorCache) ReadFile(ctx context.Context, dir, url, rev, path string) ([]byte, error) {
+	defer m.lock(dir)()
+
+	err := m.ensureRevision(ctx, dir, url, rev)
+	if err != nil {
+		return nil, err
+	}
+	obj := rev + ":" + path
+	typ, err := m.git(ctx, dir, "cat-file", "-t", obj)
+	if err != nil || strings.TrimSpace(string(typ)) != "blob" {
+		return nil, status.Errorf(codes.NotFound, "file %s not found in revision %s", path, rev)
+	}
+	res, err := m.git(ctx, dir, "cat-file", "blob", obj)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return res, nil
+}
+
+// ListFiles lists the files (but not directories) in a directory at a particular revision
+func (m *mirrorCache) ListFiles(ctx context.Context, dir, url, rev, path string) ([]string, error) {
+	defer m.lock(dir)()
+
+	err := m.ensureRevision(ctx, dir, url, rev)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"ls-tree", "-z", rev}
+	if path != "" {
+		args = append(args, "--", path+"/")
+	}
+	out, err := m.git(ctx, dir, args...)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := make([]string, 0)
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// entries have the form "<mode> <type> <object>\t<path>"
+		segs := strings.SplitN(entry, "\t", 2)
+		if len(segs) != 2 {
+			continue
+		}
+		if fields := strings.Fields(segs[0]); len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		res = append(res, segs[1])
+	}
+	return res, nil
+}
+
+// ChangedFiles lists the files which changed between base and rev. If base is empty, the first parent of rev is used.
+// Like GitHub we compare against the merge base of both revisions. Renames count as changes of the old and new path.
+func (m *mirrorCache) ChangedFiles(ctx context.Context, dir, url, rev, base string) ([]string, error) {
+	defer m.lock(dir)()
+
+	err := m.ensureRevision(ctx, dir, url, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	if base == "" {
+		// rev-list prints the commit followed by its parents
+		parents, err := m.git(ctx, dir, "rev-list", "--parents", "-n", "1", rev)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if segs := strings.Fields(string(parents)); len(segs) > 1 {
+			base = segs[1]
+		}
+	} else {
+		err = m.ensureRevision(ctx, dir, url, base)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if base == "" {
+		// this is the root commit, i.e. all of its files are new
+		out, err = m.git(ctx, dir, "diff-tree", "-r", "-z", "--root", "--no-commit-id", "--name-only", rev)
+	} else {
+		out, err = m.git(ctx, dir, "diff", "-z", "--name-only", "--no-renames", base+"..."+rev)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := make([]string, 0)
+	for _, fn := range strings.Split(string(out), "\x00") {
+		if fn == "" {
+			continue
+		}
+		res = append(res, fn)
+	}
+	return res, nil
+}
+
+// CommitMessage returns the message of a commit
+func (m *mirrorCache) CommitMessage(ctx context.Context, dir, url, rev string) (string, error) {
+	defer m.lock(dir)()
+
+	err := m.ensureRevision(ctx, dir, url, rev)
+	if err != nil {
+		return "", err
+	}
+	out, err := m.git(ctx, dir, "log", "-1", "--format=%B", rev)
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	return string(out), nil
+}
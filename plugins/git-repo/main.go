@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	plugin "github.com/csweichel/werft/pkg/plugin/client"
+	"github.com/csweichel/werft/pkg/plugin/common"
+	"github.com/csweichel/werft/plugins/github-repo/pkg/provider"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// defaultContainerImage is the image we use to clone the code, unless configured otherwise
+	defaultContainerImage = "alpine/git:latest"
+
+	// keys of the Kubernetes secret used by the clone init container. These are the keys
+	// of the kubernetes.io/basic-auth and kubernetes.io/ssh-auth secret types.
+	secretKeyUsername = "username"
+	secretKeyPassword = "password"
+	secretKeySSHKey   = "ssh-privatekey"
+)
+
+// Config configures this plugin
+type Config struct {
+	// Host is the repository host this plugin serves, e.g. git.example.com
+	Host string `yaml:"host"`
+
+	// URL is a Go template producing the remote URL of a repository, e.g. https://git.example.com/{{ .Owner }}/{{ .Repo }}.git
+	// or ssh://git@git.example.com/{{ .Owner }}/{{ .Repo }}.git. The template receives the werft Repository.
+	URL string `yaml:"url"`
+
+	// CacheDir is where the bare mirrors of repositories are kept
+	CacheDir string `yaml:"cacheDir"`
+
+	// ContainerImage is the image of the clone init container. It must contain git.
+	ContainerImage string `yaml:"containerImage,omitempty"`
+
+	Auth AuthConfig `yaml:"auth,omitempty"`
+}
+
+// AuthConfig configures the credentials used to access the remote
+type AuthConfig struct {
+	// Username and PasswordFile are used for http(s) remotes
+	Username     string `yaml:"username,omitempty"`
+	PasswordFile string `yaml:"passwordFile,omitempty"`
+
+	// SSHKeyFile is the private key used for ssh remotes
+	SSHKeyFile string `yaml:"sshKeyFile,omitempty"`
+
+	// SecretName names a Kubernetes secret in the job namespace which provides the credentials to the clone init container,
+	// so that they never become part of the job's pod spec. The secret uses the username, password and ssh-privatekey keys.
+	// If SecretName is empty, the credentials above are passed to the init container directly.
+	SecretName string `yaml:"secretName,omitempty"`
+}
+
+func main() {
+	plugin.Serve(&Config{},
+		plugin.WithRepositoryPlugin(&gitRepoPlugin{}),
+	)
+}
+
+type gitRepoPlugin struct{}
+
+func (*gitRepoPlugin) Run(ctx context.Context, config interface{}) (common.RepositoryPluginServer, error) {
+	cfg, ok := config.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("config has wrong type %s", reflect.TypeOf(config))
+	}
+	return newGitRepoServer(*cfg)
+}
+
+// gitRepoServer provides access to repositories on any Git remote
+type gitRepoServer struct {
+	Config Config
+
+	url    *template.Template
+	mirror *mirrorCache
+}
+
+func newGitRepoServer(cfg Config) (*gitRepoServer, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if cfg.CacheDir == "" {
+		return nil, fmt.Errorf("cacheDir is required")
+	}
+	url, err := template.New("url").Option("missingkey=error").Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url template: %w", err)
+	}
+
+	return &gitRepoServer{
+		Config: cfg,
+		url:    url,
+		mirror: &mirrorCache{
+			Base: cfg.CacheDir,
+			Auth: cfg.Auth,
+		},
+	}, nil
+}
+
+// remoteURL produces the URL of a repository's remote
+func (s *gitRepoServer) remoteURL(repo *v1.Repository) (string, error) {
+	if repo == nil || repo.Owner == "" || repo.Repo == "" {
+		return "", status.Error(codes.InvalidArgument, "repository owner and name are required")
+	}
+	// owner and repo become part of a path on disk, hence must not navigate elsewhere
+	for _, seg := range []string{repo.Owner, repo.Repo} {
+		if seg == "." || seg == ".." || strings.ContainsAny(seg, `/\`) {
+			return "", status.Errorf(codes.InvalidArgument, "invalid repository %s/%s", repo.Owner, repo.Repo)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := s.url.Execute(&buf, repo)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "cannot produce remote URL: %q", err)
+	}
+	return buf.String(), nil
+}
+
+// RepoHost returns the host which this plugins integrates with
+func (s *gitRepoServer) RepoHost(context.Context, *common.RepoHostRequest) (*common.RepoHostResponse, error) {
+	return &common.RepoHostResponse{
+		Host: s.Config.Host,
+	}, nil
+}
+
+// Resolve resolves the repo's revision based on its ref(erence)
+func (s *gitRepoServer) Resolve(ctx context.Context, req *common.ResolveRequest) (*common.ResolveResponse, error) {
+	repo := req.Repository
+	if repo.Revision != "" {
+		return &common.ResolveResponse{Repository: repo}, nil
+	}
+	if repo.Ref == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "ref is empty")
+	}
+
+	url, err := s.remoteURL(repo)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := s.mirror.Resolve(ctx, url, repo.Ref)
+	if err != nil {
+		return nil, err
+	}
+	repo.Revision = rev
+	log.WithField("ref", repo.Ref).WithField("rev", repo.Revision).Debug("resolved reference to revision")
+
+	return &common.ResolveResponse{
+		Repository: repo,
+	}, nil
+}
+
+// GetRemoteAnnotations extracts werft annotations from the commit message
+func (s *gitRepoServer) GetRemoteAnnotations(ctx context.Context, req *common.GetRemoteAnnotationsRequest) (*common.GetRemoteAnnotationsResponse, error) {
+	repo := req.Repository
+	url, err := s.remoteURL(repo)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := s.mirror.CommitMessage(ctx, s.mirrorDir(repo), url, repo.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.GetRemoteAnnotationsResponse{
+		Annotations: provider.ParseAnnotations(msg),
+	}, nil
+}
+
+// ChangedFiles lists the files which changed between a base revision and the revision of the repository.
+func (s *gitRepoServer) ChangedFiles(ctx context.Context, req *common.ChangedFilesRequest) (*common.ChangedFilesResponse, error) {
+	repo := req.Repository
+	url, err := s.remoteURL(repo)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := s.mirror.ChangedFiles(ctx, s.mirrorDir(repo), url, repo.Revision, req.BaseRevision)
+	if err != nil {
+		return nil, err
+	}
+	return &common.ChangedFilesResponse{Paths: paths}, nil
+}
+
+// Download downloads a file from the repository.
+func (s *gitRepoServer) Download(ctx context.Context, req *common.DownloadRequest) (*common.DownloadResponse, error) {
+	repo := req.Repository
+	url, err := s.remoteURL(repo)
+	if err != nil {
+		return nil, err
+	}
+	content, err := s.mirror.ReadFile(ctx, s.mirrorDir(repo), url, repo.Revision, cleanPath(req.Path))
+	if err != nil {
+		return nil, err
+	}
+	return &common.DownloadResponse{
+		Content: content,
+	}, nil
+}
+
+// ListFiles lists all files in a directory.
+func (s *gitRepoServer) ListFiles(ctx context.Context, req *common.ListFilesRequest) (*common.ListFilesReponse, error) {
+	repo := req.Repository
+	url, err := s.remoteURL(repo)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := s.mirror.ListFiles(ctx, s.mirrorDir(repo), url, repo.Revision, cleanPath(req.Path))
+	if err != nil {
+		return nil, err
+	}
+	return &common.ListFilesReponse{Paths: paths}, nil
+}
+
+func (s *gitRepoServer) mirrorDir(repo *v1.Repository) string {
+	return filepath.Join(s.Config.CacheDir, repo.Owner, repo.Repo+".git")
+}
+
+// cleanPath turns a path into one relative to the repository root
+func cleanPath(p string) string {
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	return p
+}
+
+// ContentInitContainer produces the init container YAML required to initialize
+// the build context from this repository in /workspace.
+func (s *gitRepoServer) ContentInitContainer(ctx context.Context, req *common.ContentInitContainerRequest) (*common.ContentInitContainerResponse, error) {
+	image := s.Config.ContainerImage
+	if image == "" {
+		image = defaultContainerImage
+	}
+
+	repo := req.Repository
+	url, err := s.remoteURL(repo)
+	if err != nil {
+		return nil, err
+	}
+	env, err := s.initContainerEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	cloneDir := "."
+	if len(req.Paths) > 0 {
+		cloneDir = fmt.Sprintf("clone-%d-%d", time.Now().Unix(), rand.Int())
+	}
+
+	var cmds []string
+	if s.Config.Auth.SSHKeyFile != "" || s.Config.Auth.SecretName != "" {
+		cmds = append(cmds,
+			`if [ -n "$GIT_SSH_KEY_SECRET" ]; then printf '%s\n' "$GIT_SSH_KEY_SECRET" > /tmp/werft-ssh-key && chmod 600 /tmp/werft-ssh-key && export GIT_SSH_COMMAND="ssh -i /tmp/werft-ssh-key -o StrictHostKeyChecking=accept-new"; fi`,
+		)
+	}
+	cloneCmd := "git clone"
+	if s.Config.Auth.PasswordFile != "" || s.Config.Auth.SecretName != "" {
+		cloneCmd = "git clone -c \"credential.helper=/bin/sh -c 'echo username=$GIT_USER_SECRET; echo password=$GIT_PASS_SECRET'\""
+	}
+	cloneTarget := repo.Ref
+	if repo.Revision != "" {
+		cloneTarget = repo.Revision
+	}
+	cmds = append(cmds, fmt.Sprintf("%s %s %s && cd %s && git checkout %s && cd -", cloneCmd, shellQuote(url), cloneDir, cloneDir, shellQuote(cloneTarget)))
+	if len(req.Paths) > 0 {
+		for _, p := range req.Paths {
+			cmds = append(cmds, fmt.Sprintf("rm -rf %s", shellQuote(p)))
+			cmds = append(cmds, fmt.Sprintf("cp -Rf %s %s", shellQuote(filepath.Join(cloneDir, p)), shellQuote(p)))
+		}
+		cmds = append(cmds, fmt.Sprintf("rm -rf %s", cloneDir))
+	}
+
+	nme := fmt.Sprintf("gitco-%d-%d", time.Now().UnixMilli(), rand.Int())
+	c := []corev1.Container{
+		{
+			Name:  nme,
+			Image: image,
+			Command: []string{
+				"sh", "-c",
+				strings.Join(cmds, " && "),
+			},
+			Env:        env,
+			WorkingDir: "/workspace",
+		},
+	}
+	buf := bytes.NewBuffer(nil)
+	err = gob.NewEncoder(buf).Encode(c)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot serialize init container: %q", err)
+	}
+
+	return &common.ContentInitContainerResponse{
+		Container: buf.Bytes(),
+	}, nil
+}
+
+// initContainerEnv produces the environment which carries the credentials into the clone init container
+func (s *gitRepoServer) initContainerEnv() ([]corev1.EnvVar, error) {
+	auth := s.Config.Auth
+	if auth.SecretName != "" {
+		fromSecret := func(name, key string) corev1.EnvVar {
+			optional := true
+			return corev1.EnvVar{
+				Name: name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: auth.SecretName},
+						Key:                  key,
+						Optional:             &optional,
+					},
+				},
+			}
+		}
+		return []corev1.EnvVar{
+			fromSecret("GIT_USER_SECRET", secretKeyUsername),
+			fromSecret("GIT_PASS_SECRET", secretKeyPassword),
+			fromSecret("GIT_SSH_KEY_SECRET", secretKeySSHKey),
+		}, nil
+	}
+
+	var res []corev1.EnvVar
+	if auth.PasswordFile != "" {
+		pass, err := ioutil.ReadFile(auth.PasswordFile)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot read password: %q", err)
+		}
+		res = append(res,
+			corev1.EnvVar{Name: "GIT_USER_SECRET", Value: auth.Username},
+			corev1.EnvVar{Name: "GIT_PASS_SECRET", Value: strings.TrimSpace(string(pass))},
+		)
+	}
+	if auth.SSHKeyFile != "" {
+		key, err := ioutil.ReadFile(auth.SSHKeyFile)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot read ssh key: %q", err)
+		}
+		res = append(res, corev1.EnvVar{Name: "GIT_SSH_KEY_SECRET", Value: string(key)})
+	}
+	return res, nil
+}
+
+// shellQuote quotes a string for use as a single argument in sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
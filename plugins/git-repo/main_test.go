@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/plugin/common"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func git(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=werft", "GIT_AUTHOR_EMAIL=werft@example.com",
+		"GIT_COMMITTER_NAME=werft", "GIT_COMMITTER_EMAIL=werft@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v: %s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// setupRemote creates a bare repository at <base>/remotes/org/repo.git and returns a server which serves it
+func setupRemote(t *testing.T) (srv *gitRepoServer, work string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	base := t.TempDir()
+	remote := filepath.Join(base, "remotes", "org", "repo.git")
+	work = filepath.Join(base, "work")
+	git(t, base, "init", "--bare", "-b", "main", remote)
+	git(t, base, "clone", remote, work)
+
+	files := map[string]string{
+		".werft/build.yaml":  "pod: {}",
+		".werft/deploy.yaml": "pod: {}",
+		"README.md":          "hello world",
+	}
+	for fn, content := range files {
+		err := os.MkdirAll(filepath.Join(work, filepath.Dir(fn)), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(filepath.Join(work, fn), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	git(t, work, "add", "-A")
+	git(t, work, "commit", "-m", "initial\n\n/werft foo=bar")
+	git(t, work, "push", "origin", "HEAD:refs/heads/main")
+
+	srv, err := newGitRepoServer(Config{
+		Host:     "git.example.com",
+		URL:      "file://" + filepath.Join(base, "remotes") + "/{{ .Owner }}/{{ .Repo }}.git",
+		CacheDir: filepath.Join(base, "cache"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, work
+}
+
+func TestResolve(t *testing.T) {
+	srv, work := setupRemote(t)
+	head := git(t, work, "rev-parse", "HEAD")
+	git(t, work, "tag", "-a", "v1.0", "-m", "release")
+	git(t, work, "push", "origin", "v1.0")
+
+	tests := []struct {
+		Ref  string
+		Rev  string
+		Code codes.Code
+	}{
+		{Ref: "refs/heads/main", Rev: head},
+		{Ref: "main", Rev: head},
+		{Ref: "refs/tags/v1.0", Rev: head},
+		{Ref: "v1.0", Rev: head},
+		{Ref: "refs/heads/unknown", Code: codes.NotFound},
+		{Ref: "", Code: codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		t.Run(test.Ref, func(t *testing.T) {
+			resp, err := srv.Resolve(context.Background(), &common.ResolveRequest{
+				Repository: &v1.Repository{Host: "git.example.com", Owner: "org", Repo: "repo", Ref: test.Ref},
+			})
+			if code := status.Code(err); code != test.Code {
+				t.Fatalf("expected code %v, got %v", test.Code, err)
+			}
+			if err != nil {
+				return
+			}
+			if resp.Repository.Revision != test.Rev {
+				t.Errorf("expected revision %s, got %s", test.Rev, resp.Repository.Revision)
+			}
+		})
+	}
+}
+
+func TestContent(t *testing.T) {
+	srv, work := setupRemote(t)
+	ctx := context.Background()
+	repo := &v1.Repository{Host: "git.example.com", Owner: "org", Repo: "repo", Ref: "refs/heads/main", Revision: git(t, work, "rev-parse", "HEAD")}
+
+	dl, err := srv.Download(ctx, &common.DownloadRequest{Repository: repo, Path: ".werft/build.yaml"})
+	if err != nil {
+		t.Fatalf("cannot download: %v", err)
+	}
+	if string(dl.Content) != "pod: {}" {
+		t.Errorf("unexpected content: %q", string(dl.Content))
+	}
+	for _, p := range []string{"does-not-exist.yaml", ".werft"} {
+		_, err = srv.Download(ctx, &common.DownloadRequest{Repository: repo, Path: p})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected NotFound for %s, got %v", p, err)
+		}
+	}
+
+	ls, err := srv.ListFiles(ctx, &common.ListFilesRequest{Repository: repo, Path: ".werft"})
+	if err != nil {
+		t.Fatalf("cannot list files: %v", err)
+	}
+	sort.Strings(ls.Paths)
+	if diff := cmp.Diff([]string{".werft/build.yaml", ".werft/deploy.yaml"}, ls.Paths); diff != "" {
+		t.Errorf("ListFiles() mismatch (-want +got):\n%s", diff)
+	}
+	ls, err = srv.ListFiles(ctx, &common.ListFilesRequest{Repository: repo, Path: ""})
+	if err != nil {
+		t.Fatalf("cannot list files: %v", err)
+	}
+	if diff := cmp.Diff([]string{"README.md"}, ls.Paths); diff != "" {
+		t.Errorf("ListFiles() mismatch (-want +got):\n%s", diff)
+	}
+
+	atns, err := srv.GetRemoteAnnotations(ctx, &common.GetRemoteAnnotationsRequest{Repository: repo})
+	if err != nil {
+		t.Fatalf("cannot get annotations: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"foo": "bar"}, atns.Annotations); diff != "" {
+		t.Errorf("GetRemoteAnnotations() mismatch (-want +got):\n%s", diff)
+	}
+
+	// new commits must become visible even though the mirror exists already
+	err = ioutil.WriteFile(filepath.Join(work, "README.md"), []byte("changed"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	git(t, work, "commit", "-am", "second")
+	git(t, work, "push", "origin", "HEAD:refs/heads/main")
+	repo.Revision = git(t, work, "rev-parse", "HEAD")
+	dl, err = srv.Download(ctx, &common.DownloadRequest{Repository: repo, Path: "README.md"})
+	if err != nil {
+		t.Fatalf("cannot download: %v", err)
+	}
+	if string(dl.Content) != "changed" {
+		t.Errorf("unexpected content: %q", string(dl.Content))
+	}
+
+	_, err = srv.Download(ctx, &common.DownloadRequest{Repository: &v1.Repository{Owner: "..", Repo: "repo", Revision: repo.Revision}, Path: "README.md"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for invalid owner, got %v", err)
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	srv, work := setupRemote(t)
+	ctx := context.Background()
+	root := git(t, work, "rev-parse", "HEAD")
+
+	err := os.MkdirAll(filepath.Join(work, "docs"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(work, "docs", "index.md"), []byte("docs"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	git(t, work, "add", "-A")
+	git(t, work, "commit", "-m", "docs")
+	git(t, work, "mv", "README.md", "docs/README.md")
+	git(t, work, "commit", "-m", "move")
+	git(t, work, "push", "origin", "HEAD:refs/heads/main")
+	head := git(t, work, "rev-parse", "HEAD")
+
+	tests := []struct {
+		Name        string
+		Rev         string
+		Base        string
+		Expectation []string
+	}{
+		{Name: "root commit", Rev: root, Expectation: []string{".werft/build.yaml", ".werft/deploy.yaml", "README.md"}},
+		{Name: "parent", Rev: head, Expectation: []string{"README.md", "docs/README.md"}},
+		{Name: "base", Rev: head, Base: root, Expectation: []string{"README.md", "docs/README.md", "docs/index.md"}},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			resp, err := srv.ChangedFiles(ctx, &common.ChangedFilesRequest{
+				Repository:   &v1.Repository{Host: "git.example.com", Owner: "org", Repo: "repo", Revision: test.Rev},
+				BaseRevision: test.Base,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(resp.Paths)
+			if diff := cmp.Diff(test.Expectation, resp.Paths); diff != "" {
+				t.Errorf("ChangedFiles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestContentInitContainer(t *testing.T) {
+	srv, work := setupRemote(t)
+	repo := &v1.Repository{Host: "git.example.com", Owner: "org", Repo: "repo", Ref: "refs/heads/main", Revision: git(t, work, "rev-parse", "HEAD")}
+
+	tests := []struct {
+		Name     string
+		Paths    []string
+		Expected []string
+	}{
+		{Name: "full clone", Expected: []string{".git", ".werft", "README.md"}},
+		{Name: "paths", Paths: []string{".werft"}, Expected: []string{".werft"}},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			resp, err := srv.ContentInitContainer(context.Background(), &common.ContentInitContainerRequest{Repository: repo, Paths: test.Paths})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var c []corev1.Container
+			err = gob.NewDecoder(bytes.NewReader(resp.Container)).Decode(&c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(c) != 1 {
+				t.Fatalf("expected one container, got %d", len(c))
+			}
+
+			// we run the init container's command locally in place of /workspace
+			workspace := t.TempDir()
+			cmd := exec.Command(c[0].Command[0], c[0].Command[1:]...)
+			cmd.Dir = workspace
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("init container command failed: %v: %s", err, out)
+			}
+
+			entries, err := ioutil.ReadDir(workspace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var act []string
+			for _, e := range entries {
+				act = append(act, e.Name())
+			}
+			if diff := cmp.Diff(test.Expected, act); diff != "" {
+				t.Errorf("workspace content mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInitContainerSecret(t *testing.T) {
+	srv, err := newGitRepoServer(Config{
+		Host:     "git.example.com",
+		URL:      "https://git.example.com/{{ .Owner }}/{{ .Repo }}.git",
+		CacheDir: t.TempDir(),
+		Auth:     AuthConfig{SecretName: "git-credentials"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := srv.initContainerEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range env {
+		if e.Value != "" || e.ValueFrom == nil || e.ValueFrom.SecretKeyRef == nil || e.ValueFrom.SecretKeyRef.Name != "git-credentials" {
+			t.Errorf("expected %s to come from the secret, got %v", e.Name, e)
+		}
+	}
+}
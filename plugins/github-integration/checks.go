@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/google/go-github/v35/github"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	checkActionRerun = "rerun"
+	checkActionStop  = "stop"
+
+	// maxFailedSliceLines is the number of lines we show of each failed log slice
+	maxFailedSliceLines = 50
+	// maxCheckOutputText is the maximum length of a check run's output text. GitHub allows up to 65535 characters.
+	maxCheckOutputText = 60000
+)
+
+// updateGitHubCheck reports the job as check run. Check runs carry the job name as external ID,
+// so that we can find them again later on and that re-run/stop actions know which job they refer to.
+func (p *githubTriggerPlugin) updateGitHubCheck(ctx context.Context, owner, repo string, job *v1.JobStatus) error {
+	var (
+		url        = fmt.Sprintf("%s/job/%s", p.Config.BaseURL, job.Name)
+		name       = werftGithubContextPrefix + "/" + job.Metadata.JobSpecName
+		status     string
+		conclusion string
+		title      string
+		actions    []*github.CheckRunAction
+	)
+	switch job.Phase {
+	case v1.JobPhase_PHASE_UNKNOWN, v1.JobPhase_PHASE_PREPARING, v1.JobPhase_PHASE_STARTING, v1.JobPhase_PHASE_WAITING:
+		status = "queued"
+		title = "build is " + strings.TrimPrefix(strings.ToLower(job.Phase.String()), "phase_")
+	case v1.JobPhase_PHASE_RUNNING:
+		status = "in_progress"
+		title = "build is running"
+	default:
+		status = "completed"
+		if job.Conditions.Success {
+			conclusion = "success"
+			title = "The build succeeded!"
+		} else {
+			conclusion = "failure"
+			title = "The build failed!"
+		}
+	}
+	if status == "completed" {
+		actions = append(actions, &github.CheckRunAction{Label: "Re-run", Description: "Start this job again", Identifier: checkActionRerun})
+	} else {
+		actions = append(actions, &github.CheckRunAction{Label: "Stop", Description: "Stop this job", Identifier: checkActionStop})
+	}
+
+	summary := renderCheckSummary(job, url)
+	output := &github.CheckRunOutput{
+		Title:   &title,
+		Summary: &summary,
+	}
+	if status == "completed" && !job.Conditions.Success {
+		text, err := p.renderFailedSlices(ctx, job.Name)
+		if err != nil {
+			log.WithError(err).WithField("job", job.Name).Warn("cannot produce failed log slices")
+		}
+		if text != "" {
+			output.Text = &text
+		}
+	}
+
+	opts := github.CreateCheckRunOptions{
+		Name:       name,
+		HeadSHA:    job.Metadata.Repository.Revision,
+		DetailsURL: &url,
+		ExternalID: &job.Name,
+		Status:     &status,
+		Output:     output,
+		Actions:    actions,
+	}
+	if conclusion != "" {
+		opts.Conclusion = &conclusion
+		opts.CompletedAt = &github.Timestamp{Time: time.Now()}
+	}
+	if job.Metadata.Created != nil {
+		opts.StartedAt = &github.Timestamp{Time: time.Unix(job.Metadata.Created.Seconds, int64(job.Metadata.Created.Nanos))}
+	}
+
+	log.WithField("check", name).WithField("status", status).Debugf("updating GitHub check run for %s", job.Name)
+	err := p.upsertCheckRun(ctx, owner, repo, opts)
+	if err != nil {
+		return err
+	}
+
+	// results posted to a GitHub channel become check runs of their own
+	var idx int
+	for _, r := range job.Results {
+		var (
+			ok   bool
+			rctx string
+		)
+		for _, c := range r.Channels {
+			if c == "github" {
+				ok = true
+				rctx = fmt.Sprintf("%s/results/%03d", name, idx)
+				idx++
+				break
+			}
+			if strings.HasPrefix(c, werftResultChannelPrefix) {
+				ok = true
+				rctx = fmt.Sprintf("%s/results/%s", name, strings.TrimPrefix(c, werftResultChannelPrefix))
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		var (
+			resultURL        = url
+			resultConclusion = "success"
+			resultStatus     = "completed"
+			resultTitle      = r.Description
+			resultSummary    = fmt.Sprintf("%s: %s", r.Type, r.Payload)
+		)
+		if r.Type == "url" {
+			resultURL = r.Payload
+		}
+		if r.Type == "conclusion" {
+			resultConclusion = r.Payload
+		}
+		err := p.upsertCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+			Name:        rctx,
+			HeadSHA:     job.Metadata.Repository.Revision,
+			DetailsURL:  &resultURL,
+			ExternalID:  &job.Name,
+			Status:      &resultStatus,
+			Conclusion:  &resultConclusion,
+			CompletedAt: &github.Timestamp{Time: time.Now()},
+			Output: &github.CheckRunOutput{
+				Title:   &resultTitle,
+				Summary: &resultSummary,
+			},
+		})
+		if err != nil {
+			log.WithError(err).WithField("job", job.Name).Warn("cannot update result check run")
+		}
+	}
+
+	return nil
+}
+
+// upsertCheckRun updates the check run of the same name and external ID if one exists, and creates a new one otherwise
+func (p *githubTriggerPlugin) upsertCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) error {
+	existing, _, err := p.Github.Checks.ListCheckRunsForRef(ctx, owner, repo, opts.HeadSHA, &github.ListCheckRunsOptions{
+		CheckName: &opts.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot list check runs: %w", err)
+	}
+
+	var id int64
+	for _, cr := range existing.CheckRuns {
+		if opts.ExternalID != nil && cr.GetExternalID() == *opts.ExternalID {
+			id = cr.GetID()
+			break
+		}
+	}
+
+	if id == 0 {
+		_, _, err = p.Github.Checks.CreateCheckRun(ctx, owner, repo, opts)
+		return err
+	}
+
+	_, _, err = p.Github.Checks.UpdateCheckRun(ctx, owner, repo, id, github.UpdateCheckRunOptions{
+		Name:        opts.Name,
+		DetailsURL:  opts.DetailsURL,
+		ExternalID:  opts.ExternalID,
+		Status:      opts.Status,
+		Conclusion:  opts.Conclusion,
+		CompletedAt: opts.CompletedAt,
+		Output:      opts.Output,
+		Actions:     opts.Actions,
+	})
+	return err
+}
+
+// renderCheckSummary produces the markdown summary of a check run from the job's results
+func renderCheckSummary(job *v1.JobStatus, url string) string {
+	var res strings.Builder
+	fmt.Fprintf(&res, "werft job [%s](%s)\n", job.Name, url)
+	if len(job.Results) == 0 {
+		return res.String()
+	}
+
+	res.WriteString("\n| Result | Description |\n| --- | --- |\n")
+	for _, r := range job.Results {
+		payload := r.Payload
+		if r.Type == "url" {
+			payload = fmt.Sprintf("[%s](%s)", r.Payload, r.Payload)
+		}
+		fmt.Fprintf(&res, "| **%s**: %s | %s |\n", escapeTableCell(r.Type), escapeTableCell(payload), escapeTableCell(r.Description))
+	}
+	return res.String()
+}
+
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
+
+// renderFailedSlices produces the markdown output text of a check run which lists the tail of all failed log slices.
+// This must only be called for jobs which are done, otherwise reading the log blocks until the job finishes.
+func (p *githubTriggerPlugin) renderFailedSlices(ctx context.Context, name string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	lstn, err := p.Werft.Listen(ctx, &v1.ListenRequest{
+		Name: name,
+		Logs: v1.ListenRequestLogs_LOGS_RAW,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	type slice struct {
+		Name    string
+		Lines   []string
+		Message string
+		Failed  bool
+	}
+	var (
+		slices []*slice
+		idx    = make(map[string]*slice)
+	)
+	for {
+		msg, err := lstn.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		evt := msg.GetSlice()
+		if evt == nil {
+			continue
+		}
+		s, ok := idx[evt.Name]
+		if !ok {
+			s = &slice{Name: evt.Name}
+			idx[evt.Name] = s
+			slices = append(slices, s)
+		}
+		switch evt.Type {
+		case v1.LogSliceType_SLICE_CONTENT:
+			s.Lines = append(s.Lines, evt.Payload)
+			if len(s.Lines) > maxFailedSliceLines {
+				s.Lines = s.Lines[len(s.Lines)-maxFailedSliceLines:]
+			}
+		case v1.LogSliceType_SLICE_FAIL:
+			s.Failed = true
+			s.Message = evt.Payload
+		}
+	}
+
+	var res strings.Builder
+	for _, s := range slices {
+		if !s.Failed {
+			continue
+		}
+
+		var sec strings.Builder
+		fmt.Fprintf(&sec, "### %s failed\n", s.Name)
+		if s.Message != "" {
+			fmt.Fprintf(&sec, "%s\n", s.Message)
+		}
+		if len(s.Lines) > 0 {
+			fmt.Fprintf(&sec, "```\n%s\n```\n", strings.ReplaceAll(strings.Join(s.Lines, "\n"), "```", "` ` `"))
+		}
+		if res.Len()+sec.Len() > maxCheckOutputText {
+			res.WriteString("\nmore slices failed - see the job log for details\n")
+			break
+		}
+		res.WriteString(sec.String())
+	}
+	return res.String(), nil
+}
+
+// processCheckRunEvent handles the re-run and stop actions of check runs we created
+func (p *githubTriggerPlugin) processCheckRunEvent(ctx context.Context, event *github.CheckRunEvent) {
+	if !p.Config.Checks.Enabled {
+		return
+	}
+
+	var action string
+	switch event.GetAction() {
+	case "rerequested":
+		action = checkActionRerun
+	case "requested_action":
+		action = event.GetRequestedAction().Identifier
+	default:
+		return
+	}
+
+	jobName := event.GetCheckRun().GetExternalID()
+	if jobName == "" {
+		return
+	}
+
+	var (
+		segs  = strings.Split(event.GetRepo().GetFullName(), "/")
+		owner = segs[0]
+		repo  = segs[len(segs)-1]
+		user  = event.GetSender().GetLogin()
+	)
+	if !p.userIsAllowedToStartJob(ctx, owner, repo, user) {
+		log.WithField("user", user).WithField("job", jobName).WithField("action", action).Warn("user is not allowed to act on check run")
+		return
+	}
+
+	var err error
+	switch action {
+	case checkActionRerun:
+		var resp *v1.StartJobResponse
+		resp, err = p.Werft.StartFromPreviousJob(ctx, &v1.StartFromPreviousJobRequest{PreviousJob: jobName})
+		if err == nil {
+			log.WithField("job", resp.Status.Name).WithField("previousJob", jobName).Info("re-ran job from check run")
+		}
+	case checkActionStop:
+		_, err = p.Werft.StopJob(ctx, &v1.StopJobRequest{Name: jobName})
+	default:
+		log.WithField("action", action).Debug("unknown check run action")
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("job", jobName).WithField("action", action).Warn("cannot handle check run action")
+	}
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/api/v1/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-github/v35/github"
+)
+
+// fakeChecksAPI implements the parts of the GitHub checks API we use
+type fakeChecksAPI struct {
+	mu        sync.Mutex
+	CheckRuns []*github.CheckRun
+	Outputs   map[int64]*github.CheckRunOutput
+	Actions   map[int64][]*github.CheckRunAction
+}
+
+func (f *fakeChecksAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var (
+		segs = strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		res  interface{}
+	)
+	switch {
+	case r.Method == "GET" && len(segs) == 6 && segs[5] == "check-runs":
+		name := r.URL.Query().Get("check_name")
+		var runs []*github.CheckRun
+		for _, cr := range f.CheckRuns {
+			if cr.GetName() == name && cr.GetHeadSHA() == segs[4] {
+				runs = append(runs, cr)
+			}
+		}
+		total := len(runs)
+		res = &github.ListCheckRunsResults{Total: &total, CheckRuns: runs}
+	case r.Method == "POST" && len(segs) == 4 && segs[3] == "check-runs":
+		var opts github.CreateCheckRunOptions
+		err := json.NewDecoder(r.Body).Decode(&opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id := int64(len(f.CheckRuns) + 1)
+		cr := &github.CheckRun{
+			ID:         &id,
+			Name:       &opts.Name,
+			HeadSHA:    &opts.HeadSHA,
+			ExternalID: opts.ExternalID,
+			Status:     opts.Status,
+			Conclusion: opts.Conclusion,
+			DetailsURL: opts.DetailsURL,
+		}
+		f.CheckRuns = append(f.CheckRuns, cr)
+		f.Outputs[id] = opts.Output
+		f.Actions[id] = opts.Actions
+		res = cr
+	case r.Method == "PATCH" && len(segs) == 5 && segs[3] == "check-runs":
+		var opts github.UpdateCheckRunOptions
+		err := json.NewDecoder(r.Body).Decode(&opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var cr *github.CheckRun
+		for _, c := range f.CheckRuns {
+			if fmt.Sprint(c.GetID()) == segs[4] {
+				cr = c
+			}
+		}
+		if cr == nil {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		cr.Status, cr.Conclusion, cr.DetailsURL = opts.Status, opts.Conclusion, opts.DetailsURL
+		f.Outputs[cr.GetID()] = opts.Output
+		f.Actions[cr.GetID()] = opts.Actions
+		res = cr
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func TestUpdateGitHubCheck(t *testing.T) {
+	api := &fakeChecksAPI{
+		Outputs: make(map[int64]*github.CheckRunOutput),
+		Actions: make(map[int64][]*github.CheckRunAction),
+	}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	logs := []*v1.LogSliceEvent{
+		{Name: "build", Type: v1.LogSliceType_SLICE_START},
+		{Name: "build", Type: v1.LogSliceType_SLICE_CONTENT, Payload: "compiling"},
+		{Name: "build", Type: v1.LogSliceType_SLICE_DONE},
+		{Name: "test", Type: v1.LogSliceType_SLICE_START},
+		{Name: "test", Type: v1.LogSliceType_SLICE_CONTENT, Payload: "--- FAIL: TestFoo"},
+		{Name: "test", Type: v1.LogSliceType_SLICE_FAIL, Payload: "tests failed"},
+	}
+	listen := mock.NewMockWerftService_ListenClient(mockCtrl)
+	var idx int
+	listen.EXPECT().Recv().AnyTimes().DoAndReturn(func() (*v1.ListenResponse, error) {
+		if idx >= len(logs) {
+			return nil, io.EOF
+		}
+		evt := logs[idx]
+		idx++
+		return &v1.ListenResponse{Content: &v1.ListenResponse_Slice{Slice: evt}}, nil
+	})
+	client := mock.NewMockWerftServiceClient(mockCtrl)
+	client.EXPECT().Listen(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(listen, nil)
+
+	plg := &githubTriggerPlugin{
+		Werft:  client,
+		Github: gh,
+		Config: &Config{BaseURL: "https://werft.example.com"},
+	}
+	plg.Config.Checks.Enabled = true
+
+	job := &v1.JobStatus{
+		Name: "werft-main.1",
+		Metadata: &v1.JobMetadata{
+			Owner:       "csweichel",
+			Repository:  &v1.Repository{Host: "github.com", Owner: "csweichel", Repo: "werft", Ref: "refs/heads/main", Revision: "rev"},
+			JobSpecName: "build",
+			Annotations: []*v1.Annotation{{Key: annotationStatusUpdate, Value: "csweichel/werft"}},
+		},
+		Phase:      v1.JobPhase_PHASE_RUNNING,
+		Conditions: &v1.JobConditions{},
+	}
+	err := plg.updateGitHubStatus(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(api.CheckRuns) != 1 {
+		t.Fatalf("expected one check run, got %d", len(api.CheckRuns))
+	}
+	if cr := api.CheckRuns[0]; cr.GetStatus() != "in_progress" || cr.GetName() != "ci/werft/build" || cr.GetExternalID() != job.Name {
+		t.Errorf("unexpected check run: %v", cr)
+	}
+	if acts := api.Actions[1]; len(acts) != 1 || acts[0].Identifier != checkActionStop {
+		t.Errorf("expected stop action, got %v", acts)
+	}
+
+	job.Phase = v1.JobPhase_PHASE_DONE
+	job.Results = []*v1.JobResult{
+		{Type: "url", Payload: "https://preview.example.com", Description: "preview environment", Channels: []string{"github"}},
+		{Type: "docker", Payload: "image:tag", Description: "the image we built"},
+	}
+	err = plg.updateGitHubStatus(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(api.CheckRuns) != 2 {
+		t.Fatalf("expected two check runs, got %d", len(api.CheckRuns))
+	}
+	if cr := api.CheckRuns[0]; cr.GetStatus() != "completed" || cr.GetConclusion() != "failure" {
+		t.Errorf("unexpected check run: %v", cr)
+	}
+	if acts := api.Actions[1]; len(acts) != 1 || acts[0].Identifier != checkActionRerun {
+		t.Errorf("expected re-run action, got %v", acts)
+	}
+	out := api.Outputs[1]
+	if out == nil {
+		t.Fatal("check run has no output")
+	}
+	for _, s := range []string{"[https://preview.example.com](https://preview.example.com)", "image:tag", "the image we built"} {
+		if !strings.Contains(out.GetSummary(), s) {
+			t.Errorf("summary does not contain %q:\n%s", s, out.GetSummary())
+		}
+	}
+	if !strings.Contains(out.GetText(), "### test failed\ntests failed\n```\n--- FAIL: TestFoo\n```") || strings.Contains(out.GetText(), "compiling") {
+		t.Errorf("unexpected output text:\n%s", out.GetText())
+	}
+	if cr := api.CheckRuns[1]; cr.GetName() != "ci/werft/build/results/000" || cr.GetDetailsURL() != "https://preview.example.com" || cr.GetConclusion() != "success" {
+		t.Errorf("unexpected result check run: %v", cr)
+	}
+}
+
+func TestProcessCheckRunEvent(t *testing.T) {
+	type Expectation struct {
+		Rerun string
+		Stop  string
+	}
+	tests := []struct {
+		Name        string
+		Action      string
+		Identifier  string
+		Expectation Expectation
+	}{
+		{Name: "rerun button", Action: "requested_action", Identifier: checkActionRerun, Expectation: Expectation{Rerun: "werft-main.1"}},
+		{Name: "stop button", Action: "requested_action", Identifier: checkActionStop, Expectation: Expectation{Stop: "werft-main.1"}},
+		{Name: "rerequested", Action: "rerequested", Expectation: Expectation{Rerun: "werft-main.1"}},
+		{Name: "completed", Action: "completed"},
+		{Name: "unknown action", Action: "requested_action", Identifier: "foobar"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			var act Expectation
+			client := mock.NewMockWerftServiceClient(mockCtrl)
+			client.EXPECT().StartFromPreviousJob(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(ctx context.Context, req *v1.StartFromPreviousJobRequest) (*v1.StartJobResponse, error) {
+					act.Rerun = req.PreviousJob
+					return &v1.StartJobResponse{Status: &v1.JobStatus{Name: "werft-main.2"}}, nil
+				})
+			client.EXPECT().StopJob(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(ctx context.Context, req *v1.StopJobRequest) (*v1.StopJobResponse, error) {
+					act.Stop = req.Name
+					return &v1.StopJobResponse{}, nil
+				})
+
+			plg := &githubTriggerPlugin{
+				Werft:    client,
+				Config:   &Config{},
+				testMode: true,
+			}
+			plg.Config.Checks.Enabled = true
+
+			evt := &github.CheckRunEvent{
+				Action:   &test.Action,
+				CheckRun: &github.CheckRun{ExternalID: github.String("werft-main.1")},
+				Repo:     &github.Repository{FullName: github.String("csweichel/werft")},
+				Sender:   &github.User{Login: github.String("csweichel")},
+			}
+			if test.Identifier != "" {
+				evt.RequestedAction = &github.RequestedAction{Identifier: test.Identifier}
+			}
+			plg.processCheckRunEvent(context.Background(), evt)
+
+			if act != test.Expectation {
+				t.Errorf("unexpected actions: expected %+v, got %+v", test.Expectation, act)
+			}
+		})
+	}
+}
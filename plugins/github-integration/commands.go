@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/google/go-github/v35/github"
+	log "github.com/sirupsen/logrus"
+)
+
+// fileExists checks if a file exists in a repository at a particular ref
+func (p *githubTriggerPlugin) fileExists(ctx context.Context, owner, repo, ref, path string) bool {
+	_, _, resp, err := p.Github.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false
+	}
+	if err != nil {
+		log.WithError(err).WithField("repo", fmt.Sprintf("%s/%s", owner, repo)).WithField("path", path).Warn("cannot check if file exists")
+		return false
+	}
+	return true
+}
+
+// listPRJobs returns the jobs which ran for the head of a pull request, newest first
+func (p *githubTriggerPlugin) listPRJobs(ctx context.Context, pr *github.PullRequest) ([]*v1.JobStatus, error) {
+	ref := pr.GetHead().GetRef()
+	if !strings.HasPrefix(ref, "refs/") {
+		// we assume this is a branch
+		ref = "refs/heads/" + ref
+	}
+	head := pr.GetHead().GetRepo()
+
+	resp, err := p.Werft.ListJobs(ctx, &v1.ListJobsRequest{
+		Filter: []*v1.FilterExpression{
+			{Terms: []*v1.FilterTerm{{Field: "repo.owner", Value: head.GetOwner().GetLogin(), Operation: v1.FilterOp_OP_EQUALS}}},
+			{Terms: []*v1.FilterTerm{{Field: "repo.repo", Value: head.GetName(), Operation: v1.FilterOp_OP_EQUALS}}},
+			{Terms: []*v1.FilterTerm{{Field: "repo.ref", Value: ref, Operation: v1.FilterOp_OP_EQUALS}}},
+			{Terms: []*v1.FilterTerm{{Field: "repo.rev", Value: pr.GetHead().GetSHA(), Operation: v1.FilterOp_OP_EQUALS}}},
+		},
+		Order: []*v1.OrderExpression{{Field: "created", Ascending: false}},
+		Limit: 50,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+func (p *githubTriggerPlugin) handleCommandRerun(ctx context.Context, pr *github.PullRequest) (msg string, err error) {
+	jobs, err := p.listPRJobs(ctx, pr)
+	if err != nil {
+		log.WithError(err).Warn("GitHub webhook error")
+		return "", fmt.Errorf("cannot list jobs - please talk to whoever's in charge of your Werft installation")
+	}
+
+	var failed *v1.JobStatus
+	for _, j := range jobs {
+		if j.Phase == v1.JobPhase_PHASE_DONE && !j.Conditions.Success {
+			failed = j
+			break
+		}
+	}
+	if failed == nil {
+		return "", fmt.Errorf("there is no failed job for %s which could be re-run", shortRev(pr.GetHead().GetSHA()))
+	}
+
+	resp, err := p.Werft.StartFromPreviousJob(ctx, &v1.StartFromPreviousJobRequest{PreviousJob: failed.Name})
+	if err != nil {
+		log.WithError(err).Warn("GitHub webhook error")
+		return "", fmt.Errorf("cannot start job - please talk to whoever's in charge of your Werft installation")
+	}
+
+	return fmt.Sprintf("re-running [%s](%s/job/%s) as [%s](%s/job/%s)",
+		failed.Name, p.Config.BaseURL, failed.Name,
+		resp.Status.Name, p.Config.BaseURL, resp.Status.Name,
+	), nil
+}
+
+func (p *githubTriggerPlugin) handleCommandStop(ctx context.Context, pr *github.PullRequest, args []string) (msg string, err error) {
+	jobs, err := p.listPRJobs(ctx, pr)
+	if err != nil {
+		log.WithError(err).Warn("GitHub webhook error")
+		return "", fmt.Errorf("cannot list jobs - please talk to whoever's in charge of your Werft installation")
+	}
+
+	var names []string
+	if len(args) > 0 {
+		// make sure we only ever stop jobs which ran for the head of this PR
+		prJobs := make(map[string]struct{}, len(jobs))
+		for _, j := range jobs {
+			prJobs[j.Name] = struct{}{}
+		}
+		for _, name := range args {
+			if _, ok := prJobs[name]; !ok {
+				return "", fmt.Errorf("job %s does not belong to this PR", name)
+			}
+			names = append(names, name)
+		}
+	} else {
+		for _, j := range jobs {
+			if j.Phase == v1.JobPhase_PHASE_DONE || j.Phase == v1.JobPhase_PHASE_CLEANUP {
+				continue
+			}
+			names = append(names, j.Name)
+		}
+		if len(names) == 0 {
+			return "", fmt.Errorf("there are no running jobs for %s", shortRev(pr.GetHead().GetSHA()))
+		}
+	}
+
+	for _, name := range names {
+		_, err = p.Werft.StopJob(ctx, &v1.StopJobRequest{Name: name})
+		if err != nil {
+			log.WithError(err).WithField("job", name).Warn("GitHub webhook error")
+			return "", fmt.Errorf("cannot stop %s - please talk to whoever's in charge of your Werft installation", name)
+		}
+	}
+
+	return fmt.Sprintf("stopped %s", strings.Join(names, ", ")), nil
+}
+
+func (p *githubTriggerPlugin) handleCommandStatus(ctx context.Context, event *github.IssueCommentEvent, pr *github.PullRequest) (msg string, err error) {
+	jobs, err := p.listPRJobs(ctx, pr)
+	if err != nil {
+		log.WithError(err).Warn("GitHub webhook error")
+		return "", fmt.Errorf("cannot list jobs - please talk to whoever's in charge of your Werft installation")
+	}
+
+	table := renderJobTable(p.Config.BaseURL, pr.GetHead().GetSHA(), jobs)
+	if p.testMode {
+		return table, nil
+	}
+
+	// the table is too large to be added to the command comment, hence we reply with a comment of its own
+	var (
+		segs  = strings.Split(event.GetRepo().GetFullName(), "/")
+		owner = segs[0]
+		repo  = segs[1]
+	)
+	_, _, err = p.Github.Issues.CreateComment(ctx, owner, repo, pr.GetNumber(), &github.IssueComment{
+		Body: &table,
+	})
+	if err != nil {
+		log.WithError(err).Warn("GitHub webhook error")
+		return "", fmt.Errorf("cannot reply with the job status")
+	}
+	return "replied with the status of this PR's jobs", nil
+}
+
+// renderJobTable produces a markdown table of jobs
+func renderJobTable(baseURL, rev string, jobs []*v1.JobStatus) string {
+	if len(jobs) == 0 {
+		return fmt.Sprintf("There are no werft jobs for %s.", shortRev(rev))
+	}
+
+	var res strings.Builder
+	fmt.Fprintf(&res, "werft jobs for %s:\n\n", shortRev(rev))
+	res.WriteString("| Job | Phase | Success | Started |\n| --- | --- | --- | --- |\n")
+	for _, j := range jobs {
+		var (
+			phase   = strings.TrimPrefix(strings.ToLower(j.Phase.String()), "phase_")
+			success = ""
+			started = ""
+		)
+		if j.Phase == v1.JobPhase_PHASE_DONE {
+			success = ":x:"
+			if j.Conditions.Success {
+				success = ":heavy_check_mark:"
+			}
+		}
+		if c := j.Metadata.Created; c != nil {
+			started = time.Unix(c.Seconds, int64(c.Nanos)).UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(&res, "| [%s](%s/job/%s) | %s | %s | %s |\n", j.Name, baseURL, j.Name, phase, success, started)
+	}
+	return res.String()
+}
+
+func shortRev(rev string) string {
+	if len(rev) > 7 {
+		return rev[:7]
+	}
+	return rev
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/api/v1/mock"
+	"github.com/csweichel/werft/pkg/filterexpr"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v35/github"
+)
+
+func TestPRCommands(t *testing.T) {
+	const headRev = "37cb8b8f7fc3499bdf65d662793dd2d968ae3bac"
+	newJob := func(name, owner, rev string, phase v1.JobPhase, success bool) *v1.JobStatus {
+		return &v1.JobStatus{
+			Name: name,
+			Metadata: &v1.JobMetadata{
+				Owner:      "csweichel",
+				Repository: &v1.Repository{Host: "github.com", Owner: owner, Repo: "test-repo", Ref: "refs/heads/werft", Revision: rev},
+				Created:    &timestamp.Timestamp{Seconds: 1622628775},
+			},
+			Phase:      phase,
+			Conditions: &v1.JobConditions{Success: success},
+		}
+	}
+	jobs := []*v1.JobStatus{
+		newJob("test-repo-werft.4", "csweichel", headRev, v1.JobPhase_PHASE_RUNNING, false),
+		newJob("test-repo-werft.3", "csweichel", headRev, v1.JobPhase_PHASE_DONE, true),
+		newJob("test-repo-werft.2", "csweichel", headRev, v1.JobPhase_PHASE_DONE, false),
+		newJob("test-repo-werft.1", "csweichel", "oldrev", v1.JobPhase_PHASE_DONE, false),
+	}
+	mainJob := newJob("test-repo-main.1", "csweichel", headRev, v1.JobPhase_PHASE_RUNNING, false)
+	mainJob.Metadata.Repository.Ref = "refs/heads/main"
+	otherJob := newJob("other-repo-main.1", "someone-else", headRev, v1.JobPhase_PHASE_RUNNING, false)
+	allJobs := append([]*v1.JobStatus{mainJob, otherJob}, jobs...)
+
+	type Expectation struct {
+		Msg     string
+		Error   string
+		Rerun   string
+		Stopped []string
+	}
+	tests := []struct {
+		Name        string
+		Cmd         string
+		Args        []string
+		Jobs        []*v1.JobStatus
+		Expectation Expectation
+	}{
+		{
+			Name:        "rerun last failed job",
+			Cmd:         "rerun",
+			Jobs:        jobs,
+			Expectation: Expectation{Rerun: "test-repo-werft.2", Msg: "re-running [test-repo-werft.2](https://werft.example.com/job/test-repo-werft.2) as [test-repo-werft.5](https://werft.example.com/job/test-repo-werft.5)"},
+		},
+		{
+			Name:        "rerun without failed job",
+			Cmd:         "rerun",
+			Jobs:        jobs[:2],
+			Expectation: Expectation{Error: "there is no failed job for 37cb8b8 which could be re-run"},
+		},
+		{
+			Name:        "stop running jobs",
+			Cmd:         "stop",
+			Jobs:        jobs,
+			Expectation: Expectation{Stopped: []string{"test-repo-werft.4"}, Msg: "stopped test-repo-werft.4"},
+		},
+		{
+			Name:        "stop named job",
+			Cmd:         "stop",
+			Args:        []string{"test-repo-werft.2"},
+			Jobs:        jobs,
+			Expectation: Expectation{Stopped: []string{"test-repo-werft.2"}, Msg: "stopped test-repo-werft.2"},
+		},
+		{
+			Name:        "stop job of other repo",
+			Cmd:         "stop",
+			Args:        []string{"other-repo-main.1"},
+			Jobs:        allJobs,
+			Expectation: Expectation{Error: "job other-repo-main.1 does not belong to this PR"},
+		},
+		{
+			Name:        "stop job of other branch",
+			Cmd:         "stop",
+			Args:        []string{"test-repo-main.1"},
+			Jobs:        allJobs,
+			Expectation: Expectation{Error: "job test-repo-main.1 does not belong to this PR"},
+		},
+		{
+			Name:        "stop job of previous revision",
+			Cmd:         "stop",
+			Args:        []string{"test-repo-werft.1"},
+			Jobs:        allJobs,
+			Expectation: Expectation{Error: "job test-repo-werft.1 does not belong to this PR"},
+		},
+		{
+			Name:        "stop unknown job",
+			Cmd:         "stop",
+			Args:        []string{"foobar"},
+			Jobs:        jobs,
+			Expectation: Expectation{Error: "job foobar does not belong to this PR"},
+		},
+		{
+			Name:        "stop without running jobs",
+			Cmd:         "stop",
+			Jobs:        jobs[1:],
+			Expectation: Expectation{Error: "there are no running jobs for 37cb8b8"},
+		},
+		{
+			Name: "status",
+			Cmd:  "status",
+			Jobs: jobs,
+			Expectation: Expectation{Msg: "werft jobs for 37cb8b8:\n\n" +
+				"| Job | Phase | Success | Started |\n| --- | --- | --- | --- |\n" +
+				"| [test-repo-werft.4](https://werft.example.com/job/test-repo-werft.4) | running |  | 2021-06-02T10:12:55Z |\n" +
+				"| [test-repo-werft.3](https://werft.example.com/job/test-repo-werft.3) | done | :heavy_check_mark: | 2021-06-02T10:12:55Z |\n" +
+				"| [test-repo-werft.2](https://werft.example.com/job/test-repo-werft.2) | done | :x: | 2021-06-02T10:12:55Z |\n",
+			},
+		},
+		{
+			Name:        "status without jobs",
+			Cmd:         "status",
+			Expectation: Expectation{Msg: "There are no werft jobs for 37cb8b8."},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			var act Expectation
+			client := mock.NewMockWerftServiceClient(mockCtrl)
+			client.EXPECT().ListJobs(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(ctx context.Context, req *v1.ListJobsRequest) (*v1.ListJobsResponse, error) {
+					if len(req.Filter) != 4 {
+						t.Errorf("expected owner, repo, ref and revision filter, got %v", req.Filter)
+					}
+					var res []*v1.JobStatus
+					for _, j := range test.Jobs {
+						if filterexpr.MatchesFilter(j, req.Filter) {
+							res = append(res, j)
+						}
+					}
+					return &v1.ListJobsResponse{Result: res, Total: int32(len(res))}, nil
+				})
+			client.EXPECT().StartFromPreviousJob(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(ctx context.Context, req *v1.StartFromPreviousJobRequest) (*v1.StartJobResponse, error) {
+					act.Rerun = req.PreviousJob
+					return &v1.StartJobResponse{Status: &v1.JobStatus{Name: "test-repo-werft.5"}}, nil
+				})
+			client.EXPECT().StopJob(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(ctx context.Context, req *v1.StopJobRequest) (*v1.StopJobResponse, error) {
+					act.Stopped = append(act.Stopped, req.Name)
+					return &v1.StopJobResponse{}, nil
+				})
+
+			plg := &githubTriggerPlugin{
+				Werft:    client,
+				Config:   &Config{BaseURL: "https://werft.example.com"},
+				testMode: true,
+			}
+			pr := &github.PullRequest{
+				Number: github.Int(1),
+				Head: &github.PullRequestBranch{
+					Ref: github.String("werft"),
+					SHA: github.String(headRev),
+					Repo: &github.Repository{
+						Name:  github.String("test-repo"),
+						Owner: &github.User{Login: github.String("csweichel")},
+					},
+				},
+			}
+			event := &github.IssueCommentEvent{
+				Repo: &github.Repository{FullName: github.String("csweichel/test-repo")},
+			}
+
+			var err error
+			switch test.Cmd {
+			case "rerun":
+				act.Msg, err = plg.handleCommandRerun(context.Background(), pr)
+			case "stop":
+				act.Msg, err = plg.handleCommandStop(context.Background(), pr, test.Args)
+			case "status":
+				act.Msg, err = plg.handleCommandStatus(context.Background(), event, pr)
+			}
+			if err != nil {
+				act.Error = err.Error()
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("%s mismatch (-want +got):\n%s", test.Cmd, strings.TrimSpace(diff))
+			}
+		})
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"net"
 	"net/http"
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/bradleyfalzon/ghinstallation"
+	"github.com/csweichel/werft/pkg/api/repoconfig"
 	v1 "github.com/csweichel/werft/pkg/api/v1"
 	"github.com/csweichel/werft/pkg/plugin/client"
 	plugin "github.com/csweichel/werft/pkg/plugin/client"
@@ -29,8 +31,12 @@ var (
 
 	commandHelp = `You can interact with werft using: ` + "`" + `/werft command <args>` + "`" + `.
 Available commands are:
- - ` + "`" + `/werft run [annotation=value]` + "`" + ` which starts a new werft job from this context.
-    You can optionally pass multiple whitespace-separated annotations.
+ - ` + "`" + `/werft run [jobspec] [annotation=value]` + "`" + ` which starts a new werft job from this context.
+    You can optionally name the job spec to run, e.g. ` + "`" + `/werft run integration-tests` + "`" + ` for ` + "`" + `.werft/integration-tests.yaml` + "`" + `,
+    and pass multiple whitespace-separated annotations.
+ - ` + "`" + `/werft rerun` + "`" + ` re-runs the last failed job of this PR's head
+ - ` + "`" + `/werft stop [job]` + "`" + ` stops a job, or all running jobs of this PR's head if no job is named
+ - ` + "`" + `/werft status` + "`" + ` lists the jobs of this PR's head
  - ` + "`" + `/werft help` + "`" + ` displays this help
 `
 )
@@ -55,6 +61,12 @@ type Config struct {
 		// If true, we'll update the comment to give feedback about what werft understood.
 		UpdateComment bool `yaml:"updateComment"`
 	} `yaml:"pullRequestComments"`
+
+	Checks struct {
+		// If true, job status is reported using check runs instead of commit statuses.
+		// This requires the checks permission and the check run event.
+		Enabled bool `yaml:"enabled"`
+	} `yaml:"checks"`
 }
 
 type JobProtectionLevel string
@@ -141,6 +153,22 @@ func (p *githubTriggerPlugin) updateGitHubStatus(job *v1.JobStatus) error {
 		return nil
 	}
 
+	var (
+		segs  = strings.Split(statusDstRepo, "/")
+		owner string
+		repo  string
+	)
+	if len(segs) == 2 {
+		owner, repo = segs[0], segs[1]
+	} else {
+		owner, repo = job.Metadata.Owner, job.Metadata.Repository.Repo
+	}
+
+	ctx := context.Background()
+	if p.Config.Checks.Enabled {
+		return p.updateGitHubCheck(ctx, owner, repo, job)
+	}
+
 	var (
 		state string
 		desc  string
@@ -167,19 +195,7 @@ func (p *githubTriggerPlugin) updateGitHubStatus(job *v1.JobStatus) error {
 		TargetURL:   &url,
 	}
 
-	var (
-		segs  = strings.Split(statusDstRepo, "/")
-		owner string
-		repo  string
-	)
-	if len(segs) == 2 {
-		owner, repo = segs[0], segs[1]
-	} else {
-		owner, repo = job.Metadata.Owner, job.Metadata.Repository.Repo
-	}
-
 	log.WithField("status", ghstatus).Debugf("updating GitHub status for %s", job.Name)
-	ctx := context.Background()
 	_, _, err := p.Github.Repositories.CreateStatus(ctx, owner, repo, job.Metadata.Repository.Revision, ghstatus)
 	if err != nil {
 		return err
@@ -284,6 +300,8 @@ func (p *githubTriggerPlugin) HandleGithubWebhook(w http.ResponseWriter, r *http
 		p.processIssueCommentEvent(r.Context(), event)
 	case *github.PullRequestEvent:
 		p.processPullRequestEditedEvent(r.Context(), event)
+	case *github.CheckRunEvent:
+		p.processCheckRunEvent(r.Context(), event)
 	case *github.DeleteEvent:
 		// handled by the push event already
 	default:
@@ -352,6 +370,10 @@ func (p *githubTriggerPlugin) processPullRequestEditedEvent(ctx context.Context,
 			if !hasStatusUpdateAnnotation && a.Key == annotationStatusUpdate {
 				continue
 			}
+			// The same holds for the base of the changed files which integrations add to the jobs they start.
+			if a.Key == repoconfig.AnnotationChangedFilesBase {
+				continue
+			}
 			jobAnnotations[a.Key] = a.Value
 		}
 
@@ -373,6 +395,7 @@ func (p *githubTriggerPlugin) processPullRequestEditedEvent(ctx context.Context,
 	)
 	if p.userIsAllowedToStartJob(ctx, prDstOwner, prDstRepo, event.GetSender().GetLogin()) {
 		req := p.prepareStartJobRequest(event.Sender, event.Repo.Owner, event.Repo.Owner, event.Repo, event.Repo, ref, rev, v1.JobTrigger_TRIGGER_MANUAL)
+		setChangedFilesBase(req, event.GetPullRequest().GetBase().GetSHA())
 		for k, v := range prAnnotations {
 			req.Metadata.Annotations = append(req.Metadata.Annotations, &v1.Annotation{
 				Key:   k,
@@ -381,7 +404,7 @@ func (p *githubTriggerPlugin) processPullRequestEditedEvent(ctx context.Context,
 		}
 		resp, err := p.Werft.StartJob2(ctx, req)
 		if err == nil {
-			msg = fmt.Sprintf("started the job as [%s](%s/job/%s) because the annotations in the pull request description changed", resp.Status.Name, p.Config.BaseURL, resp.Status.Name)
+			msg = describeStartedJobs(p.Config.BaseURL, resp) + " because the annotations in the pull request description changed"
 			switch p.Config.JobProtection {
 			case JobProtectionDefaultBranch:
 				msg += fmt.Sprintf("\n(with `.werft/` from `%s`)", event.Repo.GetDefaultBranch())
@@ -432,6 +455,9 @@ func (p *githubTriggerPlugin) processPushEvent(event *github.PushEvent) {
 	}
 
 	req := p.prepareStartJobRequest(event.Sender, event.Repo.Owner, event.Repo.Owner, event.Repo, event.Repo, event.GetRef(), rev, trigger)
+	if trigger == v1.JobTrigger_TRIGGER_PUSH {
+		setChangedFilesBase(req, event.GetBefore())
+	}
 	_, err := p.Werft.StartJob2(ctx, req)
 
 	if err != nil {
@@ -439,6 +465,34 @@ func (p *githubTriggerPlugin) processPushEvent(event *github.PushEvent) {
 	}
 }
 
+// describeStartedJobs links to the jobs a start request started
+func describeStartedJobs(baseURL string, resp *v1.StartJobResponse) string {
+	link := func(j *v1.JobStatus) string {
+		return fmt.Sprintf("[%s](%s/job/%s)", j.Name, baseURL, j.Name)
+	}
+	if len(resp.AdditionalJobs) == 0 {
+		return "started the job as " + link(resp.Status)
+	}
+
+	links := []string{link(resp.Status)}
+	for _, j := range resp.AdditionalJobs {
+		links = append(links, link(j))
+	}
+	return "started the jobs " + strings.Join(links, ", ")
+}
+
+// setChangedFilesBase makes werft compute the files changed by a job against base, e.g. to evaluate path filters
+func setChangedFilesBase(req *v1.StartJobRequest2, base string) {
+	if strings.Trim(base, "0") == "" {
+		// new branches have no sensible base - werft uses the revision's parent instead
+		return
+	}
+	req.Metadata.Annotations = append(req.Metadata.Annotations, &v1.Annotation{
+		Key:   repoconfig.AnnotationChangedFilesBase,
+		Value: base,
+	})
+}
+
 type githubRepo interface {
 	GetName() string
 	GetFullName() string
@@ -626,6 +680,12 @@ func (p *githubTriggerPlugin) processIssueCommentEvent(ctx context.Context, even
 	switch cmd {
 	case "run":
 		resp, err = p.handleCommandRun(ctx, event, pr, args)
+	case "rerun":
+		resp, err = p.handleCommandRerun(ctx, pr)
+	case "stop":
+		resp, err = p.handleCommandStop(ctx, pr, args)
+	case "status":
+		resp, err = p.handleCommandStatus(ctx, event, pr)
 	case "help":
 		resp = commandHelp
 	default:
@@ -642,7 +702,47 @@ func (p *githubTriggerPlugin) processIssueCommentEvent(ctx context.Context, even
 	feedback.Message = resp
 }
 
+// jobSpecNamePattern matches the names of job specs PR comments can run
+var jobSpecNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// isValidJobSpecName returns true if name names a job spec in .werft/, rather than a path elsewhere
+func isValidJobSpecName(name string) bool {
+	return jobSpecNamePattern.MatchString(name) && !strings.Contains(name, "..")
+}
+
 func (p *githubTriggerPlugin) handleCommandRun(ctx context.Context, event *github.IssueCommentEvent, pr *github.PullRequest, args []string) (msg string, err error) {
+	ref := pr.GetHead().GetRef()
+	if !strings.HasPrefix(ref, "refs/") {
+		// we assume this is a branch
+		ref = "refs/heads/" + ref
+	}
+
+	src := pr.GetHead().GetRepo()
+	dst := event.GetRepo()
+
+	// The first argument names a job spec if it's no annotation and the job spec exists.
+	// Otherwise we treat it as annotation without value, which is what it used to be.
+	var jobPath string
+	if len(args) > 0 && !strings.Contains(args[0], "=") {
+		if !isValidJobSpecName(args[0]) {
+			return "", fmt.Errorf("invalid job spec %s: job specs in .werft/ are named by letters, digits, _, . and - only", args[0])
+		}
+
+		var (
+			path = fmt.Sprintf(".werft/%s.yaml", args[0])
+			ok   bool
+		)
+		if p.Config.JobProtection == JobProtectionDefaultBranch {
+			ok = p.fileExists(ctx, dst.GetOwner().GetLogin(), dst.GetName(), dst.GetDefaultBranch(), path)
+		} else {
+			ok = p.fileExists(ctx, src.GetOwner().GetLogin(), src.GetName(), pr.GetHead().GetSHA(), path)
+		}
+		if ok {
+			jobPath = path
+			args = args[1:]
+		}
+	}
+
 	argm := make(map[string]string)
 	for _, arg := range args {
 		var key, value string
@@ -654,16 +754,16 @@ func (p *githubTriggerPlugin) handleCommandRun(ctx context.Context, event *githu
 		argm[key] = value
 	}
 
-	ref := pr.GetHead().GetRef()
-	if !strings.HasPrefix(ref, "refs/") {
-		// we assume this is a branch
-		ref = "refs/heads/" + ref
-	}
-
-	src := pr.GetHead().GetRepo()
-	dst := event.GetRepo()
-
 	req := p.prepareStartJobRequest(event.Comment.User, src.Owner, dst.Owner, src, dst, ref, pr.GetHead().GetSHA(), v1.JobTrigger_TRIGGER_MANUAL)
+	setChangedFilesBase(req, pr.GetBase().GetSHA())
+	if jobPath != "" {
+		switch s := req.Spec.Source.(type) {
+		case *v1.JobSpec_JobPath:
+			s.JobPath = jobPath
+		case *v1.JobSpec_Repo:
+			s.Repo.Path = jobPath
+		}
+	}
 	for _, e := range req.Metadata.Annotations {
 		delete(argm, e.Key)
 	}
@@ -680,7 +780,7 @@ func (p *githubTriggerPlugin) handleCommandRun(ctx context.Context, event *githu
 		return "", fmt.Errorf("cannot start job - please talk to whoever's in charge of your Werft installation")
 	}
 
-	msg = fmt.Sprintf("started the job as [%s](%s/job/%s)", resp.Status.Name, p.Config.BaseURL, resp.Status.Name)
+	msg = describeStartedJobs(p.Config.BaseURL, resp)
 	switch p.Config.JobProtection {
 	case JobProtectionDefaultBranch:
 		msg += fmt.Sprintf("\n(with `.werft/` from `%s`)", dst.GetDefaultBranch())
@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
@@ -57,6 +60,52 @@ func TestParseCommand(t *testing.T) {
 	}
 }
 
+func TestDescribeStartedJobs(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Resp        *v1.StartJobResponse
+		Expectation string
+	}{
+		{
+			Name:        "single job",
+			Resp:        &v1.StartJobResponse{Status: &v1.JobStatus{Name: "werft-build-main.1"}},
+			Expectation: "started the job as [werft-build-main.1](https://werft.example.com/job/werft-build-main.1)",
+		},
+		{
+			Name: "additional jobs",
+			Resp: &v1.StartJobResponse{
+				Status:         &v1.JobStatus{Name: "werft-lint-main.1"},
+				AdditionalJobs: []*v1.JobStatus{{Name: "werft-test-main.1"}},
+			},
+			Expectation: "started the jobs [werft-lint-main.1](https://werft.example.com/job/werft-lint-main.1), [werft-test-main.1](https://werft.example.com/job/werft-test-main.1)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act := describeStartedJobs("https://werft.example.com", test.Resp)
+			if act != test.Expectation {
+				t.Errorf("expected %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestIsValidJobSpecName(t *testing.T) {
+	for name, expectation := range map[string]bool{
+		"integration-tests": true,
+		"build_1.17":        true,
+		"":                  false,
+		"../foo":            false,
+		"a/b":               false,
+		"..":                false,
+		"foo..bar":          false,
+	} {
+		if act := isValidJobSpecName(name); act != expectation {
+			t.Errorf("%q: expected %v, got %v", name, expectation, act)
+		}
+	}
+}
+
 func TestHandleCommandRun(t *testing.T) {
 	type Expectation struct {
 		StartRequest *v1.StartJobRequest2 `json:"req,omitempty"`
@@ -67,6 +116,8 @@ func TestHandleCommandRun(t *testing.T) {
 		JobProtection JobProtectionLevel
 		Event         *github.IssueCommentEvent
 		PR            *github.PullRequest
+		// Files exist in all repositories at all refs
+		Files []string
 	}
 
 	fs, err := filepath.Glob("fixtures/handleCommandRun_*.json")
@@ -91,7 +142,7 @@ func TestHandleCommandRun(t *testing.T) {
 			var act Expectation
 			client := mock.NewMockWerftServiceClient(mockCtrl)
 			client.EXPECT().StartJob2(gomock.Any(), gomock.Any(), gomock.Any()).
-				MinTimes(1).
+				AnyTimes().
 				DoAndReturn(func(ctx context.Context, creq *v1.StartJobRequest2) (*v1.StartJobResponse, error) {
 					sort.Slice(creq.Metadata.Annotations, func(i, j int) bool {
 						return creq.Metadata.Annotations[i].Key < creq.Metadata.Annotations[j].Key
@@ -116,8 +167,26 @@ func TestHandleCommandRun(t *testing.T) {
 					}, nil
 				})
 
+			gh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				segs := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 5)
+				if len(segs) == 5 && segs[3] == "contents" {
+					for _, f := range fixture.Files {
+						if f == segs[4] {
+							w.Header().Set("Content-Type", "application/json")
+							_ = json.NewEncoder(w).Encode(&github.RepositoryContent{Type: github.String("file"), Path: &f})
+							return
+						}
+					}
+				}
+				http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+			}))
+			defer gh.Close()
+			ghClient := github.NewClient(nil)
+			ghClient.BaseURL, _ = url.Parse(gh.URL + "/")
+
 			plg := &githubTriggerPlugin{
-				Werft: client,
+				Werft:  client,
+				Github: ghClient,
 				Config: &Config{
 					JobProtection: fixture.JobProtection,
 				},
@@ -203,7 +272,7 @@ func TestProcessPushEvent(t *testing.T) {
 			var startReq *v1.StartJobRequest2
 			client := mock.NewMockWerftServiceClient(mockCtrl)
 			client.EXPECT().StartJob2(gomock.Any(), gomock.Any(), gomock.Any()).
-				MinTimes(1).
+				AnyTimes().
 				DoAndReturn(func(ctx context.Context, creq *v1.StartJobRequest2) (*v1.StartJobResponse, error) {
 					startReq = creq
 					return &v1.StartJobResponse{
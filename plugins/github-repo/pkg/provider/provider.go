@@ -25,6 +25,9 @@ import (
 const (
 	// defaultContainerImage is the image we use to clone the code, unless configured otherwise
 	defaultContainerImage = "alpine/git:latest"
+
+	// maxChangedFiles is the number of files GitHub lists at most when comparing or getting commits
+	maxChangedFiles = 300
 )
 
 // GitCredentialHelper can authenticate provide authentication credentials for a repository
@@ -261,6 +264,53 @@ func (s *GithubRepoServer) ListFiles(ctx context.Context, req *common.ListFilesR
 	return &common.ListFilesReponse{Paths: res}, nil
 }
 
+// ChangedFiles lists the files which changed between a base revision and the revision of the repository.
+// GitHub lists at most 300 files, hence for very large changes we return an error rather than an incomplete list,
+// so that werft considers all paths changed.
+func (s *GithubRepoServer) ChangedFiles(ctx context.Context, req *common.ChangedFilesRequest) (*common.ChangedFilesResponse, error) {
+	var (
+		repo  = req.Repository
+		base  = req.BaseRevision
+		files []*github.CommitFile
+	)
+	if base == "" {
+		commit, _, err := s.Client.Repositories.GetCommit(ctx, repo.Owner, repo.Repo, repo.Revision)
+		if err != nil {
+			return nil, translateGitHubToGRPCError(err, repo.Revision, repo.Ref)
+		}
+		if len(commit.Parents) == 0 {
+			// this is the root commit, i.e. all of its files are new
+			files = commit.Files
+		} else {
+			base = commit.Parents[0].GetSHA()
+		}
+	}
+	if base != "" {
+		cmp, _, err := s.Client.Repositories.CompareCommits(ctx, repo.Owner, repo.Repo, base, repo.Revision)
+		if err != nil {
+			return nil, translateGitHubToGRPCError(err, repo.Revision, repo.Ref)
+		}
+		files = cmp.Files
+	}
+	if len(files) >= maxChangedFiles {
+		return nil, status.Errorf(codes.OutOfRange, "%s changes %d files or more, which GitHub cannot list completely", repo.Revision, maxChangedFiles)
+	}
+
+	return &common.ChangedFilesResponse{Paths: changedPaths(files)}, nil
+}
+
+// changedPaths returns the paths of changed files. Renamed files count as changes of their old and new path.
+func changedPaths(files []*github.CommitFile) []string {
+	res := make([]string, 0, len(files))
+	for _, f := range files {
+		res = append(res, f.GetFilename())
+		if pf := f.GetPreviousFilename(); pf != "" {
+			res = append(res, pf)
+		}
+	}
+	return res
+}
+
 func translateGitHubToGRPCError(err error, rev, ref string) error {
 	if gherr, ok := err.(*github.ErrorResponse); ok && gherr.Response.StatusCode == 422 {
 		msg := fmt.Sprintf("revision %s", rev)
@@ -1,9 +1,20 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/plugin/common"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v31/github"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestParseAnnotations(t *testing.T) {
@@ -70,3 +81,62 @@ func TestParseAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestChangedFiles(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/csweichel/werft/commits/head", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"sha":"head","parents":[{"sha":"parent"}],"files":[{"filename":"not-used"}]}`))
+	})
+	mux.HandleFunc("/repos/csweichel/werft/commits/root", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"sha":"root","files":[{"filename":"README.md"}]}`))
+	})
+	mux.HandleFunc("/repos/csweichel/werft/compare/parent...head", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"files":[{"filename":"pkg/main.go"},{"filename":"docs/new.md","previous_filename":"docs/old.md"}]}`))
+	})
+	mux.HandleFunc("/repos/csweichel/werft/compare/base...head", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"files":[{"filename":"go.mod"}]}`))
+	})
+	mux.HandleFunc("/repos/csweichel/werft/compare/large...head", func(w http.ResponseWriter, r *http.Request) {
+		files := make([]string, maxChangedFiles)
+		for i := range files {
+			files[i] = fmt.Sprintf(`{"filename":"file-%d"}`, i)
+		}
+		_, _ = w.Write([]byte(`{"files":[` + strings.Join(files, ",") + `]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	s := &GithubRepoServer{Client: client}
+
+	tests := []struct {
+		Name        string
+		Revision    string
+		Base        string
+		Expectation []string
+		Error       codes.Code
+	}{
+		{Name: "parent", Revision: "head", Expectation: []string{"pkg/main.go", "docs/new.md", "docs/old.md"}},
+		{Name: "base", Revision: "head", Base: "base", Expectation: []string{"go.mod"}},
+		{Name: "root commit", Revision: "root", Expectation: []string{"README.md"}},
+		{Name: "truncated", Revision: "head", Base: "large", Error: codes.OutOfRange},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			resp, err := s.ChangedFiles(context.Background(), &common.ChangedFilesRequest{
+				Repository:   &v1.Repository{Owner: "csweichel", Repo: "werft", Revision: test.Revision},
+				BaseRevision: test.Base,
+			})
+			if code := status.Code(err); code != test.Error {
+				t.Fatalf("expected %v, got %v: %v", test.Error, code, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.Expectation, resp.Paths); diff != "" {
+				t.Errorf("ChangedFiles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strconv"
+
+	plugin "github.com/csweichel/werft/pkg/plugin/client"
+	"github.com/csweichel/werft/pkg/plugin/common"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Config configures this plugin
+type Config struct {
+	// BaseURL is the URL of the GitLab installation, e.g. https://gitlab.example.com
+	BaseURL      string `yaml:"baseURL"`
+	EnableTeams  bool   `yaml:"enableTeams,omitempty"`
+	EnableEmails bool   `yaml:"enableEmails,omitempty"`
+}
+
+func main() {
+	plugin.Serve(&Config{},
+		plugin.WithAuthenticationPlugin(&gitlabAuthPlugin{}),
+	)
+	fmt.Fprintln(os.Stderr, "shutting down")
+}
+
+type gitlabAuthPlugin struct{}
+
+func (*gitlabAuthPlugin) Run(ctx context.Context, config interface{}) (common.AuthenticationPluginServer, error) {
+	cfg, ok := config.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("config has wrong type %s", reflect.TypeOf(config))
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://gitlab.com"
+	}
+
+	return &authServer{Config: cfg}, nil
+}
+
+type authServer struct {
+	Config *Config
+
+	// clientOpts are passed to every GitLab client we create
+	clientOpts []gitlab.ClientOptionFunc
+}
+
+// Authenticate interprets the token as GitLab personal access token
+func (as *authServer) Authenticate(ctx context.Context, req *common.AuthenticateRequest) (*common.AuthenticateResponse, error) {
+	client, err := gitlab.NewClient(req.Token, append([]gitlab.ClientOptionFunc{gitlab.WithBaseURL(as.Config.BaseURL)}, as.clientOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	user, resp, err := client.Users.CurrentUser(gitlab.WithContext(ctx))
+	if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+		return &common.AuthenticateResponse{Known: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var emails []string
+	if as.Config.EnableEmails {
+		if user.Email != "" {
+			emails = append(emails, user.Email)
+		}
+		rawEmails, _, _ := client.Users.ListEmails(gitlab.WithContext(ctx))
+		for _, e := range rawEmails {
+			if e.Email == user.Email {
+				continue
+			}
+			emails = append(emails, e.Email)
+		}
+	}
+
+	var teams []string
+	if as.Config.EnableTeams {
+		rawGroups, _, _ := client.Groups.ListGroups(&gitlab.ListGroupsOptions{ListOptions: gitlab.ListOptions{PerPage: 100}}, gitlab.WithContext(ctx))
+		for _, g := range rawGroups {
+			teams = append(teams, g.FullPath)
+		}
+	}
+
+	return &common.AuthenticateResponse{
+		Known:    true,
+		Username: user.Username,
+		Metadata: map[string]string{
+			"two-factor-authentication": strconv.FormatBool(user.TwoFactorEnabled),
+			"name":                      user.Name,
+		},
+		Emails: emails,
+		Teams:  teams,
+	}, nil
+}
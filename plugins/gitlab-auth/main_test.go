@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/csweichel/werft/pkg/plugin/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestAuthenticate(t *testing.T) {
+	fc, err := ioutil.ReadFile("fixtures/gitlab-api.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var responses map[string]json.RawMessage
+	err = json.Unmarshal(fc, &responses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "valid-token" {
+			http.Error(w, `{"message":"401 Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			http.Error(w, `{"message":"404 Not Found"}`, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(resp)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		Name        string
+		Config      Config
+		Token       string
+		Expectation *common.AuthenticateResponse
+	}{
+		{
+			Name:        "unknown token",
+			Token:       "invalid-token",
+			Expectation: &common.AuthenticateResponse{Known: false},
+		},
+		{
+			Name:  "known token",
+			Token: "valid-token",
+			Expectation: &common.AuthenticateResponse{
+				Known:    true,
+				Username: "csweichel",
+				Metadata: map[string]string{"name": "Christian Weichel", "two-factor-authentication": "true"},
+			},
+		},
+		{
+			Name:   "emails and teams",
+			Config: Config{EnableEmails: true, EnableTeams: true},
+			Token:  "valid-token",
+			Expectation: &common.AuthenticateResponse{
+				Known:    true,
+				Username: "csweichel",
+				Metadata: map[string]string{"name": "Christian Weichel", "two-factor-authentication": "true"},
+				Emails:   []string{"chris@example.com", "chris@werft.dev"},
+				Teams:    []string{"acme/platform", "acme"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			cfg := test.Config
+			cfg.BaseURL = srv.URL
+			as := &authServer{Config: &cfg, clientOpts: []gitlab.ClientOptionFunc{gitlab.WithoutRetries()}}
+
+			act, err := as.Authenticate(context.Background(), &common.AuthenticateRequest{Token: test.Token})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act, cmp.Comparer(func(a, b *common.AuthenticateResponse) bool {
+				return a.Known == b.Known && a.Username == b.Username &&
+					cmp.Equal(a.Metadata, b.Metadata) && cmp.Equal(a.Emails, b.Emails) && cmp.Equal(a.Teams, b.Teams)
+			})); diff != "" {
+				t.Errorf("Authenticate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,769 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/csweichel/werft/pkg/api/repoconfig"
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	plugin "github.com/csweichel/werft/pkg/plugin/client"
+	repo "github.com/csweichel/werft/plugins/github-repo/pkg/provider"
+	log "github.com/sirupsen/logrus"
+	"github.com/xanzy/go-gitlab"
+)
+
+var (
+	werftGitLabContextPrefix = "ci/werft"
+	werftResultChannelPrefix = "gitlab-check-"
+
+	// annotationStatusUpdate is set on jobs whoose status needs to be updated on GitLab.
+	// This is set only on jobs created through GitLab events and contains the project path.
+	annotationStatusUpdate = "updateGitLabStatus"
+
+	// emptyRevision is the revision GitLab sends as "after" when a branch or tag was deleted
+	emptyRevision = "0000000000000000000000000000000000000000"
+
+	commandHelp = `You can interact with werft using: ` + "`" + `/werft command <args>` + "`" + `.
+Available commands are:
+ - ` + "`" + `/werft run [annotation=value]` + "`" + ` which starts a new werft job from this context.
+    You can optionally pass multiple whitespace-separated annotations.
+ - ` + "`" + `/werft help` + "`" + ` displays this help
+`
+)
+
+// Config configures this plugin
+type Config struct {
+	BaseURL string `yaml:"baseURL"`
+
+	// GitLabURL is the URL of the GitLab installation, e.g. https://gitlab.example.com
+	GitLabURL string `yaml:"gitlabURL"`
+	// Token is an access token with api scope used to update commit statuses and comment on merge requests
+	Token string `yaml:"token"`
+	// WebhookSecret is the secret token configured for the webhook in GitLab
+	WebhookSecret string             `yaml:"webhookSecret"`
+	JobProtection JobProtectionLevel `yaml:"jobProtection"`
+
+	MRComments struct {
+		Enabled bool `yaml:"enabled"`
+
+		// If this is a non-empty list, the commenting user needs to be a member of at least one
+		// of the groups listed here for the build to start.
+		RequiresGroup []string `yaml:"requiresGroup"`
+	} `yaml:"mergeRequestComments"`
+}
+
+type JobProtectionLevel string
+
+const (
+	// JobProtectionOff           JobProtectionLevel = ""
+	JobProtectionDefaultBranch JobProtectionLevel = "default-branch"
+)
+
+// host returns the host of the GitLab installation which is used as repository host of the jobs we start
+func (c *Config) host() string {
+	u, err := url.Parse(c.GitLabURL)
+	if err != nil || u.Host == "" {
+		return "gitlab.com"
+	}
+	return u.Host
+}
+
+func main() {
+	plg := &gitlabTriggerPlugin{}
+	plugin.Serve(&Config{},
+		plugin.WithIntegrationPlugin(plg),
+		plugin.WithProxyPass(plg),
+	)
+}
+
+type gitlabTriggerPlugin struct {
+	Config *Config
+	Werft  v1.WerftServiceClient
+	GitLab *gitlab.Client
+
+	testMode bool
+}
+
+func (p *gitlabTriggerPlugin) Run(ctx context.Context, config interface{}, srv *plugin.Services) error {
+	cfg, ok := config.(*Config)
+	if !ok {
+		return fmt.Errorf("config has wrong type %s", reflect.TypeOf(config))
+	}
+	if cfg.GitLabURL == "" {
+		cfg.GitLabURL = "https://gitlab.com"
+	}
+
+	glClient, err := gitlab.NewClient(cfg.Token, gitlab.WithBaseURL(cfg.GitLabURL))
+	if err != nil {
+		return err
+	}
+
+	p.Config = cfg
+	p.Werft = srv
+	p.GitLab = glClient
+
+	errchan := make(chan error)
+	sub, err := srv.Subscribe(ctx, &v1.SubscribeRequest{})
+	if err != nil {
+		return fmt.Errorf("cannot subscribe for notification: %w", err)
+	}
+	log.Infof("status updates for GitLab set up")
+	go func() {
+		for {
+			inc, err := sub.Recv()
+			if err != nil {
+				errchan <- err
+				return
+			}
+
+			err = p.updateGitLabStatus(inc.Result)
+			if err != nil {
+				log.WithError(err).Error("cannot update GitLab status")
+			}
+		}
+	}()
+
+	select {
+	case err := <-errchan:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// updateGitLabStatus sets the commit status of a job's revision. GitLab shows those statuses as external pipeline
+// on the commit and its merge requests.
+func (p *gitlabTriggerPlugin) updateGitLabStatus(job *v1.JobStatus) error {
+	var (
+		wantsUpdate bool
+		project     string
+	)
+	for _, a := range job.Metadata.Annotations {
+		if a.Key == annotationStatusUpdate {
+			wantsUpdate = true
+			project = a.Value
+			break
+		}
+	}
+	if !wantsUpdate {
+		return nil
+	}
+	if project == "" {
+		project = job.Metadata.Repository.Owner + "/" + job.Metadata.Repository.Repo
+	}
+
+	var (
+		state gitlab.BuildStateValue
+		desc  string
+	)
+	switch job.Phase {
+	case v1.JobPhase_PHASE_PREPARING, v1.JobPhase_PHASE_STARTING:
+		state = gitlab.Pending
+		desc = "build is " + strings.TrimPrefix(strings.ToLower(job.Phase.String()), "phase_")
+	case v1.JobPhase_PHASE_RUNNING:
+		state = gitlab.Running
+		desc = "build is running"
+	default:
+		if job.Conditions.Success {
+			state = gitlab.Success
+			desc = "The build succeeded!"
+		} else {
+			state = gitlab.Failed
+			desc = "The build failed!"
+		}
+	}
+
+	var (
+		url      = fmt.Sprintf("%s/job/%s", p.Config.BaseURL, job.Name)
+		jobGLctx = werftGitLabContextPrefix + "/" + job.Metadata.JobSpecName
+		rev      = job.Metadata.Repository.Revision
+		ref      = gitlabRef(job.Metadata.Repository.Ref)
+	)
+	opts := &gitlab.SetCommitStatusOptions{
+		State:       state,
+		Name:        &jobGLctx,
+		Description: &desc,
+		TargetURL:   &url,
+	}
+	if ref != "" {
+		opts.Ref = &ref
+	}
+
+	log.WithField("status", opts).Debugf("updating GitLab status for %s", job.Name)
+	_, _, err := p.GitLab.Commits.SetCommitStatus(project, rev, opts)
+	if err != nil {
+		return err
+	}
+
+	// update all result statuses
+	var idx int
+	for _, r := range job.Results {
+		var (
+			ok    bool
+			glctx string
+		)
+		for _, c := range r.Channels {
+			if c == "gitlab" {
+				ok = true
+				glctx = fmt.Sprintf("%s/results/%03d", jobGLctx, idx)
+				idx++
+				break
+			}
+			if strings.HasPrefix(c, werftResultChannelPrefix) {
+				ok = true
+				glctx = fmt.Sprintf("%s/results/%s", jobGLctx, strings.TrimPrefix(c, werftResultChannelPrefix))
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		resultURL := url
+		if r.Type == "url" {
+			resultURL = r.Payload
+		}
+		success := gitlab.Success
+		if r.Type == "conclusion" {
+			success = conclusionToState(r.Payload)
+		}
+		desc := r.Description
+		ropts := &gitlab.SetCommitStatusOptions{
+			State:       success,
+			Name:        &glctx,
+			Description: &desc,
+			TargetURL:   &resultURL,
+		}
+		if ref != "" {
+			ropts.Ref = &ref
+		}
+		_, _, err := p.GitLab.Commits.SetCommitStatus(project, rev, ropts)
+		if err != nil {
+			log.WithError(err).WithField("job", job.Name).Warn("cannot update result status")
+		}
+	}
+
+	return nil
+}
+
+// conclusionToState translates a conclusion result, which uses the GitHub vocabulary, to a GitLab build state
+func conclusionToState(conclusion string) gitlab.BuildStateValue {
+	switch conclusion {
+	case "failure", "timed_out", "action_required":
+		return gitlab.Failed
+	case "cancelled":
+		return gitlab.Canceled
+	case "skipped", "neutral":
+		return gitlab.Skipped
+	default:
+		return gitlab.BuildStateValue(conclusion)
+	}
+}
+
+// gitlabRef turns a werft ref into the branch or tag name GitLab expects
+func gitlabRef(ref string) string {
+	ref = strings.TrimPrefix(ref, "refs/heads/")
+	ref = strings.TrimPrefix(ref, "refs/tags/")
+	return ref
+}
+
+func (p *gitlabTriggerPlugin) Serve(ctx context.Context, l net.Listener) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", http.HandlerFunc(p.HandleGitLabWebhook))
+	http.Serve(l, mux)
+	<-ctx.Done()
+
+	return nil
+}
+
+// HandleGitLabWebhook handles incoming GitLab events
+func (p *gitlabTriggerPlugin) HandleGitLabWebhook(w http.ResponseWriter, r *http.Request) {
+	var err error
+	defer func(err *error) {
+		if *err == nil {
+			return
+		}
+
+		log.WithError(*err).Warn("GitLab webhook error")
+		http.Error(w, (*err).Error(), http.StatusInternalServerError)
+	}(&err)
+
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if p.Config.WebhookSecret != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Gitlab-Token")), []byte(p.Config.WebhookSecret)) != 1 {
+		http.Error(w, "invalid webhook token", http.StatusUnauthorized)
+		return
+	}
+
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return
+	}
+	event, err := gitlab.ParseWebhook(gitlab.HookEventType(r), payload)
+	if err != nil && (strings.Contains(err.Error(), "unexpected event type") || strings.Contains(err.Error(), "unexpected noteable type")) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	switch event := event.(type) {
+	case *gitlab.PushEvent:
+		p.processPushEvent(event)
+	case *gitlab.TagEvent:
+		p.processTagEvent(event)
+	case *gitlab.MergeEvent:
+		p.processMergeRequestEvent(r.Context(), event)
+	case *gitlab.MergeCommentEvent:
+		p.processMergeCommentEvent(r.Context(), event)
+	default:
+		log.WithField("event", event).Debug("unhandled GitLab event")
+	}
+}
+
+// gitlabProject is the part of a GitLab project we need to start a job
+type gitlabProject struct {
+	ID                int
+	PathWithNamespace string
+	DefaultBranch     string
+}
+
+// split returns the namespace (which can contain subgroups) and name of the project
+func (p gitlabProject) split() (owner, repo string) {
+	idx := strings.LastIndex(p.PathWithNamespace, "/")
+	if idx < 0 {
+		return "", p.PathWithNamespace
+	}
+	return p.PathWithNamespace[:idx], p.PathWithNamespace[idx+1:]
+}
+
+func projectFromRepository(id int, r *gitlab.Repository) gitlabProject {
+	if r == nil {
+		return gitlabProject{ID: id}
+	}
+	return gitlabProject{ID: id, PathWithNamespace: r.PathWithNamespace, DefaultBranch: r.DefaultBranch}
+}
+
+func (p *gitlabTriggerPlugin) processPushEvent(event *gitlab.PushEvent) {
+	prj := gitlabProject{ID: event.ProjectID, PathWithNamespace: event.Project.PathWithNamespace, DefaultBranch: event.Project.DefaultBranch}
+	p.startPushJob(event.UserUsername, prj, event.Ref, event.Before, event.After)
+}
+
+func (p *gitlabTriggerPlugin) processTagEvent(event *gitlab.TagEvent) {
+	prj := gitlabProject{ID: event.ProjectID, PathWithNamespace: event.Project.PathWithNamespace, DefaultBranch: event.Project.DefaultBranch}
+	p.startPushJob(event.UserUsername, prj, event.Ref, event.Before, event.After)
+}
+
+func (p *gitlabTriggerPlugin) startPushJob(pusher string, prj gitlabProject, ref, before, rev string) {
+	ctx := context.Background()
+
+	trigger := v1.JobTrigger_TRIGGER_PUSH
+	if rev == emptyRevision {
+		trigger = v1.JobTrigger_TRIGGER_DELETED
+
+		// rev after deletion makes no sense
+		rev = ""
+	}
+
+	req := p.prepareStartJobRequest(pusher, prj, prj, ref, rev, trigger)
+	if trigger == v1.JobTrigger_TRIGGER_PUSH {
+		setChangedFilesBase(req, before)
+	}
+	_, err := p.Werft.StartJob2(ctx, req)
+
+	if err != nil {
+		log.WithError(err).Warn("GitLab webhook error")
+	}
+}
+
+func (p *gitlabTriggerPlugin) processMergeRequestEvent(ctx context.Context, event *gitlab.MergeEvent) {
+	mr := event.ObjectAttributes
+	// Pushing to the source branch of a merge request produces an "update" event, as do label or assignee changes.
+	// Annotations are only relevant when the description changes (opening a merge request is handled here as well).
+	mrAnnotations := repo.ParseAnnotations(mr.Description)
+	switch mr.Action {
+	case "update":
+		if event.Changes.Description.Previous == event.Changes.Description.Current {
+			return
+		}
+		oldAnnotations := repo.ParseAnnotations(event.Changes.Description.Previous)
+		// the annotations didn't change between the merge request edits - there's nothing to do
+		if mapEQ(oldAnnotations, mrAnnotations) {
+			return
+		}
+	case "open":
+	default:
+		return
+	}
+
+	hasStatusUpdateAnnotation := len(mrAnnotations) > 0 && mrAnnotations[annotationStatusUpdate] != ""
+
+	ref := mr.SourceBranch
+	if !strings.HasPrefix(ref, "refs/") {
+		// we assume this is a branch
+		ref = "refs/heads/" + ref
+	}
+	rev := mr.LastCommit.ID
+
+	lastJobs, err := p.Werft.ListJobs(ctx, &v1.ListJobsRequest{
+		Filter: []*v1.FilterExpression{
+			{
+				Terms: []*v1.FilterTerm{
+					{Field: "repo.ref", Value: ref, Operation: v1.FilterOp_OP_EQUALS},
+				},
+			},
+		},
+		Order: []*v1.OrderExpression{{Field: "created", Ascending: false}},
+		Limit: 10,
+	})
+	if err != nil {
+		log.WithError(err).Warn("cannot list last jobs when handling merge request description update")
+		return
+	}
+
+	// We only care about the last job that ran for the same commit as that of the merge request event
+	for _, lastJob := range lastJobs.Result {
+		if lastJob.Metadata.Repository.Revision != rev {
+			continue
+		}
+
+		jobAnnotations := make(map[string]string, len(lastJob.Metadata.Annotations))
+		for _, a := range lastJob.Metadata.Annotations {
+			// All jobs started by push events carry the status update annotation - see processPullRequestEditedEvent
+			// in the github-integration for why we ignore it here.
+			if !hasStatusUpdateAnnotation && a.Key == annotationStatusUpdate {
+				continue
+			}
+			if a.Key == repoconfig.AnnotationChangedFilesBase {
+				continue
+			}
+			jobAnnotations[a.Key] = a.Value
+		}
+
+		// If the annotations didn't change between the last job and the event we're currently processing we have nothing to do
+		if mapEQ(mrAnnotations, jobAnnotations) {
+			return
+		}
+		break
+	}
+
+	var (
+		msg    string
+		src    = projectFromRepository(mr.SourceProjectID, mr.Source)
+		dst    = projectFromRepository(mr.TargetProjectID, mr.Target)
+		sender string
+	)
+	if event.User != nil {
+		sender = event.User.Username
+	}
+	if p.userIsAllowedToStartJob(ctx, dst.PathWithNamespace, event.User) {
+		req := p.prepareStartJobRequest(sender, src, dst, ref, rev, v1.JobTrigger_TRIGGER_MANUAL)
+		setChangedFilesBase(req, mr.TargetBranch)
+		for k, v := range mrAnnotations {
+			req.Metadata.Annotations = append(req.Metadata.Annotations, &v1.Annotation{
+				Key:   k,
+				Value: v,
+			})
+		}
+		resp, err := p.Werft.StartJob2(ctx, req)
+		if err == nil {
+			msg = describeStartedJobs(p.Config.BaseURL, resp) + " because the annotations in the merge request description changed"
+			switch p.Config.JobProtection {
+			case JobProtectionDefaultBranch:
+				msg += fmt.Sprintf("\n(with `.werft/` from `%s`)", dst.DefaultBranch)
+			}
+		} else {
+			log.WithError(err).Warn("GitLab webhook error")
+			msg = "cannot start job - please talk to whoever's in charge of your Werft installation"
+		}
+	} else {
+		msg = "annotations in the merge request changed, but user is not allowed to start a job"
+	}
+
+	if p.testMode {
+		return
+	}
+	_, _, err = p.GitLab.Notes.CreateMergeRequestNote(dst.PathWithNamespace, mr.IID, &gitlab.CreateMergeRequestNoteOptions{
+		Body: &msg,
+	})
+	if err != nil {
+		log.WithError(err).Error("cannot create note after handling merge request description update")
+	}
+}
+
+func mapEQ(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		bv, ok := b[k]
+		if !ok || v != bv {
+			return false
+		}
+	}
+	return true
+}
+
+// describeStartedJobs links to the jobs a start request started
+func describeStartedJobs(baseURL string, resp *v1.StartJobResponse) string {
+	link := func(j *v1.JobStatus) string {
+		return fmt.Sprintf("[%s](%s/job/%s)", j.Name, baseURL, j.Name)
+	}
+	if len(resp.AdditionalJobs) == 0 {
+		return "started the job as " + link(resp.Status)
+	}
+
+	links := []string{link(resp.Status)}
+	for _, j := range resp.AdditionalJobs {
+		links = append(links, link(j))
+	}
+	return "started the jobs " + strings.Join(links, ", ")
+}
+
+// setChangedFilesBase makes werft compute the files changed by a job against base, e.g. to evaluate path filters.
+// Merge requests use their target branch as base: like git diff A...B GitLab compares against the merge base.
+func setChangedFilesBase(req *v1.StartJobRequest2, base string) {
+	if base == "" || base == emptyRevision {
+		// new branches have no sensible base - werft uses the revision's parent instead
+		return
+	}
+	req.Metadata.Annotations = append(req.Metadata.Annotations, &v1.Annotation{
+		Key:   repoconfig.AnnotationChangedFilesBase,
+		Value: base,
+	})
+}
+
+func (p *gitlabTriggerPlugin) prepareStartJobRequest(pusher string, src, dst gitlabProject, ref, rev string, trigger v1.JobTrigger) *v1.StartJobRequest2 {
+	host := p.Config.host()
+	srcOwner, srcRepo := src.split()
+	metadata := v1.JobMetadata{
+		Owner: pusher,
+		Repository: &v1.Repository{
+			Host:          host,
+			Owner:         srcOwner,
+			Repo:          srcRepo,
+			Ref:           ref,
+			Revision:      rev,
+			DefaultBranch: src.DefaultBranch,
+		},
+		Trigger: trigger,
+	}
+	if trigger != v1.JobTrigger_TRIGGER_DELETED {
+		metadata.Annotations = []*v1.Annotation{
+			{
+				Key:   annotationStatusUpdate,
+				Value: dst.PathWithNamespace,
+			},
+		}
+	}
+
+	var spec v1.JobSpec
+	if src.ID != dst.ID {
+		spec.NameSuffix = "fork"
+	}
+
+	if p.Config.JobProtection == JobProtectionDefaultBranch ||
+		trigger == v1.JobTrigger_TRIGGER_DELETED {
+		dstOwner, dstRepo := dst.split()
+		defaultBranch := &v1.Repository{
+			Host:          host,
+			Owner:         dstOwner,
+			Repo:          dstRepo,
+			Ref:           "refs/heads/" + dst.DefaultBranch,
+			DefaultBranch: dst.DefaultBranch,
+		}
+		spec.Source = &v1.JobSpec_Repo{
+			Repo: &v1.JobSpec_FromRepo{
+				Repo: defaultBranch,
+			},
+		}
+		spec.RepoSideload = append(spec.RepoSideload, &v1.JobSpec_FromRepo{
+			Repo: defaultBranch,
+			Path: ".werft",
+		})
+	} else {
+		// let werft decide where to get the job from
+		spec.Source = &v1.JobSpec_JobPath{}
+	}
+
+	return &v1.StartJobRequest2{
+		Metadata: &metadata,
+		Spec:     &spec,
+	}
+}
+
+// userIsAllowedToStartJob checks if the user has at least developer access to the project
+func (p *gitlabTriggerPlugin) userIsAllowedToStartJob(ctx context.Context, project string, user *gitlab.EventUser) bool {
+	if p.testMode {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+
+	member, _, err := p.GitLab.ProjectMembers.GetInheritedProjectMember(project, user.ID, gitlab.WithContext(ctx))
+	if err != nil {
+		log.WithError(err).WithField("project", project).WithField("user", user.Username).Warn("cannot get access level")
+		return false
+	}
+	return member.AccessLevel >= gitlab.DeveloperPermissions
+}
+
+func (p *gitlabTriggerPlugin) userIsGroupMember(ctx context.Context, user *gitlab.EventUser) bool {
+	if len(p.Config.MRComments.RequiresGroup) == 0 {
+		return true
+	}
+	if p.testMode {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+
+	for _, grp := range p.Config.MRComments.RequiresGroup {
+		_, _, err := p.GitLab.GroupMembers.GetGroupMember(grp, user.ID, gitlab.WithContext(ctx))
+		if err == nil {
+			return true
+		}
+		log.WithError(err).WithField("group", grp).WithField("user", user.Username).Debug("user is not a group member")
+	}
+	return false
+}
+
+func (p *gitlabTriggerPlugin) processMergeCommentEvent(ctx context.Context, event *gitlab.MergeCommentEvent) {
+	if !p.Config.MRComments.Enabled {
+		return
+	}
+
+	cmd, args, err := parseCommand(event.ObjectAttributes.Note)
+	if err == nil && cmd == "" {
+		return
+	}
+
+	var (
+		project = event.Project.PathWithNamespace
+		msg     string
+	)
+	defer func() {
+		if p.testMode || msg == "" {
+			return
+		}
+
+		_, _, err := p.GitLab.Notes.CreateMergeRequestNote(project, event.MergeRequest.IID, &gitlab.CreateMergeRequestNoteOptions{
+			Body: &msg,
+		}, gitlab.WithContext(ctx))
+		if err != nil {
+			log.WithError(err).Error("cannot respond to merge request note")
+		}
+	}()
+
+	if !p.userIsGroupMember(ctx, event.User) || !p.userIsAllowedToStartJob(ctx, project, event.User) {
+		msg = ":-1: not authorized"
+		return
+	}
+	if err != nil {
+		msg = ":-1: " + err.Error()
+		return
+	}
+
+	var resp string
+	switch cmd {
+	case "run":
+		resp, err = p.handleCommandRun(ctx, event, args)
+	case "help":
+		resp = commandHelp
+	default:
+		err = fmt.Errorf("unknown command: %s\nUse `/werft help` to list the available commands", cmd)
+	}
+	if err != nil {
+		log.WithError(err).Warn("GitLab webhook error")
+		msg = ":-1: " + err.Error()
+		return
+	}
+	msg = ":+1: " + resp
+}
+
+func (p *gitlabTriggerPlugin) handleCommandRun(ctx context.Context, event *gitlab.MergeCommentEvent, args []string) (msg string, err error) {
+	argm := make(map[string]string)
+	for _, arg := range args {
+		var key, value string
+		if segs := strings.Split(arg, "="); len(segs) == 1 {
+			key = arg
+		} else {
+			key, value = segs[0], strings.Join(segs[1:], "=")
+		}
+		argm[key] = value
+	}
+
+	mr := event.MergeRequest
+	ref := mr.SourceBranch
+	if !strings.HasPrefix(ref, "refs/") {
+		// we assume this is a branch
+		ref = "refs/heads/" + ref
+	}
+
+	var (
+		src    = projectFromRepository(mr.SourceProjectID, mr.Source)
+		dst    = projectFromRepository(mr.TargetProjectID, mr.Target)
+		sender string
+	)
+	if event.User != nil {
+		sender = event.User.Username
+	}
+
+	req := p.prepareStartJobRequest(sender, src, dst, ref, mr.LastCommit.ID, v1.JobTrigger_TRIGGER_MANUAL)
+	setChangedFilesBase(req, mr.TargetBranch)
+	for _, e := range req.Metadata.Annotations {
+		delete(argm, e.Key)
+	}
+	for k, v := range argm {
+		req.Metadata.Annotations = append(req.Metadata.Annotations, &v1.Annotation{
+			Key:   k,
+			Value: v,
+		})
+	}
+
+	resp, err := p.Werft.StartJob2(ctx, req)
+	if err != nil {
+		log.WithError(err).Warn("GitLab webhook error")
+		return "", fmt.Errorf("cannot start job - please talk to whoever's in charge of your Werft installation")
+	}
+
+	msg = describeStartedJobs(p.Config.BaseURL, resp)
+	switch p.Config.JobProtection {
+	case JobProtectionDefaultBranch:
+		msg += fmt.Sprintf("\n(with `.werft/` from `%s`)", dst.DefaultBranch)
+	}
+
+	return msg, nil
+}
+
+func parseCommand(msg string) (cmd string, args []string, err error) {
+	for _, l := range strings.Split(msg, "\n") {
+		l = strings.TrimSpace(l)
+		if !strings.HasPrefix(l, "/werft") {
+			continue
+		}
+		l = strings.TrimPrefix(l, "/werft")
+		l = strings.TrimSpace(l)
+
+		segs := strings.Fields(l)
+		if len(segs) < 1 {
+			return "", nil, fmt.Errorf("cannot parse %s: missing command", l)
+		}
+
+		return segs[0], segs[1:], nil
+	}
+
+	return "", nil, nil
+}
@@ -0,0 +1,398 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/api/v1/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestParseCommand(t *testing.T) {
+	type Expectation struct {
+		Cmd  string
+		Args []string
+		Err  string
+	}
+	tests := []struct {
+		Name        string
+		Input       string
+		Expectation Expectation
+	}{
+		{Name: "empty line", Input: ""},
+		{Name: "ignore line", Input: "something\nsomethingelse"},
+		{Name: "no command", Input: "/werft", Expectation: Expectation{Err: "cannot parse : missing command"}},
+		{Name: "no arg", Input: "/werft foo", Expectation: Expectation{Cmd: "foo", Args: []string{}}},
+		{Name: "one arg", Input: "/werft foo bar", Expectation: Expectation{Cmd: "foo", Args: []string{"bar"}}},
+		{Name: "two args", Input: "/werft foo bar=baz something", Expectation: Expectation{Cmd: "foo", Args: []string{"bar=baz", "something"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				act Expectation
+				err error
+			)
+			act.Cmd, act.Args, err = parseCommand(test.Input)
+			if err != nil {
+				act.Err = err.Error()
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("parseCommand() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func startJobResponse(ctx context.Context, creq *v1.StartJobRequest2) (*v1.StartJobResponse, error) {
+	return &v1.StartJobResponse{
+		Status: &v1.JobStatus{
+			Name: "foo",
+			Metadata: &v1.JobMetadata{
+				Owner: "owner",
+				Repository: &v1.Repository{
+					Host:     "gitlab.com",
+					Owner:    "repo-owner",
+					Repo:     "repo",
+					Ref:      "ref",
+					Revision: "rev",
+				},
+				Trigger:     v1.JobTrigger_TRIGGER_MANUAL,
+				JobSpecName: "foo",
+			},
+		},
+	}, nil
+}
+
+// checkGolden compares the start request against the golden file of the fixture fn. If there's no golden file yet, we write one and fail the test.
+func checkGolden(t *testing.T, fn string, act *v1.StartJobRequest2) {
+	var expectation *v1.StartJobRequest2
+	goldenFN := strings.TrimSuffix(fn, filepath.Ext(fn)) + ".golden"
+	if fc, err := os.ReadFile(goldenFN); err == nil && len(fc) > 0 {
+		var ex v1.StartJobRequest2
+		err = jsonpb.Unmarshal(bytes.NewReader(fc), &ex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectation = &ex
+	} else if os.IsNotExist(err) {
+		var (
+			m   jsonpb.Marshaler
+			fc  string
+			err error
+		)
+		if act != nil {
+			fc, err = m.MarshalToString(act)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		_ = ioutil.WriteFile(goldenFN, []byte(fc), 0644)
+		t.Fatalf("no golden file present %s: wrote %s", fn, goldenFN)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+
+	less := func(a, b *v1.Annotation) bool { return a.Key < b.Key }
+	if diff := cmp.Diff(expectation, act, cmpopts.SortSlices(less)); diff != "" {
+		t.Errorf("start request mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandleCommandRun(t *testing.T) {
+	type Fixture struct {
+		JobProtection JobProtectionLevel
+		Event         *gitlab.MergeCommentEvent
+	}
+
+	fs, err := filepath.Glob("fixtures/handleCommandRun_*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fn := range fs {
+		t.Run(fn, func(t *testing.T) {
+			fc, err := ioutil.ReadFile(fn)
+			if err != nil {
+				t.Fatalf("cannot read %s: %v", fn, err)
+			}
+			var fixture Fixture
+			err = json.Unmarshal(fc, &fixture)
+			if err != nil {
+				t.Fatalf("cannot unmarshal %s: %v", fn, err)
+			}
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			var startReq *v1.StartJobRequest2
+			client := mock.NewMockWerftServiceClient(mockCtrl)
+			client.EXPECT().StartJob2(gomock.Any(), gomock.Any(), gomock.Any()).
+				MinTimes(1).
+				DoAndReturn(func(ctx context.Context, creq *v1.StartJobRequest2) (*v1.StartJobResponse, error) {
+					sort.Slice(creq.Metadata.Annotations, func(i, j int) bool {
+						return creq.Metadata.Annotations[i].Key < creq.Metadata.Annotations[j].Key
+					})
+					startReq = creq
+					return startJobResponse(ctx, creq)
+				})
+
+			plg := &gitlabTriggerPlugin{
+				Werft: client,
+				Config: &Config{
+					BaseURL:       "https://werft.example.com",
+					JobProtection: fixture.JobProtection,
+				},
+			}
+			_, args, err := parseCommand(fixture.Event.ObjectAttributes.Note)
+			if err != nil {
+				t.Fatal(err)
+			}
+			msg, err := plg.handleCommandRun(context.Background(), fixture.Event, args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(msg, "https://werft.example.com/job/foo") {
+				t.Errorf("response does not link to the job: %s", msg)
+			}
+
+			checkGolden(t, fn, startReq)
+		})
+	}
+}
+
+func TestProcessPushEvent(t *testing.T) {
+	type Fixture struct {
+		Type      string
+		GitLabURL string
+		Event     json.RawMessage
+	}
+
+	fs, err := filepath.Glob("fixtures/processPushEvent_*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fn := range fs {
+		t.Run(fn, func(t *testing.T) {
+			fc, err := ioutil.ReadFile(fn)
+			if err != nil {
+				t.Fatalf("cannot read %s: %v", fn, err)
+			}
+			var fixture Fixture
+			err = json.Unmarshal(fc, &fixture)
+			if err != nil {
+				t.Fatalf("cannot unmarshal %s: %v", fn, err)
+			}
+			if len(fixture.Event) == 0 {
+				t.Fatal("broken fixture: no event")
+			}
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			var startReq *v1.StartJobRequest2
+			client := mock.NewMockWerftServiceClient(mockCtrl)
+			client.EXPECT().StartJob2(gomock.Any(), gomock.Any(), gomock.Any()).
+				MinTimes(1).
+				DoAndReturn(func(ctx context.Context, creq *v1.StartJobRequest2) (*v1.StartJobResponse, error) {
+					startReq = creq
+					return startJobResponse(ctx, creq)
+				})
+
+			plg := &gitlabTriggerPlugin{
+				Werft: client,
+				Config: &Config{
+					GitLabURL:     fixture.GitLabURL,
+					WebhookSecret: "secret",
+				},
+			}
+
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(fixture.Event))
+			req.Header.Set("X-Gitlab-Event", fixture.Type)
+			req.Header.Set("X-Gitlab-Token", "secret")
+			rec := httptest.NewRecorder()
+			plg.HandleGitLabWebhook(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("unexpected webhook response %d: %s", rec.Code, rec.Body.String())
+			}
+
+			checkGolden(t, fn, startReq)
+		})
+	}
+}
+
+func TestWebhookSecret(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	plg := &gitlabTriggerPlugin{
+		Werft:  mock.NewMockWerftServiceClient(mockCtrl),
+		Config: &Config{WebhookSecret: "secret"},
+	}
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"object_kind": "push"}`))
+	req.Header.Set("X-Gitlab-Event", "Push Hook")
+	req.Header.Set("X-Gitlab-Token", "not-the-secret")
+	rec := httptest.NewRecorder()
+	plg.HandleGitLabWebhook(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestProcessMergeRequestEvent(t *testing.T) {
+	type Fixture struct {
+		Event        *gitlab.MergeEvent
+		ListResponse []*v1.JobStatus
+	}
+
+	fs, err := filepath.Glob("fixtures/processMergeRequestEvent_*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fn := range fs {
+		t.Run(fn, func(t *testing.T) {
+			fc, err := ioutil.ReadFile(fn)
+			if err != nil {
+				t.Fatalf("cannot read %s: %v", fn, err)
+			}
+			var fixture Fixture
+			err = json.Unmarshal(fc, &fixture)
+			if err != nil {
+				t.Fatalf("cannot unmarshal %s: %v", fn, err)
+			}
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			var startReq *v1.StartJobRequest2
+			client := mock.NewMockWerftServiceClient(mockCtrl)
+			client.EXPECT().ListJobs(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(ctx context.Context, in *v1.ListJobsRequest) (*v1.ListJobsResponse, error) {
+					return &v1.ListJobsResponse{Result: fixture.ListResponse}, nil
+				})
+			client.EXPECT().StartJob2(gomock.Any(), gomock.Any(), gomock.Any()).
+				AnyTimes().
+				DoAndReturn(func(ctx context.Context, creq *v1.StartJobRequest2) (*v1.StartJobResponse, error) {
+					startReq = creq
+					return startJobResponse(ctx, creq)
+				})
+
+			plg := &gitlabTriggerPlugin{
+				Werft:    client,
+				Config:   &Config{},
+				testMode: true,
+			}
+			if fixture.Event == nil {
+				t.Fatal("broken fixture: no event")
+			}
+			plg.processMergeRequestEvent(context.Background(), fixture.Event)
+
+			checkGolden(t, fn, startReq)
+		})
+	}
+}
+
+func TestUpdateGitLabStatus(t *testing.T) {
+	type status struct {
+		Path        string `json:"-"`
+		State       string `json:"state"`
+		Ref         string `json:"ref"`
+		Name        string `json:"name"`
+		TargetURL   string `json:"target_url"`
+		Description string `json:"description"`
+	}
+
+	var act []status
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v4/" {
+			// go-gitlab probes the API to configure its rate limiter
+			return
+		}
+		var s status
+		err := json.NewDecoder(r.Body).Decode(&s)
+		if err != nil {
+			t.Errorf("cannot decode status: %v", err)
+		}
+		s.Path = r.Method + " " + r.URL.EscapedPath()
+		act = append(act, s)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer srv.Close()
+
+	gl, err := gitlab.NewClient("token", gitlab.WithBaseURL(srv.URL), gitlab.WithoutRetries())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plg := &gitlabTriggerPlugin{
+		Config: &Config{BaseURL: "https://werft.example.com"},
+		GitLab: gl,
+	}
+
+	jobs := []*v1.JobStatus{
+		{
+			Name: "no-annotation.1",
+			Metadata: &v1.JobMetadata{
+				Repository:  &v1.Repository{Owner: "csweichel", Repo: "test-repo", Ref: "refs/heads/main", Revision: "rev"},
+				JobSpecName: "build",
+			},
+			Phase:      v1.JobPhase_PHASE_RUNNING,
+			Conditions: &v1.JobConditions{},
+		},
+		{
+			Name: "test-repo-main.1",
+			Metadata: &v1.JobMetadata{
+				Repository:  &v1.Repository{Owner: "werft/plugins", Repo: "test-repo", Ref: "refs/heads/main", Revision: "rev"},
+				JobSpecName: "build",
+				Annotations: []*v1.Annotation{{Key: annotationStatusUpdate, Value: "werft/plugins/test-repo"}},
+			},
+			Phase:      v1.JobPhase_PHASE_RUNNING,
+			Conditions: &v1.JobConditions{},
+		},
+		{
+			Name: "test-repo-main.1",
+			Metadata: &v1.JobMetadata{
+				Repository:  &v1.Repository{Owner: "werft/plugins", Repo: "test-repo", Ref: "refs/heads/main", Revision: "rev"},
+				JobSpecName: "build",
+				Annotations: []*v1.Annotation{{Key: annotationStatusUpdate, Value: "werft/plugins/test-repo"}},
+			},
+			Phase:      v1.JobPhase_PHASE_DONE,
+			Conditions: &v1.JobConditions{Success: false},
+			Results: []*v1.JobResult{
+				{Type: "url", Payload: "https://preview.example.com", Description: "preview environment", Channels: []string{"gitlab"}},
+				{Type: "conclusion", Payload: "failure", Description: "3 tests failed", Channels: []string{"gitlab-check-tests"}},
+				{Type: "docker", Payload: "image:tag", Description: "not published to GitLab"},
+			},
+		},
+	}
+	for _, job := range jobs {
+		err = plg.updateGitLabStatus(job)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const path = "POST /api/v4/projects/werft%2Fplugins%2Ftest-repo/statuses/rev"
+	exp := []status{
+		{Path: path, State: "running", Ref: "main", Name: "ci/werft/build", TargetURL: "https://werft.example.com/job/test-repo-main.1", Description: "build is running"},
+		{Path: path, State: "failed", Ref: "main", Name: "ci/werft/build", TargetURL: "https://werft.example.com/job/test-repo-main.1", Description: "The build failed!"},
+		{Path: path, State: "success", Ref: "main", Name: "ci/werft/build/results/000", TargetURL: "https://preview.example.com", Description: "preview environment"},
+		{Path: path, State: "failed", Ref: "main", Name: "ci/werft/build/results/tests", TargetURL: "https://werft.example.com/job/test-repo-main.1", Description: "3 tests failed"},
+	}
+	if diff := cmp.Diff(exp, act); diff != "" {
+		t.Errorf("updateGitLabStatus() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	plugin "github.com/csweichel/werft/pkg/plugin/client"
+	"github.com/csweichel/werft/pkg/plugin/common"
+	"github.com/csweichel/werft/plugins/gitlab-repo/pkg/provider"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Config configures this plugin
+type Config struct {
+	// BaseURL is the URL of the GitLab installation, e.g. https://gitlab.example.com
+	BaseURL string `yaml:"baseURL"`
+	// Token is an access token with read_api and read_repository scope
+	Token string `yaml:"token"`
+
+	ContainerImage string `yaml:"containerImage"`
+}
+
+func main() {
+	plugin.Serve(&Config{},
+		plugin.WithRepositoryPlugin(&gitlabRepoPlugin{}),
+	)
+	fmt.Fprintln(os.Stderr, "shutting down")
+}
+
+type gitlabRepoPlugin struct{}
+
+func (*gitlabRepoPlugin) Run(ctx context.Context, config interface{}) (common.RepositoryPluginServer, error) {
+	cfg, ok := config.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("config has wrong type %s", reflect.TypeOf(config))
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://gitlab.com"
+	}
+
+	client, err := gitlab.NewClient(cfg.Token, gitlab.WithBaseURL(cfg.BaseURL))
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider.GitLabRepoServer{
+		Client: client,
+		Config: provider.Config{
+			BaseURL:        cfg.BaseURL,
+			Token:          cfg.Token,
+			ContainerImage: cfg.ContainerImage,
+		},
+	}, nil
+}
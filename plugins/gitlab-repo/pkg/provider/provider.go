@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/plugin/common"
+	ghprovider "github.com/csweichel/werft/plugins/github-repo/pkg/provider"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/xanzy/go-gitlab"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// defaultContainerImage is the image we use to clone the code, unless configured otherwise
+	defaultContainerImage = "alpine/git:latest"
+
+	// tokenUser is the username GitLab expects when cloning using an access token
+	tokenUser = "oauth2"
+)
+
+// GitLabRepoServer provides access to GitLab repos
+type GitLabRepoServer struct {
+	Client *gitlab.Client
+	Config Config
+}
+
+// Config configures the GitLabRepoServer
+type Config struct {
+	// BaseURL is the URL of the GitLab installation, e.g. https://gitlab.example.com
+	BaseURL string
+	// Token is used to clone the repository in the init container
+	Token          string
+	ContainerImage string
+}
+
+// Host returns the host of the GitLab installation, e.g. gitlab.example.com
+func (c Config) Host() string {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil || u.Host == "" {
+		return "gitlab.com"
+	}
+	return u.Host
+}
+
+// ProjectID produces the GitLab project ID (its path) of a repository. The owner can contain subgroups, e.g. group/subgroup.
+func ProjectID(repo *v1.Repository) string {
+	return repo.Owner + "/" + repo.Repo
+}
+
+// RepoHost returns the host which this plugins integrates with
+func (s *GitLabRepoServer) RepoHost(context.Context, *common.RepoHostRequest) (*common.RepoHostResponse, error) {
+	return &common.RepoHostResponse{
+		Host: s.Config.Host(),
+	}, nil
+}
+
+// Resolve resolves the repo's revision based on its ref(erence)
+func (s *GitLabRepoServer) Resolve(ctx context.Context, req *common.ResolveRequest) (*common.ResolveResponse, error) {
+	repo := req.Repository
+	if repo.Revision != "" {
+		return &common.ResolveResponse{Repository: repo}, nil
+	}
+	if repo.Ref == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "ref is empty")
+	}
+
+	// GitLab's API expects branch and tag names rather than refs
+	ref := strings.TrimPrefix(strings.TrimPrefix(repo.Ref, "refs/heads/"), "refs/tags/")
+	commit, _, err := s.Client.Commits.GetCommit(ProjectID(repo), ref, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, translateGitLabToGRPCError(err, repo.Revision, repo.Ref)
+	}
+	repo.Revision = commit.ID
+	log.WithField("ref", repo.Ref).WithField("rev", repo.Revision).Debug("resolved reference to revision")
+
+	return &common.ResolveResponse{
+		Repository: repo,
+	}, nil
+}
+
+// GetRemoteAnnotations extracts werft annotations from the description of the merge request the commit belongs to
+func (s *GitLabRepoServer) GetRemoteAnnotations(ctx context.Context, req *common.GetRemoteAnnotationsRequest) (*common.GetRemoteAnnotationsResponse, error) {
+	repo := req.Repository
+
+	res := make(map[string]string)
+	mrs, _, err := s.Client.Commits.ListMergeRequestsByCommit(ProjectID(repo), repo.Revision, gitlab.WithContext(ctx))
+	if err != nil {
+		log.WithField("ref", repo.Ref).WithField("rev", repo.Revision).WithError(err).Warn("cannot search for associated merge requests")
+		return &common.GetRemoteAnnotationsResponse{Annotations: res}, nil
+	}
+
+	var open []*gitlab.MergeRequest
+	for _, mr := range mrs {
+		if mr.State == "opened" {
+			open = append(open, mr)
+		}
+	}
+	if len(open) > 0 {
+		sort.Slice(open, func(i, j int) bool { return open[i].CreatedAt.Before(*open[j].CreatedAt) })
+		mr := open[0]
+		if len(open) > 1 {
+			log.WithField("ref", repo.Ref).WithField("rev", repo.Revision).WithField("mr", mr.WebURL).Warn("found multiple open merge requests - using oldest one")
+		}
+
+		for k, v := range ghprovider.ParseAnnotations(mr.Description) {
+			res[k] = v
+		}
+	}
+	return &common.GetRemoteAnnotationsResponse{
+		Annotations: res,
+	}, nil
+}
+
+// ContentInitContainer produces the init container YAML required to initialize
+// the build context from this repository in /workspace.
+func (s *GitLabRepoServer) ContentInitContainer(ctx context.Context, req *common.ContentInitContainerRequest) (*common.ContentInitContainerResponse, error) {
+	image := s.Config.ContainerImage
+	if image == "" {
+		image = defaultContainerImage
+	}
+
+	var (
+		repo     = req.Repository
+		cloneURL = strings.TrimSuffix(s.Config.BaseURL, "/") + "/" + ProjectID(repo) + ".git"
+	)
+
+	cloneDir := "."
+	if len(req.Paths) > 0 {
+		cloneDir = fmt.Sprintf("clone-%d-%d", time.Now().Unix(), rand.Int())
+	}
+
+	cloneCmd := "git clone"
+	if s.Config.Token != "" {
+		cloneCmd = "git clone -c \"credential.helper=/bin/sh -c 'echo username=$GLUSER_SECRET; echo password=$GLPASS_SECRET'\""
+	}
+	cloneTarget := repo.Ref
+	if repo.Revision != "" {
+		cloneTarget = repo.Revision
+	}
+	cloneCmd = fmt.Sprintf("%s %s %s && cd %s && git checkout %s && cd -", cloneCmd, cloneURL, cloneDir, cloneDir, cloneTarget)
+
+	if len(req.Paths) > 0 {
+		var cmds []string
+		cmds = append(cmds, cloneCmd)
+		for _, p := range req.Paths {
+			cmds = append(cmds, fmt.Sprintf("rm -rf %s", p))
+			cmds = append(cmds, fmt.Sprintf("cp -Rf %s %s", filepath.Join(cloneDir, p), p))
+		}
+		cmds = append(cmds, fmt.Sprintf("rm -rf %s", cloneDir))
+		cloneCmd = strings.Join(cmds, " && ")
+	}
+
+	var env []corev1.EnvVar
+	if s.Config.Token != "" {
+		env = []corev1.EnvVar{
+			{
+				Name:  "GLUSER_SECRET",
+				Value: tokenUser,
+			},
+			{
+				Name:  "GLPASS_SECRET",
+				Value: s.Config.Token,
+			},
+		}
+	}
+
+	nme := fmt.Sprintf("glco-%d-%d", time.Now().UnixMilli(), rand.Int())
+	c := []corev1.Container{
+		{
+			Name:  nme,
+			Image: image,
+			Command: []string{
+				"sh", "-c",
+				cloneCmd,
+			},
+			Env:        env,
+			WorkingDir: "/workspace",
+		},
+	}
+	buf := bytes.NewBuffer(nil)
+	err := gob.NewEncoder(buf).Encode(c)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot serialize init container: %q", err)
+	}
+
+	return &common.ContentInitContainerResponse{
+		Container: buf.Bytes(),
+	}, nil
+}
+
+// Download downloads a file from the repository.
+func (s *GitLabRepoServer) Download(ctx context.Context, req *common.DownloadRequest) (*common.DownloadResponse, error) {
+	rev := req.Repository.Revision
+	res, _, err := s.Client.RepositoryFiles.GetRawFile(ProjectID(req.Repository), req.Path, &gitlab.GetRawFileOptions{
+		Ref: &rev,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, translateGitLabToGRPCError(err, req.Repository.Revision, req.Repository.Ref)
+	}
+	return &common.DownloadResponse{
+		Content: res,
+	}, nil
+}
+
+// ListFiles lists all files in a directory.
+func (s *GitLabRepoServer) ListFiles(ctx context.Context, req *common.ListFilesRequest) (*common.ListFilesReponse, error) {
+	var (
+		rev  = req.Repository.Revision
+		path = req.Path
+		opts = &gitlab.ListTreeOptions{
+			ListOptions: gitlab.ListOptions{PerPage: 100},
+			Path:        &path,
+			Ref:         &rev,
+		}
+		res []string
+	)
+	for {
+		nodes, resp, err := s.Client.Repositories.ListTree(ProjectID(req.Repository), opts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, translateGitLabToGRPCError(err, req.Repository.Revision, req.Repository.Ref)
+		}
+		for _, n := range nodes {
+			if n.Type != "blob" {
+				continue
+			}
+			res = append(res, n.Path)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return &common.ListFilesReponse{Paths: res}, nil
+}
+
+// ChangedFiles lists the files which changed between a base revision and the revision of the repository.
+// Renames count as changes of the old and new path.
+func (s *GitLabRepoServer) ChangedFiles(ctx context.Context, req *common.ChangedFilesRequest) (*common.ChangedFilesResponse, error) {
+	var (
+		repo  = req.Repository
+		diffs []*gitlab.Diff
+	)
+	if req.BaseRevision == "" {
+		opts := &gitlab.GetCommitDiffOptions{PerPage: 100}
+		for {
+			page, resp, err := s.Client.Commits.GetCommitDiff(ProjectID(repo), repo.Revision, opts, gitlab.WithContext(ctx))
+			if err != nil {
+				return nil, translateGitLabToGRPCError(err, repo.Revision, repo.Ref)
+			}
+			diffs = append(diffs, page...)
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+	} else {
+		cmp, _, err := s.Client.Repositories.Compare(ProjectID(repo), &gitlab.CompareOptions{
+			From: &req.BaseRevision,
+			To:   &repo.Revision,
+		}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, translateGitLabToGRPCError(err, repo.Revision, repo.Ref)
+		}
+		diffs = cmp.Diffs
+	}
+
+	res := make([]string, 0, len(diffs))
+	for _, d := range diffs {
+		res = append(res, d.NewPath)
+		if d.OldPath != "" && d.OldPath != d.NewPath {
+			res = append(res, d.OldPath)
+		}
+	}
+	return &common.ChangedFilesResponse{Paths: res}, nil
+}
+
+func translateGitLabToGRPCError(err error, rev, ref string) error {
+	if glerr, ok := err.(*gitlab.ErrorResponse); ok && glerr.Response.StatusCode == http.StatusNotFound {
+		msg := fmt.Sprintf("revision %s", rev)
+		if ref != "" {
+			msg = fmt.Sprintf("ref %s (revision %s)", ref, rev)
+		}
+		return status.Error(codes.NotFound, fmt.Sprintf("%s not found", msg))
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	v1 "github.com/csweichel/werft/pkg/api/v1"
+	"github.com/csweichel/werft/pkg/plugin/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xanzy/go-gitlab"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// interaction is a recorded GitLab API request and its response
+type interaction struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+	Raw     string            `json:"raw,omitempty"`
+}
+
+// newReplayServer starts a GitLab API server which replays the recorded interactions
+func newReplayServer(t *testing.T, fn string) *GitLabRepoServer {
+	fc, err := ioutil.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var interactions []interaction
+	err = json.Unmarshal(fc, &interactions)
+	if err != nil {
+		t.Fatalf("cannot unmarshal %s: %v", fn, err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		url := r.URL.EscapedPath()
+		if url == "/api/v4/" {
+			// the client probes the API when configuring its rate limiter
+			return
+		}
+		if q := r.URL.Query(); len(q) > 0 {
+			url += "?" + q.Encode()
+		}
+		for _, i := range interactions {
+			if i.Method != r.Method || i.URL != url {
+				continue
+			}
+			for k, v := range i.Headers {
+				w.Header().Set(k, v)
+			}
+			if i.Raw != "" {
+				w.WriteHeader(i.Status)
+				w.Write([]byte(i.Raw))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(i.Status)
+			w.Write(i.Body)
+			return
+		}
+		t.Errorf("unexpected request: %s %s", r.Method, url)
+		http.Error(w, "unexpected request", http.StatusTeapot)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(srv.URL), gitlab.WithoutRetries())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &GitLabRepoServer{
+		Client: client,
+		Config: Config{BaseURL: srv.URL},
+	}
+}
+
+func testRepo() *v1.Repository {
+	return &v1.Repository{
+		Host:  "gitlab.example.com",
+		Owner: "csweichel",
+		Repo:  "werft-test",
+	}
+}
+
+func TestResolve(t *testing.T) {
+	srv := newReplayServer(t, "fixtures/gitlab-api.json")
+
+	tests := []struct {
+		Ref  string
+		Rev  string
+		Code codes.Code
+	}{
+		{Ref: "refs/heads/main", Rev: "6104942438c14ec7bd21c6cd5bd995272b3faff6"},
+		{Ref: "main", Rev: "6104942438c14ec7bd21c6cd5bd995272b3faff6"},
+		{Ref: "refs/tags/v1.0", Rev: "ae1d9fb46aa2b07ee9836d49862ec4e2c46fbbba"},
+		{Ref: "refs/heads/does-not-exist", Code: codes.NotFound},
+		{Ref: "", Code: codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		t.Run(test.Ref, func(t *testing.T) {
+			repo := testRepo()
+			repo.Ref = test.Ref
+			resp, err := srv.Resolve(context.Background(), &common.ResolveRequest{Repository: repo})
+			if code := status.Code(err); code != test.Code {
+				t.Fatalf("expected code %v, got %v", test.Code, err)
+			}
+			if err != nil {
+				return
+			}
+			if resp.Repository.Revision != test.Rev {
+				t.Errorf("expected revision %s, got %s", test.Rev, resp.Repository.Revision)
+			}
+		})
+	}
+}
+
+func TestContent(t *testing.T) {
+	var (
+		srv  = newReplayServer(t, "fixtures/gitlab-api.json")
+		ctx  = context.Background()
+		repo = testRepo()
+	)
+	repo.Revision = "6104942438c14ec7bd21c6cd5bd995272b3faff6"
+
+	dl, err := srv.Download(ctx, &common.DownloadRequest{Repository: repo, Path: ".werft/build.yaml"})
+	if err != nil {
+		t.Fatalf("cannot download: %v", err)
+	}
+	if exp := "pod:\n  containers:\n  - name: build\n    image: alpine:latest\n"; string(dl.Content) != exp {
+		t.Errorf("unexpected content: %q", string(dl.Content))
+	}
+	_, err = srv.Download(ctx, &common.DownloadRequest{Repository: repo, Path: "does-not-exist.yaml"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	ls, err := srv.ListFiles(ctx, &common.ListFilesRequest{Repository: repo, Path: ".werft"})
+	if err != nil {
+		t.Fatalf("cannot list files: %v", err)
+	}
+	sort.Strings(ls.Paths)
+	if diff := cmp.Diff([]string{".werft/build.yaml", ".werft/deploy.yaml"}, ls.Paths); diff != "" {
+		t.Errorf("ListFiles() mismatch (-want +got):\n%s", diff)
+	}
+
+	atns, err := srv.GetRemoteAnnotations(ctx, &common.GetRemoteAnnotationsRequest{Repository: repo})
+	if err != nil {
+		t.Fatalf("cannot get annotations: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"with-preview": "", "version": "1.2"}, atns.Annotations); diff != "" {
+		t.Errorf("GetRemoteAnnotations() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	srv := newReplayServer(t, "fixtures/gitlab-api.json")
+
+	tests := []struct {
+		Name        string
+		Base        string
+		Expectation []string
+	}{
+		{Name: "parent", Expectation: []string{".werft/build.yaml", "docs/README.md", "README.md"}},
+		{Name: "base", Base: "ae1d9fb46aa2b07ee9836d49862ec4e2c46fbbba", Expectation: []string{"main.go", ".werft/deploy.yaml"}},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			repo := testRepo()
+			repo.Revision = "6104942438c14ec7bd21c6cd5bd995272b3faff6"
+			resp, err := srv.ChangedFiles(context.Background(), &common.ChangedFilesRequest{Repository: repo, BaseRevision: test.Base})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, resp.Paths); diff != "" {
+				t.Errorf("ChangedFiles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
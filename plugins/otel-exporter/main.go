@@ -31,6 +31,7 @@ import (
 // Config configures this plugin
 type Config struct {
 	Filter   []string     `yaml:"filter"`
+	Query    string       `yaml:"query"`
 	Exporter OTelExporter `yaml:"exporter"`
 }
 
@@ -78,9 +79,13 @@ func (*otelExporterPlugin) Run(ctx context.Context, config interface{}, srv *cli
 	if err != nil {
 		return fmt.Errorf("cannot parse filter: %w", err)
 	}
+	query, err := filterexpr.ParseExpression(cfg.Query)
+	if err != nil {
+		return fmt.Errorf("cannot parse query: %w", err)
+	}
 
 	sub, err := srv.Subscribe(ctx, &v1.SubscribeRequest{
-		Filter: []*v1.FilterExpression{{Terms: filter}},
+		Filter: append([]*v1.FilterExpression{{Terms: filter}}, query...),
 	})
 	if err != nil {
 		return fmt.Errorf("cannot subscribe: %w", err)
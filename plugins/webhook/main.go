@@ -23,6 +23,7 @@ type Config struct {
 	Notifications []struct {
 		WebhookURL  string   `yaml:"url"`
 		Filter      []string `yaml:"filter"`
+		Query       string   `yaml:"query"`
 		Template    string   `yaml:"template"`
 		ContentType string   `yaml:"contentType"`
 	} `yaml:"notifications"`
@@ -48,6 +49,10 @@ func (*webhookPlugin) Run(ctx context.Context, config interface{}, srv *client.S
 		if err != nil {
 			log.WithError(err).Errorf("cannot parse filter for notification %d", idx)
 		}
+		query, err := filterexpr.ParseExpression(nf.Query)
+		if err != nil {
+			log.WithError(err).Errorf("cannot parse query for notification %d", idx)
+		}
 
 		tpl, err := template.New("tpl").Parse(nf.Template)
 		if err != nil {
@@ -59,7 +64,7 @@ func (*webhookPlugin) Run(ctx context.Context, config interface{}, srv *client.S
 			defer wg.Done()
 
 			sub, err := srv.Subscribe(ctx, &v1.SubscribeRequest{
-				Filter: []*v1.FilterExpression{{Terms: filter}},
+				Filter: append([]*v1.FilterExpression{{Terms: filter}}, query...),
 			})
 			if err != nil {
 				log.WithError(err).Errorf("cannot subscribe for notification %d", idx)
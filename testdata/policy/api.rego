@@ -35,6 +35,13 @@ allow {
     not input.message.sideload
 }
 
+# Allow team members to validate jobs
+allow {
+    is_team_member
+
+    input.method == "/v1.WerftService/ValidateJob"
+}
+
 # Allow team members to run previously started jobs
 allow {
     is_team_member
@@ -42,6 +49,60 @@ allow {
     input.method == "/v1.WerftService/StartFromPreviousJob"
 }
 
+# Allow team members to protect jobs from garbage collection
+allow {
+    is_team_member
+
+    input.method == "/v1.WerftService/PinJob"
+}
+allow {
+    is_team_member
+
+    input.method == "/v1.WerftService/UnpinJob"
+}
+
+# Allow team members to manage schedules. Schedules start their jobs without going through this policy,
+# hence they're subject to the same restrictions as StartGitHubJob: custom jobs and sideloading only off main.
+allow {
+    is_team_member
+
+    input.method == "/v1.WerftService/CreateSchedule"
+    not startswith(input.message.schedule.metadata.repository.ref, "refs/heads/main")
+}
+allow {
+    is_team_member
+
+    input.method == "/v1.WerftService/CreateSchedule"
+    not input.message.schedule.spec.Source.JobYaml
+    not input.message.schedule.spec.direct_sideload
+    not input.message.schedule.spec.repo_sideload
+}
+allow {
+    is_team_member
+
+    input.method == "/v1.WerftService/DeleteSchedule"
+}
+allow {
+    is_team_member
+
+    input.method == "/v1.WerftService/PauseSchedule"
+}
+allow {
+    input.method == "/v1.WerftService/ListSchedules"
+}
+
+# Allow authenticated users to approve or reject jobs. Werft itself checks that they are among a job's approvers.
+allow {
+    input.auth.known
+
+    input.method == "/v1.WerftService/ApproveJob"
+}
+allow {
+    input.auth.known
+
+    input.method == "/v1.WerftService/RejectJob"
+}
+
 is_team_member {
     input.auth.known
     endswith(input.auth.emails[_], "@gitpod.io")